@@ -1,11 +1,15 @@
 package api
 
 import (
+	"strings"
 	"upm/udevs_go_auth_service/api/docs"
 	"upm/udevs_go_auth_service/api/handlers"
+	"upm/udevs_go_auth_service/api/http"
 	"upm/udevs_go_auth_service/config"
+	"upm/udevs_go_auth_service/pkg/revocation"
 
 	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/security"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
 )
@@ -13,7 +17,7 @@ import (
 // SetUpRouter godoc
 // @description This is a api gateway
 // @termsOfService https://udevs.io
-func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
+func SetUpRouter(h handlers.Handler, cfg config.Config, denyList *revocation.DenyList) (r *gin.Engine) {
 	r = gin.New()
 
 	r.Use(gin.Logger(), gin.Recovery())
@@ -24,6 +28,7 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 	docs.SwaggerInfo.Schemes = []string{cfg.HTTPScheme}
 
 	r.Use(customCORSMiddleware())
+	r.Use(revokedTokenMiddleware(cfg, denyList))
 
 	r.GET("/ping", h.Ping)
 	r.GET("/config", h.GetConfig)
@@ -54,6 +59,7 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 	r.POST("/client-type", h.CreateClientType)
 	r.GET("/client-type", h.GetClientTypeList)
 	r.GET("/client-type/:client-type-id", h.GetClientTypeByID)
+	r.GET("/client-type/:client-type-id/user-info-schema", h.GetUserInfoSchema)
 	r.PUT("/client-type", h.UpdateClientType)
 	r.DELETE("/client-type/:client-type-id", h.DeleteClientType)
 
@@ -65,6 +71,8 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 	r.POST("/relation", h.AddRelation)
 	r.PUT("/relation", h.UpdateRelation)
 	r.DELETE("/relation/:relation-id", h.RemoveRelation)
+	r.GET("/relation/:relation-id/subtree", h.GetRelationSubtree)
+	r.PUT("/relation/:relation-id/parent", h.MoveRelation)
 
 	r.POST("/user-info-field", h.AddUserInfoField)
 	r.PUT("/user-info-field", h.UpdateUserInfoField)
@@ -82,6 +90,10 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 	r.GET("/permission/:permission-id", h.GetPermissionByID)
 	r.PUT("/permission", h.UpdatePermission)
 	r.DELETE("/permission/:permission-id", h.DeletePermission)
+	r.POST("/permission_generated", h.PermissionGeneratedPermission)
+	r.GET("/rbac-config/:client-platform-id", h.ExportRBACConfig)
+	r.PUT("/rbac-config/:client-platform-id", h.ApplyRBACConfig)
+	r.GET("/permission-matrix/:client-platform-id", h.GetPermissionMatrix)
 
 	r.POST("/upsert-scope", h.UpsertScope)
 
@@ -92,6 +104,15 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 	r.POST("/role-permission/many", h.AddRolePermissions)
 	r.DELETE("/role-permission", h.RemoveRolePermission)
 
+	r.POST("/role-parent", h.AddRoleParent)
+	r.DELETE("/role-parent", h.RemoveRoleParent)
+
+	r.POST("/deny-rule", h.CreateDenyRule)
+	r.GET("/deny-rule", h.GetDenyRuleList)
+	r.DELETE("/deny-rule/:deny-rule-id", h.DeleteDenyRule)
+
+	r.POST("/explain-access", h.ExplainAccess)
+
 	r.POST("/user", h.CreateUser)
 	r.GET("/user", h.GetUserList)
 	r.GET("/user/:user-id", h.GetUserByID)
@@ -99,6 +120,19 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 	r.DELETE("/user/:user-id", h.DeleteUser)
 	r.PUT("/user/reset-password", h.ResetPassword)
 	r.POST("/user/send-message", h.SendMessageToUserEmail)
+	r.POST("/user/import", h.ImportUsers)
+	r.GET("/user-export", h.ExportUsers)
+	r.PUT("/user-state/:user-id/activate", h.ActivateUser)
+	r.PUT("/user-state/:user-id/suspend", h.SuspendUser)
+	r.PUT("/user-state/:user-id/lock", h.LockUser)
+	r.PUT("/user-state/:user-id/reinstate", h.ReinstateUser)
+	r.PUT("/user-state/:user-id/restore", h.RestoreUser)
+	r.GET("/user-state/:user-id/history", h.GetUserStateHistory)
+	r.POST("/user-data/:user-id/export", h.ExportUserData)
+	r.POST("/user-data/:user-id/erase", h.EraseUserData)
+	r.POST("/user-contact/:user-id/verification", h.SendContactVerification)
+	r.PUT("/user-contact/:user-id/verify", h.VerifyContact)
+	r.POST("/data-subject-receipt/verify", h.VerifyDataSubjectReceipt)
 
 	r.POST("/integration", h.CreateIntegration)
 	r.GET("/integration", h.GetIntegrationList)
@@ -111,6 +145,15 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 
 	r.POST("/user-relation", h.AddUserRelation)
 	r.DELETE("/user-relation", h.RemoveUserRelation)
+	r.GET("/user-relation/:user-id/effective", h.GetUserEffectiveRelations)
+
+	r.POST("/user-role", h.AddUserRole)
+	r.DELETE("/user-role", h.RemoveUserRole)
+	r.GET("/user-role/:user-id", h.GetUserRoleList)
+
+	r.POST("/user-permission", h.GrantUserPermission)
+	r.DELETE("/user-permission/:user-permission-id", h.RevokeUserPermission)
+	r.GET("/user-permission-list/:user-id", h.GetUserPermissionList)
 
 	r.POST("/upsert-user-info/:user-id", h.UpsertUserInfo)
 
@@ -118,6 +161,20 @@ func SetUpRouter(h handlers.Handler, cfg config.Config) (r *gin.Engine) {
 	r.DELETE("/logout", h.Logout)
 	r.PUT("/refresh", h.RefreshToken)
 	r.POST("/has-acess", h.HasAccess)
+	r.POST("/reauth/passcode", h.SendReauthPasscode)
+	r.PUT("/reauth", h.Reauthenticate)
+	r.PUT("/credential/password", h.ChangePassword)
+	r.POST("/credential/contact/passcode", h.SendContactChangePasscode)
+	r.PUT("/credential/contact", h.ChangeContact)
+	r.PUT("/credential/login", h.ChangeLogin)
+	r.GET("/revoked-session", h.GetRevokedSessionList)
+
+	r.POST("/passkey/registration/begin", h.BeginPasskeyRegistration)
+	r.POST("/passkey/registration/finish", h.FinishPasskeyRegistration)
+	r.POST("/passkey/login/begin", h.BeginPasskeyLogin)
+	r.POST("/passkey/login/finish", h.FinishPasskeyLogin)
+	r.POST("/passkey/list", h.GetPasskeyList)
+	r.DELETE("/passkey", h.RemovePasskey)
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	return
@@ -139,3 +196,27 @@ func customCORSMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// revokedTokenMiddleware rejects bearer tokens of logged out sessions using the in-memory deny list,
+// so the request doesn't reach the database
+func revokedTokenMiddleware(cfg config.Config, denyList *revocation.DenyList) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+		if token == "" {
+			c.Next()
+			return
+		}
+
+		tokenInfo, err := security.ParseClaims(token, cfg.SecretKey)
+		if err == nil && denyList.IsRevoked(tokenInfo.ID) {
+			c.AbortWithStatusJSON(http.Unauthorized.Code, http.Response{
+				Status:      http.Unauthorized.Status,
+				Description: http.Unauthorized.Description,
+				Data:        "session has been revoked",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -340,6 +340,41 @@ func (h *Handler) GetClientTypeByID(c *gin.Context) {
 	h.handleResponse(c, http.OK, resp)
 }
 
+// GetUserInfoSchema godoc
+// @ID get_user_info_schema
+// @Router /client-type/{client-type-id}/user-info-schema [GET]
+// @Summary Get UserInfo Schema
+// @Description Returns user info field definitions of the client type compiled into a JSON Schema for forms
+// @Tags ClientType
+// @Accept json
+// @Produce json
+// @Param client-type-id path string true "client-type-id"
+// @Success 200 {object} http.Response{data=auth_service.UserInfoSchema} "UserInfoSchemaBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetUserInfoSchema(c *gin.Context) {
+	client_typeID := c.Param("client-type-id")
+
+	if !util.IsValidUUID(client_typeID) {
+		h.handleResponse(c, http.InvalidArgument, "client_type id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.ClientService().GetUserInfoSchema(
+		c.Request.Context(),
+		&auth_service.ClientTypePrimaryKey{
+			Id: client_typeID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
 // UpdateClientType godoc
 // @ID update_client_type
 // @Router /client-type [PUT]
@@ -654,6 +689,82 @@ func (h *Handler) RemoveRelation(c *gin.Context) {
 	h.handleResponse(c, http.NoContent, resp)
 }
 
+// GetRelationSubtree godoc
+// @ID get_relation_subtree
+// @Router /relation/{relation-id}/subtree [GET]
+// @Summary Get Relation Subtree
+// @Description Get Relation with all its descendants
+// @Tags Relation
+// @Accept json
+// @Produce json
+// @Param relation-id path string true "relation-id"
+// @Success 200 {object} http.Response{data=auth_service.GetRelationSubtreeResponse} "GetRelationSubtreeResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetRelationSubtree(c *gin.Context) {
+	relationID := c.Param("relation-id")
+
+	if !util.IsValidUUID(relationID) {
+		h.handleResponse(c, http.InvalidArgument, "relation id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.ClientService().GetRelationSubtree(
+		c.Request.Context(),
+		&auth_service.RelationPrimaryKey{
+			Id: relationID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// MoveRelation godoc
+// @ID move_relation
+// @Router /relation/{relation-id}/parent [PUT]
+// @Summary Move Relation
+// @Description Move Relation with its subtree under another parent, an empty parent_id makes it a root
+// @Tags Relation
+// @Accept json
+// @Produce json
+// @Param relation-id path string true "relation-id"
+// @Param relation body auth_service.MoveRelationRequest true "MoveRelationRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.Relation} "Relation data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) MoveRelation(c *gin.Context) {
+	var relation auth_service.MoveRelationRequest
+
+	err := c.ShouldBindJSON(&relation)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	relation.Id = c.Param("relation-id")
+	if !util.IsValidUUID(relation.Id) {
+		h.handleResponse(c, http.InvalidArgument, "relation id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.ClientService().MoveRelation(
+		c.Request.Context(),
+		&relation,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
 // AddUserInfoField godoc
 // @ID create_user_info_field
 // @Router /user-info-field [POST]
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangePassword godoc
+// @ID change_password
+// @Router /credential/password [PUT]
+// @Summary Change Password
+// @Description Replaces the password of the session user, the current password is required and the old email is notified
+// @Tags Session
+// @Accept json
+// @Produce json
+// @Param credential body auth_service.ChangePasswordRequest true "ChangePasswordRequestBody"
+// @Success 204
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ChangePassword(c *gin.Context) {
+	var credential auth_service.ChangePasswordRequest
+
+	err := c.ShouldBindJSON(&credential)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().ChangePassword(
+		c.Request.Context(),
+		&credential,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.NoContent, resp)
+}
+
+// SendContactChangePasscode godoc
+// @ID send_contact_change_passcode
+// @Router /credential/contact/passcode [POST]
+// @Summary Send Contact Change Passcode
+// @Description Sends a passcode to the new email or phone of the session user, the current password is required
+// @Tags Session
+// @Accept json
+// @Produce json
+// @Param credential body auth_service.SendContactChangePasscodeRequest true "SendContactChangePasscodeRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.SendContactChangePasscodeResponse} "Passcode data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) SendContactChangePasscode(c *gin.Context) {
+	var credential auth_service.SendContactChangePasscodeRequest
+
+	err := c.ShouldBindJSON(&credential)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().SendContactChangePasscode(
+		c.Request.Context(),
+		&credential,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// ChangeContact godoc
+// @ID change_contact
+// @Router /credential/contact [PUT]
+// @Summary Change Contact
+// @Description Sets the email or phone the passcode was sent to, the old email is notified
+// @Tags Session
+// @Accept json
+// @Produce json
+// @Param credential body auth_service.ChangeContactRequest true "ChangeContactRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ChangeContact(c *gin.Context) {
+	var credential auth_service.ChangeContactRequest
+
+	err := c.ShouldBindJSON(&credential)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().ChangeContact(
+		c.Request.Context(),
+		&credential,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// ChangeLogin godoc
+// @ID change_login
+// @Router /credential/login [PUT]
+// @Summary Change Login
+// @Description Replaces the login of the session user, the current password is required and the email is notified
+// @Tags Session
+// @Accept json
+// @Produce json
+// @Param credential body auth_service.ChangeLoginRequest true "ChangeLoginRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ChangeLogin(c *gin.Context) {
+	var credential auth_service.ChangeLoginRequest
+
+	err := c.ShouldBindJSON(&credential)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().ChangeLogin(
+		c.Request.Context(),
+		&credential,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
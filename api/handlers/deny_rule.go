@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/util"
+)
+
+// CreateDenyRule godoc
+// @ID create_deny_rule
+// @Router /deny-rule [POST]
+// @Summary Create DenyRule
+// @Description Denies a permission or a scope pattern to a role or a user whatever allows it, a pattern may use * for a segment and a trailing **
+// @Tags DenyRule
+// @Accept json
+// @Produce json
+// @Param deny-rule body auth_service.CreateDenyRuleRequest true "CreateDenyRuleRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.DenyRule} "DenyRule data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) CreateDenyRule(c *gin.Context) {
+	var denyRule auth_service.CreateDenyRuleRequest
+
+	err := c.ShouldBindJSON(&denyRule)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.PermissionService().CreateDenyRule(
+		c.Request.Context(),
+		&denyRule,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// DeleteDenyRule godoc
+// @ID delete_deny_rule
+// @Router /deny-rule/{deny-rule-id} [DELETE]
+// @Summary Delete DenyRule
+// @Description Delete DenyRule
+// @Tags DenyRule
+// @Accept json
+// @Produce json
+// @Param deny-rule-id path string true "deny-rule-id"
+// @Success 204
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) DeleteDenyRule(c *gin.Context) {
+	denyRuleID := c.Param("deny-rule-id")
+
+	if !util.IsValidUUID(denyRuleID) {
+		h.handleResponse(c, http.InvalidArgument, "deny rule id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.PermissionService().DeleteDenyRule(
+		c.Request.Context(),
+		&auth_service.DenyRulePrimaryKey{
+			Id: denyRuleID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.NoContent, resp)
+}
+
+// GetDenyRuleList godoc
+// @ID get_deny_rule_list
+// @Router /deny-rule [GET]
+// @Summary Get DenyRule List
+// @Description Get the deny rules of the client platform, optionally of a role or a user only
+// @Tags DenyRule
+// @Accept json
+// @Produce json
+// @Param client-platform-id query string true "client-platform-id"
+// @Param role-id query string false "role-id"
+// @Param user-id query string false "user-id"
+// @Success 200 {object} http.Response{data=auth_service.GetDenyRuleListResponse} "GetDenyRuleListResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetDenyRuleList(c *gin.Context) {
+	clientPlatformID := c.Query("client-platform-id")
+
+	if !util.IsValidUUID(clientPlatformID) {
+		h.handleResponse(c, http.InvalidArgument, "client platform id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.PermissionService().GetDenyRuleList(
+		c.Request.Context(),
+		&auth_service.GetDenyRuleListRequest{
+			ClientPlatformId: clientPlatformID,
+			RoleId:           c.Query("role-id"),
+			UserId:           c.Query("user-id"),
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExplainAccess godoc
+// @ID explain_access
+// @Router /explain-access [POST]
+// @Summary Explain Access
+// @Description Decides a request of a user or a role and returns why, with the scope permissions, the roles granting them, deny rules, inactive assignments and grants and the state of the user, add_role_ids and remove_role_ids try role changes out without saving them
+// @Tags Permission
+// @Accept json
+// @Produce json
+// @Param explain-access body auth_service.ExplainAccessRequest true "ExplainAccessRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.ExplainAccessResponse} "ExplainAccessResponseBody"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ExplainAccess(c *gin.Context) {
+	var explainAccess auth_service.ExplainAccessRequest
+
+	err := c.ShouldBindJSON(&explainAccess)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.PermissionService().ExplainAccess(
+		c.Request.Context(),
+		&explainAccess,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
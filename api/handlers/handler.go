@@ -1,14 +1,19 @@
 package handlers
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 	"upm/udevs_go_auth_service/api/http"
 	"upm/udevs_go_auth_service/config"
+	"upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/grpc/client"
 
 	"github.com/saidamir98/udevs_pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type Handler struct {
@@ -69,3 +74,32 @@ func (h *Handler) getLimitParam(c *gin.Context) (offset int, err error) {
 	offsetStr := c.DefaultQuery("limit", h.cfg.DefaultLimit)
 	return strconv.Atoi(offsetStr)
 }
+
+// getUserInfoFilters parses user-info-filter query params written as field:operator:value,
+// the value is read as JSON when it is valid JSON and as a string otherwise
+func (h *Handler) getUserInfoFilters(c *gin.Context) (filters []*auth_service.UserInfoFilter, err error) {
+	for _, param := range c.QueryArray("user-info-filter") {
+		parts := strings.SplitN(param, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("user-info-filter %s must be written as field:operator:value", param)
+		}
+
+		operator, ok := auth_service.UserInfoFilterOperators_value[strings.ToUpper(parts[1])]
+		if !ok {
+			return nil, fmt.Errorf("user-info-filter %s has unknown operator %s", param, parts[1])
+		}
+
+		value := &structpb.Value{}
+		if err := protojson.Unmarshal([]byte(parts[2]), value); err != nil {
+			value = structpb.NewStringValue(parts[2])
+		}
+
+		filters = append(filters, &auth_service.UserInfoFilter{
+			FieldName: parts[0],
+			Operator:  auth_service.UserInfoFilterOperators(operator),
+			Value:     value,
+		})
+	}
+
+	return filters, nil
+}
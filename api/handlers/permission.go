@@ -632,18 +632,104 @@ func (h *Handler) RemoveRolePermission(c *gin.Context) {
 	h.handleResponse(c, http.NoContent, resp)
 }
 
-// AddPermissionScope godoc
+// AddRoleParent godoc
+// @ID add_role_parent
+// @Router /role-parent [POST]
+// @Summary Add Role Parent
+// @Description Makes the role inherit the permissions of the parent and its ancestors, a parent making a cycle is rejected
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param role-parent body auth_service.RoleParent true "RoleParentBody"
+// @Success 201 {object} http.Response{data=auth_service.GetRoleByIdResponse} "Role data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) AddRoleParent(c *gin.Context) {
+	var roleParent auth_service.RoleParent
+
+	err := c.ShouldBindJSON(&roleParent)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.PermissionService().AddRoleParent(
+		c.Request.Context(),
+		&roleParent,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// RemoveRoleParent godoc
+// @ID remove_role_parent
+// @Router /role-parent [DELETE]
+// @Summary Remove Role Parent
+// @Description Stops the role inheriting from the parent
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Param role-parent body auth_service.RoleParent true "RoleParentBody"
+// @Success 200 {object} http.Response{data=auth_service.GetRoleByIdResponse} "Role data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) RemoveRoleParent(c *gin.Context) {
+	var roleParent auth_service.RoleParent
+
+	err := c.ShouldBindJSON(&roleParent)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.PermissionService().RemoveRoleParent(
+		c.Request.Context(),
+		&roleParent,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// PermissionGeneratedPermission godoc
 // @ID permission_generated
 // @Router /permission_generated [POST]
 // @Summary Generate Permission
-// @Description Generate Permission
+// @Description Creates the permissions of the tree missing from the client platform and syncs their scopes, other fields are left as they are, permissions missing from the tree are deleted if prune is set
 // @Tags Permission
 // @Accept json
 // @Produce json
-// @Param permission-scope body auth_service.PermissionGenerated true "AddPermissionScopeRequestBody"
-// @Success 201 {object} http.Response{data=auth_service.PermissionScope} "PermissionScope data"
+// @Param permission-generated body auth_service.PermissionGenerated true "PermissionGeneratedRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.PermissionGeneratedResponse} "PermissionGeneratedResponseBody"
 // @Response 400 {object} http.Response{data=string} "Bad Request"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) PermissionGeneratedPermission(c *gin.Context) {
+	var permissionGenerated auth_service.PermissionGenerated
+
+	err := c.ShouldBindJSON(&permissionGenerated)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
 
+	resp, err := h.services.PermissionService().PermissionList(
+		c.Request.Context(),
+		&permissionGenerated,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
 }
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/util"
+)
+
+// GetPermissionMatrix godoc
+// @ID get_permission_matrix
+// @Router /permission-matrix/{client-platform-id} [GET]
+// @Summary Get Permission Matrix
+// @Description Shows the permissions and optionally the scopes every role of the client platform has, inherited ones and deny rules of the roles included, use format CSV to download it
+// @Tags Permission
+// @Produce json
+// @Produce text/csv
+// @Param client-platform-id path string true "client-platform-id"
+// @Param include-scopes query boolean false "include-scopes"
+// @Param format query string false "JSON or CSV, JSON by default"
+// @Success 200 {object} http.Response{data=auth_service.GetPermissionMatrixResponse} "GetPermissionMatrixResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetPermissionMatrix(c *gin.Context) {
+	clientPlatformID := c.Param("client-platform-id")
+
+	if !util.IsValidUUID(clientPlatformID) {
+		h.handleResponse(c, http.InvalidArgument, "client platform id is an invalid uuid")
+		return
+	}
+
+	includeScopes, err := strconv.ParseBool(c.DefaultQuery("include-scopes", "false"))
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	req := &auth_service.GetPermissionMatrixRequest{
+		ClientPlatformId: clientPlatformID,
+		IncludeScopes:    includeScopes,
+	}
+
+	if strings.ToUpper(c.DefaultQuery("format", "JSON")) == "CSV" {
+		resp, err := h.services.PermissionService().ExportPermissionMatrix(
+			c.Request.Context(),
+			req,
+		)
+
+		if err != nil {
+			h.handleResponse(c, http.GRPCError, err.Error())
+			return
+		}
+
+		c.Header("Content-Disposition", `attachment; filename="permission_matrix.csv"`)
+		c.Data(http.OK.Code, "text/csv", resp.Csv)
+		return
+	}
+
+	resp, err := h.services.PermissionService().GetPermissionMatrix(
+		c.Request.Context(),
+		req,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
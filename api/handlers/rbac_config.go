@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"strconv"
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/util"
+)
+
+// ExportRBACConfig godoc
+// @ID export_rbac_config
+// @Router /rbac-config/{client-platform-id} [GET]
+// @Summary Export RBAC Config
+// @Description Downloads the permission tree, scopes, roles and role permissions of the client platform as yaml
+// @Tags Permission
+// @Produce application/x-yaml
+// @Param client-platform-id path string true "client-platform-id"
+// @Success 200 {file} file "rbac.yaml"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ExportRBACConfig(c *gin.Context) {
+	clientPlatformID := c.Param("client-platform-id")
+
+	if !util.IsValidUUID(clientPlatformID) {
+		h.handleResponse(c, http.InvalidArgument, "client platform id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.PermissionService().ExportRBACConfig(
+		c.Request.Context(),
+		&auth_service.ExportRBACConfigRequest{
+			ClientPlatformId: clientPlatformID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="rbac.yaml"`)
+	c.Data(http.OK.Code, "application/x-yaml", resp.Config)
+}
+
+// ApplyRBACConfig godoc
+// @ID apply_rbac_config
+// @Router /rbac-config/{client-platform-id} [PUT]
+// @Summary Apply RBAC Config
+// @Description Makes the client platform match the yaml of the body in one transaction, what is missing from it is deleted, use dry-run to get the plan only
+// @Tags Permission
+// @Accept application/x-yaml
+// @Produce json
+// @Param client-platform-id path string true "client-platform-id"
+// @Param dry-run query boolean false "dry-run"
+// @Param config body string true "yaml in the format of the export"
+// @Success 200 {object} http.Response{data=auth_service.ApplyRBACConfigResponse} "Plan"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ApplyRBACConfig(c *gin.Context) {
+	clientPlatformID := c.Param("client-platform-id")
+
+	if !util.IsValidUUID(clientPlatformID) {
+		h.handleResponse(c, http.InvalidArgument, "client platform id is an invalid uuid")
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry-run", "false"))
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	config, err := c.GetRawData()
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.PermissionService().ApplyRBACConfig(
+		c.Request.Context(),
+		&auth_service.ApplyRBACConfigRequest{
+			ClientPlatformId: clientPlatformID,
+			Config:           config,
+			DryRun:           dryRun,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"strconv"
 	"upm/udevs_go_auth_service/api/http"
 
 	"upm/udevs_go_auth_service/genproto/auth_service"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Login godoc
@@ -136,6 +139,256 @@ func (h *Handler) HasAccess(c *gin.Context) {
 		&login,
 	)
 
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			h.handleResponse(c, http.StepUpRequired, err.Error())
+			return
+		}
+
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// SendReauthPasscode godoc
+// @ID send_reauth_passcode
+// @Router /reauth/passcode [POST]
+// @Summary Send Reauth Passcode
+// @Description Sends a one time code to confirm the current session again
+// @Tags Session
+// @Accept json
+// @Produce json
+// @Param reauth body auth_service.SendReauthPasscodeRequest true "SendReauthPasscodeRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.SendReauthPasscodeResponse} "Passcode data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) SendReauthPasscode(c *gin.Context) {
+	var reauth auth_service.SendReauthPasscodeRequest
+
+	err := c.ShouldBindJSON(&reauth)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().SendReauthPasscode(
+		c.Request.Context(),
+		&reauth,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// Reauthenticate godoc
+// @ID reauthenticate
+// @Router /reauth [PUT]
+// @Summary Reauthenticate
+// @Description Confirms the current session with password or passcode without creating a new one
+// @Tags Session
+// @Accept json
+// @Produce json
+// @Param reauth body auth_service.ReauthenticateRequest true "ReauthenticateRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.Session} "Session data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) Reauthenticate(c *gin.Context) {
+	var reauth auth_service.ReauthenticateRequest
+
+	err := c.ShouldBindJSON(&reauth)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().Reauthenticate(
+		c.Request.Context(),
+		&reauth,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// GetRevokedSessionList godoc
+// @ID get_revoked_session_list
+// @Router /revoked-session [GET]
+// @Summary Get Revoked Session List
+// @Description Returns revoked sessions (jti) after the given sequence number whose tokens haven't expired yet
+// @Tags Session
+// @Accept json
+// @Produce json
+// @Param after-seq query integer false "after-seq"
+// @Param limit query integer false "limit"
+// @Success 200 {object} http.Response{data=auth_service.GetRevokedSessionListResponse} "GetRevokedSessionListResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetRevokedSessionList(c *gin.Context) {
+	afterSeq, err := strconv.ParseInt(c.DefaultQuery("after-seq", "0"), 10, 64)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	limit, err := h.getLimitParam(c)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().GetRevokedSessionList(
+		c.Request.Context(),
+		&auth_service.GetRevokedSessionListRequest{
+			AfterSeq: afterSeq,
+			Limit:    int32(limit),
+		},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// BeginPasskeyRegistration godoc
+// @ID begin_passkey_registration
+// @Router /passkey/registration/begin [POST]
+// @Summary Begin Passkey Registration
+// @Description Returns the options for navigator.credentials.create()
+// @Tags Passkey
+// @Accept json
+// @Produce json
+// @Param passkey body auth_service.BeginPasskeyRegistrationRequest true "BeginPasskeyRegistrationRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.BeginPasskeyRegistrationResponse} "Registration options"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) BeginPasskeyRegistration(c *gin.Context) {
+	var passkey auth_service.BeginPasskeyRegistrationRequest
+
+	err := c.ShouldBindJSON(&passkey)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().BeginPasskeyRegistration(
+		c.Request.Context(),
+		&passkey,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// FinishPasskeyRegistration godoc
+// @ID finish_passkey_registration
+// @Router /passkey/registration/finish [POST]
+// @Summary Finish Passkey Registration
+// @Description Verifies the created credential and stores its public key
+// @Tags Passkey
+// @Accept json
+// @Produce json
+// @Param passkey body auth_service.FinishPasskeyRegistrationRequest true "FinishPasskeyRegistrationRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.PasskeyCredential} "Passkey data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) FinishPasskeyRegistration(c *gin.Context) {
+	var passkey auth_service.FinishPasskeyRegistrationRequest
+
+	err := c.ShouldBindJSON(&passkey)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().FinishPasskeyRegistration(
+		c.Request.Context(),
+		&passkey,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// BeginPasskeyLogin godoc
+// @ID begin_passkey_login
+// @Router /passkey/login/begin [POST]
+// @Summary Begin Passkey Login
+// @Description Returns the options for navigator.credentials.get()
+// @Tags Passkey
+// @Accept json
+// @Produce json
+// @Param passkey body auth_service.BeginPasskeyLoginRequest true "BeginPasskeyLoginRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.BeginPasskeyLoginResponse} "Login options"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) BeginPasskeyLogin(c *gin.Context) {
+	var passkey auth_service.BeginPasskeyLoginRequest
+
+	err := c.ShouldBindJSON(&passkey)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().BeginPasskeyLogin(
+		c.Request.Context(),
+		&passkey,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// FinishPasskeyLogin godoc
+// @ID finish_passkey_login
+// @Router /passkey/login/finish [POST]
+// @Summary Finish Passkey Login
+// @Description Verifies the assertion, pass the password as well for the second factor flow
+// @Tags Passkey
+// @Accept json
+// @Produce json
+// @Param passkey body auth_service.FinishPasskeyLoginRequest true "FinishPasskeyLoginRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.LoginResponse} "User data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) FinishPasskeyLogin(c *gin.Context) {
+	var passkey auth_service.FinishPasskeyLoginRequest
+
+	err := c.ShouldBindJSON(&passkey)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().FinishPasskeyLogin(
+		c.Request.Context(),
+		&passkey,
+	)
+
 	if err != nil {
 		h.handleResponse(c, http.GRPCError, err.Error())
 		return
@@ -143,3 +396,71 @@ func (h *Handler) HasAccess(c *gin.Context) {
 
 	h.handleResponse(c, http.Created, resp)
 }
+
+// GetPasskeyList godoc
+// @ID get_passkey_list
+// @Router /passkey/list [POST]
+// @Summary Get Passkey List
+// @Description Get passkeys of the current user
+// @Tags Passkey
+// @Accept json
+// @Produce json
+// @Param passkey body auth_service.GetPasskeyListRequest true "GetPasskeyListRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.GetPasskeyListResponse} "GetPasskeyListResponseBody"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetPasskeyList(c *gin.Context) {
+	var passkey auth_service.GetPasskeyListRequest
+
+	err := c.ShouldBindJSON(&passkey)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().GetPasskeyList(
+		c.Request.Context(),
+		&passkey,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// RemovePasskey godoc
+// @ID remove_passkey
+// @Router /passkey [DELETE]
+// @Summary Remove Passkey
+// @Description Remove a passkey of the current user
+// @Tags Passkey
+// @Accept json
+// @Produce json
+// @Param passkey body auth_service.RemovePasskeyRequest true "RemovePasskeyRequestBody"
+// @Success 204
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) RemovePasskey(c *gin.Context) {
+	var passkey auth_service.RemovePasskeyRequest
+
+	err := c.ShouldBindJSON(&passkey)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.SessionService().RemovePasskey(
+		c.Request.Context(),
+		&passkey,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.NoContent, resp)
+}
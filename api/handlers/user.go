@@ -1,14 +1,22 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
 	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/config"
+	"upm/udevs_go_auth_service/pkg/tabular"
 
 	"upm/udevs_go_auth_service/genproto/auth_service"
 
+	"github.com/saidamir98/udevs_pkg/logger"
 	"github.com/saidamir98/udevs_pkg/util"
 
 	"github.com/gin-gonic/gin"
 
+	"google.golang.org/grpc/status"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -60,6 +68,9 @@ func (h *Handler) CreateUser(c *gin.Context) {
 // @Param client-platform-id query string false "client-platform-id"
 // @Param client-type-id query string false "client-type-id"
 // @Param project-id query string false "project-id"
+// @Param user-info-filter query []string false "field:operator:value, operators are EQ, GT, GTE, LT, LTE, CONTAINS, IN, requires client-type-id" collectionFormat(multi)
+// @Param user-info-order-by query string false "user info field to sort by, requires client-type-id"
+// @Param arrangement query string false "ASC or DESC"
 // @Success 200 {object} http.Response{data=auth_service.GetUserListResponse} "GetUserListResponseBody"
 // @Response 400 {object} http.Response{data=string} "Invalid Argument"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
@@ -76,16 +87,17 @@ func (h *Handler) GetUserList(c *gin.Context) {
 		return
 	}
 
+	req, err := h.getUserListFilter(c)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+	req.Limit = int32(limit)
+	req.Offset = int32(offset)
+
 	resp, err := h.services.UserService().GetUserList(
 		c.Request.Context(),
-		&auth_service.GetUserListRequest{
-			Limit:            int32(limit),
-			Offset:           int32(offset),
-			Search:           c.Query("search"),
-			ClientPlatformId: c.Query("client-platform-id"),
-			ClientTypeId:     c.Query("client-type-id"),
-			ProjectId:        c.Query("project-id"),
-		},
+		req,
 	)
 
 	if err != nil {
@@ -169,7 +181,7 @@ func (h *Handler) UpdateUser(c *gin.Context) {
 // @ID delete_user
 // @Router /user/{user-id} [DELETE]
 // @Summary Delete User
-// @Description Get User
+// @Description Marks the user deleted and revokes its sessions, the user can be restored until the retention passes
 // @Tags User
 // @Accept json
 // @Produce json
@@ -268,6 +280,41 @@ func (h *Handler) RemoveUserRelation(c *gin.Context) {
 	h.handleResponse(c, http.NoContent, resp)
 }
 
+// GetUserEffectiveRelations godoc
+// @ID get_user_effective_relations
+// @Router /user-relation/{user-id}/effective [GET]
+// @Summary Get User Effective Relations
+// @Description Get the relations of the user with all their descendants
+// @Tags UserRelation
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Success 200 {object} http.Response{data=auth_service.GetUserEffectiveRelationsResponse} "GetUserEffectiveRelationsResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetUserEffectiveRelations(c *gin.Context) {
+	userID := c.Param("user-id")
+
+	if !util.IsValidUUID(userID) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().GetUserEffectiveRelations(
+		c.Request.Context(),
+		&auth_service.UserPrimaryKey{
+			Id: userID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
 // // UpsertUserInfo godoc
 // // @ID upsert_user_info
 // // @Router /upsert-user-info/{user-id} [POST]
@@ -279,7 +326,7 @@ func (h *Handler) RemoveUserRelation(c *gin.Context) {
 // // @Param data body structpb.Struct true "UpsertUserInfoRequestBody"
 // // @Param user-id path string true "user-id"
 // // @Success 201 {object} http.Response{data=auth_service.Role} "Role data"
-// // @Response 400 {object} http.Response{data=string} "Bad Request"
+// // @Response 400 {object} http.Response{data=[]auth_service.UserInfoFieldError} "Field errors"
 // // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) UpsertUserInfo(c *gin.Context) {
 	var data structpb.Struct
@@ -305,6 +352,13 @@ func (h *Handler) UpsertUserInfo(c *gin.Context) {
 	)
 
 	if err != nil {
+		for _, detail := range status.Convert(err).Details() {
+			if fieldErrors, ok := detail.(*auth_service.UserInfoFieldErrors); ok {
+				h.handleResponse(c, http.InvalidArgument, fieldErrors.Errors)
+				return
+			}
+		}
+
 		h.handleResponse(c, http.GRPCError, err.Error())
 		return
 	}
@@ -390,3 +444,463 @@ func (h *Handler) SendMessageToUserEmail(c *gin.Context) {
 
 	h.handleResponse(c, http.NoContent, resp)
 }
+
+// ActivateUser godoc
+// @ID activate_user
+// @Router /user-state/{user-id}/activate [PUT]
+// @Summary Activate User
+// @Description Moves a pending user to active
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param state body auth_service.ChangeUserStateRequest true "ChangeUserStateRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ActivateUser(c *gin.Context) {
+	var state auth_service.ChangeUserStateRequest
+
+	err := c.ShouldBindJSON(&state)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	state.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(state.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().ActivateUser(
+		c.Request.Context(),
+		&state,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// SuspendUser godoc
+// @ID suspend_user
+// @Router /user-state/{user-id}/suspend [PUT]
+// @Summary Suspend User
+// @Description Suspends a user with a reason, optionally until the given time
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param state body auth_service.SuspendUserRequest true "SuspendUserRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) SuspendUser(c *gin.Context) {
+	var state auth_service.SuspendUserRequest
+
+	err := c.ShouldBindJSON(&state)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	state.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(state.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().SuspendUser(
+		c.Request.Context(),
+		&state,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// LockUser godoc
+// @ID lock_user
+// @Router /user-state/{user-id}/lock [PUT]
+// @Summary Lock User
+// @Description Locks a user until reinstated
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param state body auth_service.ChangeUserStateRequest true "ChangeUserStateRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) LockUser(c *gin.Context) {
+	var state auth_service.ChangeUserStateRequest
+
+	err := c.ShouldBindJSON(&state)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	state.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(state.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().LockUser(
+		c.Request.Context(),
+		&state,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// ReinstateUser godoc
+// @ID reinstate_user
+// @Router /user-state/{user-id}/reinstate [PUT]
+// @Summary Reinstate User
+// @Description Moves a suspended, locked or expired user back to active
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param state body auth_service.ChangeUserStateRequest true "ChangeUserStateRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ReinstateUser(c *gin.Context) {
+	var state auth_service.ChangeUserStateRequest
+
+	err := c.ShouldBindJSON(&state)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	state.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(state.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().ReinstateUser(
+		c.Request.Context(),
+		&state,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// RestoreUser godoc
+// @ID restore_user
+// @Router /user-state/{user-id}/restore [PUT]
+// @Summary Restore User
+// @Description Brings a deleted user back in the state it was deleted in
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param state body auth_service.ChangeUserStateRequest true "ChangeUserStateRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) RestoreUser(c *gin.Context) {
+	var state auth_service.ChangeUserStateRequest
+
+	err := c.ShouldBindJSON(&state)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	state.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(state.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().RestoreUser(
+		c.Request.Context(),
+		&state,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// GetUserStateHistory godoc
+// @ID get_user_state_history
+// @Router /user-state/{user-id}/history [GET]
+// @Summary Get User State History
+// @Description Get User State History
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Success 200 {object} http.Response{data=auth_service.GetUserStateHistoryResponse} "GetUserStateHistoryResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetUserStateHistory(c *gin.Context) {
+	userID := c.Param("user-id")
+
+	if !util.IsValidUUID(userID) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().GetUserStateHistory(
+		c.Request.Context(),
+		&auth_service.UserPrimaryKey{
+			Id: userID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// ImportUsers godoc
+// @ID import_users
+// @Router /user/import [POST]
+// @Summary Import Users
+// @Description Creates or updates users from a CSV or XLSX file, rows are matched to existing users by phone, email or login
+// @Tags User
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file, the first row is the header"
+// @Param project-id formData string true "project-id"
+// @Param client-platform-id formData string true "client-platform-id"
+// @Param client-type-id formData string true "client-type-id"
+// @Param role-id formData string false "role of created users"
+// @Param expires-at formData string false "expiry of created users"
+// @Param state formData string false "PENDING or ACTIVE"
+// @Param format formData string false "CSV or XLSX, guessed by the file name when empty"
+// @Param column-mapping formData string false "JSON object of column header to phone, email, login, password, name, photo_url, expires_at, role_id, relation_ids or user_info.<field>"
+// @Param dry-run formData bool false "validate without saving"
+// @Success 200 {object} http.Response{data=auth_service.ImportUsersResponse} "ImportUsersResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ImportUsers(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	if file.Size > int64(config.ImportMaxFileSize) {
+		h.handleResponse(c, http.InvalidArgument, fmt.Sprintf("file must not be larger than %d bytes", config.ImportMaxFileSize))
+		return
+	}
+
+	header := &auth_service.ImportUsersHeader{
+		ProjectId:        c.PostForm("project-id"),
+		ClientPlatformId: c.PostForm("client-platform-id"),
+		ClientTypeId:     c.PostForm("client-type-id"),
+		RoleId:           c.PostForm("role-id"),
+		ExpiresAt:        c.PostForm("expires-at"),
+		Format:           c.PostForm("format"),
+		DryRun:           c.PostForm("dry-run") == "true",
+	}
+
+	for _, id := range []string{header.ProjectId, header.ClientPlatformId, header.ClientTypeId} {
+		if !util.IsValidUUID(id) {
+			h.handleResponse(c, http.InvalidArgument, "project, client platform and client type ids must be valid uuids")
+			return
+		}
+	}
+
+	if len(header.Format) == 0 {
+		header.Format = tabular.FormatByFileName(file.Filename)
+	}
+
+	if state := c.PostForm("state"); len(state) > 0 {
+		value, ok := auth_service.UserStates_value[strings.ToUpper(state)]
+		if !ok {
+			h.handleResponse(c, http.InvalidArgument, "state must be PENDING or ACTIVE")
+			return
+		}
+		header.State = auth_service.UserStates(value)
+	}
+
+	if mapping := c.PostForm("column-mapping"); len(mapping) > 0 {
+		if err := json.Unmarshal([]byte(mapping), &header.ColumnMapping); err != nil {
+			h.handleResponse(c, http.InvalidArgument, "column-mapping must be a JSON object of strings")
+			return
+		}
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+	defer f.Close()
+
+	stream, err := h.services.UserService().ImportUsers(c.Request.Context())
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	err = stream.Send(&auth_service.ImportUsersRequest{
+		Payload: &auth_service.ImportUsersRequest_Header{
+			Header: header,
+		},
+	})
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	chunk := make([]byte, config.FileChunkSize)
+	for {
+		n, err := f.Read(chunk)
+		if n > 0 {
+			err := stream.Send(&auth_service.ImportUsersRequest{
+				Payload: &auth_service.ImportUsersRequest_Chunk{
+					Chunk: chunk[:n],
+				},
+			})
+			if err != nil {
+				break
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, err.Error())
+			return
+		}
+	}
+
+	// a failed Send is reported by CloseAndRecv with the status of the server
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// ExportUsers godoc
+// @ID export_users
+// @Router /user-export [GET]
+// @Summary Export Users
+// @Description Downloads every user matching the filters of the user list with role name, relations and user info, password hashes are never exported
+// @Tags User
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "CSV or NDJSON, CSV by default"
+// @Param search query string false "search"
+// @Param client-platform-id query string false "client-platform-id"
+// @Param client-type-id query string false "client-type-id"
+// @Param project-id query string false "project-id"
+// @Param user-info-filter query []string false "field:operator:value, operators are EQ, GT, GTE, LT, LTE, CONTAINS, IN, requires client-type-id" collectionFormat(multi)
+// @Param arrangement query string false "ASC or DESC by created_at"
+// @Success 200 {file} file "users.csv or users.ndjson"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ExportUsers(c *gin.Context) {
+	filter, err := h.getUserListFilter(c)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	format := strings.ToUpper(c.DefaultQuery("format", "CSV"))
+
+	stream, err := h.services.UserService().ExportUsers(
+		c.Request.Context(),
+		&auth_service.ExportUsersRequest{
+			Filter: filter,
+			Format: format,
+		},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	// the first message carries the validation errors of the request,
+	// once the download has started errors can only cut it short
+	resp, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	contentType, extension := "text/csv", "csv"
+	if format == "NDJSON" {
+		contentType, extension = "application/x-ndjson", "ndjson"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, extension))
+	c.Header("Content-Type", contentType)
+	c.Status(http.OK.Code)
+
+	for err == nil {
+		if _, err = c.Writer.Write(resp.Chunk); err != nil {
+			h.log.Error("!!!ExportUsers--->", logger.Error(err))
+			return
+		}
+		c.Writer.Flush()
+
+		resp, err = stream.Recv()
+	}
+
+	if err != io.EOF {
+		h.log.Error("!!!ExportUsers--->", logger.Error(err))
+	}
+}
+
+func (h *Handler) getUserListFilter(c *gin.Context) (*auth_service.GetUserListRequest, error) {
+	userInfoFilters, err := h.getUserInfoFilters(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth_service.GetUserListRequest{
+		Search:           c.Query("search"),
+		ClientPlatformId: c.Query("client-platform-id"),
+		ClientTypeId:     c.Query("client-type-id"),
+		ProjectId:        c.Query("project-id"),
+		UserInfoFilters:  userInfoFilters,
+		UserInfoOrderBy:  c.Query("user-info-order-by"),
+		Arrangement:      c.Query("arrangement"),
+	}, nil
+}
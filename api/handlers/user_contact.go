@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/util"
+)
+
+// SendContactVerification godoc
+// @ID send_contact_verification
+// @Router /user-contact/{user-id}/verification [POST]
+// @Summary Send Contact Verification
+// @Description Sends a passcode to the email or phone of the user, the contact is verified with the passcode
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param request body auth_service.SendContactVerificationRequest true "SendContactVerificationRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.SendContactVerificationResponse} "Passcode data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) SendContactVerification(c *gin.Context) {
+	var request auth_service.SendContactVerificationRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	request.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(request.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().SendContactVerification(
+		c.Request.Context(),
+		&request,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// VerifyContact godoc
+// @ID verify_contact
+// @Router /user-contact/{user-id}/verify [PUT]
+// @Summary Verify Contact
+// @Description Marks the contact the passcode was sent to as verified
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param request body auth_service.VerifyContactRequest true "VerifyContactRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.User} "User data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) VerifyContact(c *gin.Context) {
+	var request auth_service.VerifyContactRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	request.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(request.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().VerifyContact(
+		c.Request.Context(),
+		&request,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/util"
+)
+
+// ExportUserData godoc
+// @ID export_user_data
+// @Router /user-data/{user-id}/export [POST]
+// @Summary Export User Data
+// @Description Returns everything stored about the user as a json document with a signed receipt, the document is base64 encoded and the receipt digest is taken over its decoded bytes
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param request body auth_service.DataSubjectRequest true "DataSubjectRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.ExportUserDataResponse} "Export data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ExportUserData(c *gin.Context) {
+	var request auth_service.DataSubjectRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	request.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(request.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().ExportUserData(
+		c.Request.Context(),
+		&request,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// EraseUserData godoc
+// @ID erase_user_data
+// @Router /user-data/{user-id}/erase [POST]
+// @Summary Erase User Data
+// @Description Deletes the user and anonymizes its personal data at once, the user row and the audit records are kept
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Param request body auth_service.DataSubjectRequest true "DataSubjectRequestBody"
+// @Success 200 {object} http.Response{data=auth_service.EraseUserDataResponse} "Erasure data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) EraseUserData(c *gin.Context) {
+	var request auth_service.DataSubjectRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	request.UserId = c.Param("user-id")
+
+	if !util.IsValidUUID(request.UserId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().EraseUserData(
+		c.Request.Context(),
+		&request,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// VerifyDataSubjectReceipt godoc
+// @ID verify_data_subject_receipt
+// @Router /data-subject-receipt/verify [POST]
+// @Summary Verify Data Subject Receipt
+// @Description Checks the signature of an export or erasure receipt and that the service issued it
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param receipt body auth_service.DataSubjectReceipt true "DataSubjectReceiptBody"
+// @Success 200 {object} http.Response{data=auth_service.VerifyDataSubjectReceiptResponse} "Verification data"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) VerifyDataSubjectReceipt(c *gin.Context) {
+	var receipt auth_service.DataSubjectReceipt
+
+	err := c.ShouldBindJSON(&receipt)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.UserService().VerifyDataSubjectReceipt(
+		c.Request.Context(),
+		&receipt,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/util"
+)
+
+// GrantUserPermission godoc
+// @ID grant_user_permission
+// @Router /user-permission [POST]
+// @Summary Grant UserPermission
+// @Description Grants a permission to the user until ends_at, starts_at defaults to now, times are in RFC3339
+// @Tags UserPermission
+// @Accept json
+// @Produce json
+// @Param user-permission body auth_service.GrantUserPermissionRequest true "GrantUserPermissionRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.UserPermission} "UserPermission data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GrantUserPermission(c *gin.Context) {
+	var userPermission auth_service.GrantUserPermissionRequest
+
+	err := c.ShouldBindJSON(&userPermission)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.UserService().GrantUserPermission(
+		c.Request.Context(),
+		&userPermission,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// RevokeUserPermission godoc
+// @ID revoke_user_permission
+// @Router /user-permission/{user-permission-id} [DELETE]
+// @Summary Revoke UserPermission
+// @Description Revokes a permission granted to the user before it ends
+// @Tags UserPermission
+// @Accept json
+// @Produce json
+// @Param user-permission-id path string true "user-permission-id"
+// @Success 204
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) RevokeUserPermission(c *gin.Context) {
+	userPermissionID := c.Param("user-permission-id")
+
+	if !util.IsValidUUID(userPermissionID) {
+		h.handleResponse(c, http.InvalidArgument, "user permission id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().RevokeUserPermission(
+		c.Request.Context(),
+		&auth_service.UserPermissionPrimaryKey{
+			Id: userPermissionID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.NoContent, resp)
+}
+
+// GetUserPermissionList godoc
+// @ID get_user_permission_list
+// @Router /user-permission-list/{user-id} [GET]
+// @Summary Get UserPermission List
+// @Description Get the permissions granted to the user that haven't ended, future ones included
+// @Tags UserPermission
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Success 200 {object} http.Response{data=auth_service.GetUserPermissionListResponse} "GetUserPermissionListResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetUserPermissionList(c *gin.Context) {
+	userID := c.Param("user-id")
+
+	if !util.IsValidUUID(userID) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().GetUserPermissionList(
+		c.Request.Context(),
+		&auth_service.UserPrimaryKey{
+			Id: userID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
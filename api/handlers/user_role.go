@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/saidamir98/udevs_pkg/util"
+)
+
+// AddUserRole godoc
+// @ID add_user_role
+// @Router /user-role [POST]
+// @Summary Add UserRole
+// @Description Assigns a role to the user besides the primary one, starts_at and ends_at are optional RFC3339 times
+// @Tags UserRole
+// @Accept json
+// @Produce json
+// @Param user-role body auth_service.AddUserRoleRequest true "AddUserRoleRequestBody"
+// @Success 201 {object} http.Response{data=auth_service.UserRole} "UserRole data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) AddUserRole(c *gin.Context) {
+	var userRole auth_service.AddUserRoleRequest
+
+	err := c.ShouldBindJSON(&userRole)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.UserService().AddUserRole(
+		c.Request.Context(),
+		&userRole,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, resp)
+}
+
+// RemoveUserRole godoc
+// @ID delete_user_role
+// @Router /user-role [DELETE]
+// @Summary Delete UserRole
+// @Description Removes a role assigned to the user
+// @Tags UserRole
+// @Accept json
+// @Produce json
+// @Param user-role body auth_service.UserRolePrimaryKey true "UserRolePrimaryKeyBody"
+// @Success 204
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) RemoveUserRole(c *gin.Context) {
+	var userRole auth_service.UserRolePrimaryKey
+
+	err := c.ShouldBindJSON(&userRole)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.UserService().RemoveUserRole(
+		c.Request.Context(),
+		&userRole,
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.NoContent, resp)
+}
+
+// GetUserRoleList godoc
+// @ID get_user_role_list
+// @Router /user-role/{user-id} [GET]
+// @Summary Get UserRole List
+// @Description Get the roles assigned to the user besides the primary one, expired and future ones included
+// @Tags UserRole
+// @Accept json
+// @Produce json
+// @Param user-id path string true "user-id"
+// @Success 200 {object} http.Response{data=auth_service.GetUserRoleListResponse} "GetUserRoleListResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetUserRoleList(c *gin.Context) {
+	userID := c.Param("user-id")
+
+	if !util.IsValidUUID(userID) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	resp, err := h.services.UserService().GetUserRoleList(
+		c.Request.Context(),
+		&auth_service.UserPrimaryKey{
+			Id: userID,
+		},
+	)
+
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
@@ -43,6 +43,11 @@ var (
 		Status:      "UNAUTHORIZED",
 		Description: "...",
 	}
+	StepUpRequired = Status{
+		Code:        401,
+		Status:      "STEP_UP_REQUIRED",
+		Description: "The permission requires a recent authentication, reauthenticate and retry",
+	}
 	Forbidden = Status{
 		Code:        403,
 		Status:      "FORBIDDEN",
@@ -8,6 +8,9 @@ import (
 	"upm/udevs_go_auth_service/config"
 	"upm/udevs_go_auth_service/grpc"
 	"upm/udevs_go_auth_service/grpc/client"
+	"upm/udevs_go_auth_service/pkg/contact"
+	"upm/udevs_go_auth_service/pkg/revocation"
+	"upm/udevs_go_auth_service/scheduler"
 	"upm/udevs_go_auth_service/storage/postgres"
 
 	"github.com/saidamir98/udevs_pkg/logger"
@@ -35,12 +38,20 @@ func main() {
 	log := logger.NewLogger(cfg.ServiceName, loggerLevel)
 	defer logger.Cleanup(log)
 
+	if !contact.IsRegion(cfg.DefaultPhoneRegion) {
+		log.Panic("config.DefaultPhoneRegion", logger.String("region", cfg.DefaultPhoneRegion))
+	}
+
 	pgStore, err := postgres.NewPostgres(context.Background(), cfg)
 	if err != nil {
 		log.Panic("postgres.NewPostgres", logger.Error(err))
 	}
 	defer pgStore.CloseDB()
 
+	if cfg.SchedulerEnabled {
+		scheduler.New(cfg, log, pgStore).Start(context.Background())
+	}
+
 	svcs, err := client.NewGrpcClients(cfg)
 	if err != nil {
 		log.Panic("client.NewGrpcClients", logger.Error(err))
@@ -60,9 +71,12 @@ func main() {
 		}
 	}()
 
+	denyList := revocation.NewDenyList()
+	go revocation.Watch(context.Background(), svcs.SessionService(), denyList, log)
+
 	h := handlers.NewHandler(cfg, log, svcs)
 
-	r := api.SetUpRouter(h, cfg)
+	r := api.SetUpRouter(h, cfg, denyList)
 
 	r.Run(cfg.HTTPPort)
 }
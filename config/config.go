@@ -37,10 +37,24 @@ type Config struct {
 	DefaultLimit  string
 
 	SecretKey string
+	// ReceiptSigningKey signs data subject receipts, data subject requests are refused until it is set
+	// to a key other than SecretKey
+	ReceiptSigningKey string
 
 	PasscodePool   string
 	PasscodeLength int
 
+	// DefaultPhoneRegion is the region of phone numbers written without a calling code
+	DefaultPhoneRegion string
+	// SMSHookURL is the sms gateway passcodes for phones are posted to, without it they can be sent by email only
+	SMSHookURL string
+
+	SchedulerEnabled     bool
+	UserExpiryNotifyDays int
+
+	// UserDeleteRetentionDays is how long a deleted user can be restored before it is anonymized
+	UserDeleteRetentionDays int
+
 	SettingsServiceHost string
 	SettingsGRPCPort    string
 
@@ -75,10 +89,20 @@ func Load() Config {
 	config.DefaultLimit = cast.ToString(getOrReturnDefaultValue("DEFAULT_LIMIT", "10"))
 
 	config.SecretKey = cast.ToString(getOrReturnDefaultValue("SECRET_KEY", "Here$houldBe$ome$ecretKey"))
+	config.ReceiptSigningKey = cast.ToString(getOrReturnDefaultValue("RECEIPT_SIGNING_KEY", ""))
 
 	config.PasscodePool = cast.ToString(getOrReturnDefaultValue("PASSCODE_POOL", "0123456789"))
 	config.PasscodeLength = cast.ToInt(getOrReturnDefaultValue("PASSCODE_LENGTH", "6"))
 
+	config.SchedulerEnabled = cast.ToBool(getOrReturnDefaultValue("SCHEDULER_ENABLED", true))
+	config.UserExpiryNotifyDays = cast.ToInt(getOrReturnDefaultValue("USER_EXPIRY_NOTIFY_DAYS", 7))
+
+	config.UserDeleteRetentionDays = cast.ToInt(getOrReturnDefaultValue("USER_DELETE_RETENTION_DAYS", 30))
+
+	config.DefaultPhoneRegion = cast.ToString(getOrReturnDefaultValue("DEFAULT_PHONE_REGION", "UZ"))
+
+	config.SMSHookURL = cast.ToString(getOrReturnDefaultValue("SMS_HOOK_URL", ""))
+
 	config.SettingsServiceHost = cast.ToString(getOrReturnDefaultValue("SETTINGS_SERVICE_HOST", "0.0.0.0"))
 	config.SettingsGRPCPort = cast.ToString(getOrReturnDefaultValue("SETTINGS_GRPC_PORT", ":9101"))
 
@@ -10,4 +10,49 @@ const (
 	AccessTokenExpiresInTime time.Duration = 1 * 24 * 60 * time.Minute
 	// RefreshTokenExpiresInTime ...
 	RefreshTokenExpiresInTime time.Duration = 30 * 24 * 60 * time.Minute
+	// PasskeyChallengeExpiresInTime ...
+	PasskeyChallengeExpiresInTime time.Duration = 5 * time.Minute
+	// PasscodeExpiresInTime ...
+	PasscodeExpiresInTime time.Duration = 5 * time.Minute
+	// PasscodeMaxAttempts is how many times a passcode may be checked before it is locked
+	PasscodeMaxAttempts int32 = 5
+	// RevocationFeedPollInterval is how often WatchRevokedSessions looks for new revocations
+	RevocationFeedPollInterval time.Duration = 2 * time.Second
+	// RevocationFeedLookback is how many sequence numbers before the last sent one WatchRevokedSessions
+	// reads again, a revocation may commit after ones with higher sequence numbers
+	RevocationFeedLookback int64 = 100
+	// RevokedSessionListLimit ...
+	RevokedSessionListLimit int32 = 1000
+	// CleanupJobInterval is how often expired sessions, passcodes and challenges are purged
+	CleanupJobInterval time.Duration = 10 * time.Minute
+	// ExpiryJobInterval is how often users and integrations are checked for expiry
+	ExpiryJobInterval time.Duration = 5 * time.Minute
+	// ExpiryNotificationJobInterval ...
+	ExpiryNotificationJobInterval time.Duration = 1 * time.Hour
+	// PurgeDeletedUsersJobInterval is how often users deleted longer than the retention are anonymized
+	PurgeDeletedUsersJobInterval time.Duration = 1 * time.Hour
+	// ImportMaxFileSize is the largest file ImportUsers accepts
+	ImportMaxFileSize int = 32 << 20
+	// ImportBatchSize is the number of rows upserted in one transaction
+	ImportBatchSize int = 500
+	// FileChunkSize is the size of file chunks sent over grpc streams
+	FileChunkSize int = 64 << 10
+	// ExportPageSize is the number of users read from the database at once by ExportUsers
+	ExportPageSize int32 = 1000
+)
+
+const (
+	PasskeyCeremonyRegistration = "REGISTRATION"
+	PasskeyCeremonyLogin        = "LOGIN"
+)
+
+const (
+	PasscodeStatePending   int32 = 0
+	PasscodeStateConfirmed int32 = 1
+)
+
+const (
+	PasscodePurposeReauth        = "REAUTH"
+	PasscodePurposeVerifyContact = "VERIFY_CONTACT"
+	PasscodePurposeChangeContact = "CHANGE_CONTACT"
 )
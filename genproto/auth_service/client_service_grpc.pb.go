@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.19.4
+// - protoc             (unknown)
 // source: client_service.proto
 
 package auth_service
@@ -42,9 +42,12 @@ type ClientServiceClient interface {
 	AddRelation(ctx context.Context, in *AddRelationRequest, opts ...grpc.CallOption) (*Relation, error)
 	UpdateRelation(ctx context.Context, in *UpdateRelationRequest, opts ...grpc.CallOption) (*Relation, error)
 	RemoveRelation(ctx context.Context, in *RelationPrimaryKey, opts ...grpc.CallOption) (*Relation, error)
+	GetRelationSubtree(ctx context.Context, in *RelationPrimaryKey, opts ...grpc.CallOption) (*GetRelationSubtreeResponse, error)
+	MoveRelation(ctx context.Context, in *MoveRelationRequest, opts ...grpc.CallOption) (*Relation, error)
 	AddUserInfoField(ctx context.Context, in *AddUserInfoFieldRequest, opts ...grpc.CallOption) (*UserInfoField, error)
 	UpdateUserInfoField(ctx context.Context, in *UpdateUserInfoFieldRequest, opts ...grpc.CallOption) (*UserInfoField, error)
 	RemoveUserInfoField(ctx context.Context, in *UserInfoFieldPrimaryKey, opts ...grpc.CallOption) (*UserInfoField, error)
+	GetUserInfoSchema(ctx context.Context, in *ClientTypePrimaryKey, opts ...grpc.CallOption) (*UserInfoSchema, error)
 }
 
 type clientServiceClient struct {
@@ -226,6 +229,24 @@ func (c *clientServiceClient) RemoveRelation(ctx context.Context, in *RelationPr
 	return out, nil
 }
 
+func (c *clientServiceClient) GetRelationSubtree(ctx context.Context, in *RelationPrimaryKey, opts ...grpc.CallOption) (*GetRelationSubtreeResponse, error) {
+	out := new(GetRelationSubtreeResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.ClientService/GetRelationSubtree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientServiceClient) MoveRelation(ctx context.Context, in *MoveRelationRequest, opts ...grpc.CallOption) (*Relation, error) {
+	out := new(Relation)
+	err := c.cc.Invoke(ctx, "/auth_service.ClientService/MoveRelation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *clientServiceClient) AddUserInfoField(ctx context.Context, in *AddUserInfoFieldRequest, opts ...grpc.CallOption) (*UserInfoField, error) {
 	out := new(UserInfoField)
 	err := c.cc.Invoke(ctx, "/auth_service.ClientService/AddUserInfoField", in, out, opts...)
@@ -253,6 +274,15 @@ func (c *clientServiceClient) RemoveUserInfoField(ctx context.Context, in *UserI
 	return out, nil
 }
 
+func (c *clientServiceClient) GetUserInfoSchema(ctx context.Context, in *ClientTypePrimaryKey, opts ...grpc.CallOption) (*UserInfoSchema, error) {
+	out := new(UserInfoSchema)
+	err := c.cc.Invoke(ctx, "/auth_service.ClientService/GetUserInfoSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClientServiceServer is the server API for ClientService service.
 // All implementations must embed UnimplementedClientServiceServer
 // for forward compatibility
@@ -276,9 +306,12 @@ type ClientServiceServer interface {
 	AddRelation(context.Context, *AddRelationRequest) (*Relation, error)
 	UpdateRelation(context.Context, *UpdateRelationRequest) (*Relation, error)
 	RemoveRelation(context.Context, *RelationPrimaryKey) (*Relation, error)
+	GetRelationSubtree(context.Context, *RelationPrimaryKey) (*GetRelationSubtreeResponse, error)
+	MoveRelation(context.Context, *MoveRelationRequest) (*Relation, error)
 	AddUserInfoField(context.Context, *AddUserInfoFieldRequest) (*UserInfoField, error)
 	UpdateUserInfoField(context.Context, *UpdateUserInfoFieldRequest) (*UserInfoField, error)
 	RemoveUserInfoField(context.Context, *UserInfoFieldPrimaryKey) (*UserInfoField, error)
+	GetUserInfoSchema(context.Context, *ClientTypePrimaryKey) (*UserInfoSchema, error)
 	mustEmbedUnimplementedClientServiceServer()
 }
 
@@ -343,6 +376,12 @@ func (UnimplementedClientServiceServer) UpdateRelation(context.Context, *UpdateR
 func (UnimplementedClientServiceServer) RemoveRelation(context.Context, *RelationPrimaryKey) (*Relation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRelation not implemented")
 }
+func (UnimplementedClientServiceServer) GetRelationSubtree(context.Context, *RelationPrimaryKey) (*GetRelationSubtreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelationSubtree not implemented")
+}
+func (UnimplementedClientServiceServer) MoveRelation(context.Context, *MoveRelationRequest) (*Relation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveRelation not implemented")
+}
 func (UnimplementedClientServiceServer) AddUserInfoField(context.Context, *AddUserInfoFieldRequest) (*UserInfoField, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddUserInfoField not implemented")
 }
@@ -352,6 +391,9 @@ func (UnimplementedClientServiceServer) UpdateUserInfoField(context.Context, *Up
 func (UnimplementedClientServiceServer) RemoveUserInfoField(context.Context, *UserInfoFieldPrimaryKey) (*UserInfoField, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserInfoField not implemented")
 }
+func (UnimplementedClientServiceServer) GetUserInfoSchema(context.Context, *ClientTypePrimaryKey) (*UserInfoSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserInfoSchema not implemented")
+}
 func (UnimplementedClientServiceServer) mustEmbedUnimplementedClientServiceServer() {}
 
 // UnsafeClientServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -707,6 +749,42 @@ func _ClientService_RemoveRelation_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientService_GetRelationSubtree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).GetRelationSubtree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.ClientService/GetRelationSubtree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).GetRelationSubtree(ctx, req.(*RelationPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientService_MoveRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).MoveRelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.ClientService/MoveRelation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).MoveRelation(ctx, req.(*MoveRelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ClientService_AddUserInfoField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddUserInfoFieldRequest)
 	if err := dec(in); err != nil {
@@ -761,6 +839,24 @@ func _ClientService_RemoveUserInfoField_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientService_GetUserInfoSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientTypePrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).GetUserInfoSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.ClientService/GetUserInfoSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).GetUserInfoSchema(ctx, req.(*ClientTypePrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClientService_ServiceDesc is the grpc.ServiceDesc for ClientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -844,6 +940,14 @@ var ClientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRelation",
 			Handler:    _ClientService_RemoveRelation_Handler,
 		},
+		{
+			MethodName: "GetRelationSubtree",
+			Handler:    _ClientService_GetRelationSubtree_Handler,
+		},
+		{
+			MethodName: "MoveRelation",
+			Handler:    _ClientService_MoveRelation_Handler,
+		},
 		{
 			MethodName: "AddUserInfoField",
 			Handler:    _ClientService_AddUserInfoField_Handler,
@@ -856,6 +960,10 @@ var ClientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveUserInfoField",
 			Handler:    _ClientService_RemoveUserInfoField_Handler,
 		},
+		{
+			MethodName: "GetUserInfoSchema",
+			Handler:    _ClientService_GetUserInfoSchema_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "client_service.proto",
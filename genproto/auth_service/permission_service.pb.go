@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.27.1
-// 	protoc        v3.19.4
+// 	protoc        (unknown)
 // source: permission_service.proto
 
 package auth_service
@@ -21,12 +21,17 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PermissionGenerated is a tree generated from the routes of a service, the sync creates the permissions
+// missing from the client platform and reconciles the scopes of every permission, the tree carries
+// nothing else, so recent_auth_minutes and relation_scoped of existing permissions are left as they are
 type PermissionGenerated struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Permissions []*PermissionGenerated_Permission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Permissions      []*PermissionGenerated_Permission `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	ClientPlatformId string                            `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	Prune            bool                              `protobuf:"varint,3,opt,name=prune,proto3" json:"prune,omitempty"` // delete permissions of the client platform missing from the tree
 }
 
 func (x *PermissionGenerated) Reset() {
@@ -68,20 +73,36 @@ func (x *PermissionGenerated) GetPermissions() []*PermissionGenerated_Permission
 	return nil
 }
 
-type GetPermissionByIDResponse struct {
+func (x *PermissionGenerated) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *PermissionGenerated) GetPrune() bool {
+	if x != nil {
+		return x.Prune
+	}
+	return false
+}
+
+// PermissionGeneratedResponse is the diff of the sync, permissions are named by their path in the tree
+type PermissionGeneratedResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id               string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ClientPlatformId string             `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	ParentId         string             `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	Name             string             `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	PermissionScopes []*PermissionScope `protobuf:"bytes,5,rep,name=permission_scopes,json=permissionScopes,proto3" json:"permission_scopes,omitempty"`
+	Created       []string `protobuf:"bytes,1,rep,name=created,proto3" json:"created,omitempty"`
+	Updated       []string `protobuf:"bytes,2,rep,name=updated,proto3" json:"updated,omitempty"` // permissions whose scopes were changed
+	Deleted       []string `protobuf:"bytes,3,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	Unchanged     int32    `protobuf:"varint,4,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	ScopesAdded   int32    `protobuf:"varint,5,opt,name=scopes_added,json=scopesAdded,proto3" json:"scopes_added,omitempty"`
+	ScopesRemoved int32    `protobuf:"varint,6,opt,name=scopes_removed,json=scopesRemoved,proto3" json:"scopes_removed,omitempty"`
 }
 
-func (x *GetPermissionByIDResponse) Reset() {
-	*x = GetPermissionByIDResponse{}
+func (x *PermissionGeneratedResponse) Reset() {
+	*x = PermissionGeneratedResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_permission_service_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -89,13 +110,13 @@ func (x *GetPermissionByIDResponse) Reset() {
 	}
 }
 
-func (x *GetPermissionByIDResponse) String() string {
+func (x *PermissionGeneratedResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPermissionByIDResponse) ProtoMessage() {}
+func (*PermissionGeneratedResponse) ProtoMessage() {}
 
-func (x *GetPermissionByIDResponse) ProtoReflect() protoreflect.Message {
+func (x *PermissionGeneratedResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_permission_service_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -107,60 +128,63 @@ func (x *GetPermissionByIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPermissionByIDResponse.ProtoReflect.Descriptor instead.
-func (*GetPermissionByIDResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PermissionGeneratedResponse.ProtoReflect.Descriptor instead.
+func (*PermissionGeneratedResponse) Descriptor() ([]byte, []int) {
 	return file_permission_service_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetPermissionByIDResponse) GetId() string {
+func (x *PermissionGeneratedResponse) GetCreated() []string {
 	if x != nil {
-		return x.Id
+		return x.Created
 	}
-	return ""
+	return nil
 }
 
-func (x *GetPermissionByIDResponse) GetClientPlatformId() string {
+func (x *PermissionGeneratedResponse) GetUpdated() []string {
 	if x != nil {
-		return x.ClientPlatformId
+		return x.Updated
 	}
-	return ""
+	return nil
 }
 
-func (x *GetPermissionByIDResponse) GetParentId() string {
+func (x *PermissionGeneratedResponse) GetDeleted() []string {
 	if x != nil {
-		return x.ParentId
+		return x.Deleted
 	}
-	return ""
+	return nil
 }
 
-func (x *GetPermissionByIDResponse) GetName() string {
+func (x *PermissionGeneratedResponse) GetUnchanged() int32 {
 	if x != nil {
-		return x.Name
+		return x.Unchanged
 	}
-	return ""
+	return 0
 }
 
-func (x *GetPermissionByIDResponse) GetPermissionScopes() []*PermissionScope {
+func (x *PermissionGeneratedResponse) GetScopesAdded() int32 {
 	if x != nil {
-		return x.PermissionScopes
+		return x.ScopesAdded
 	}
-	return nil
+	return 0
 }
 
-type GetRoleByIdResponse struct {
+func (x *PermissionGeneratedResponse) GetScopesRemoved() int32 {
+	if x != nil {
+		return x.ScopesRemoved
+	}
+	return 0
+}
+
+type ExportRBACConfigRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id           string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ClientTypeId string        `protobuf:"bytes,2,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
-	Name         string        `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	ClientType   *ClientType   `protobuf:"bytes,4,opt,name=client_type,json=clientType,proto3" json:"client_type,omitempty"`
-	Permissions  []*Permission `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
 }
 
-func (x *GetRoleByIdResponse) Reset() {
-	*x = GetRoleByIdResponse{}
+func (x *ExportRBACConfigRequest) Reset() {
+	*x = ExportRBACConfigRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_permission_service_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -168,13 +192,13 @@ func (x *GetRoleByIdResponse) Reset() {
 	}
 }
 
-func (x *GetRoleByIdResponse) String() string {
+func (x *ExportRBACConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRoleByIdResponse) ProtoMessage() {}
+func (*ExportRBACConfigRequest) ProtoMessage() {}
 
-func (x *GetRoleByIdResponse) ProtoReflect() protoreflect.Message {
+func (x *ExportRBACConfigRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_permission_service_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -186,58 +210,28 @@ func (x *GetRoleByIdResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRoleByIdResponse.ProtoReflect.Descriptor instead.
-func (*GetRoleByIdResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportRBACConfigRequest.ProtoReflect.Descriptor instead.
+func (*ExportRBACConfigRequest) Descriptor() ([]byte, []int) {
 	return file_permission_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetRoleByIdResponse) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *GetRoleByIdResponse) GetClientTypeId() string {
-	if x != nil {
-		return x.ClientTypeId
-	}
-	return ""
-}
-
-func (x *GetRoleByIdResponse) GetName() string {
+func (x *ExportRBACConfigRequest) GetClientPlatformId() string {
 	if x != nil {
-		return x.Name
+		return x.ClientPlatformId
 	}
 	return ""
 }
 
-func (x *GetRoleByIdResponse) GetClientType() *ClientType {
-	if x != nil {
-		return x.ClientType
-	}
-	return nil
-}
-
-func (x *GetRoleByIdResponse) GetPermissions() []*Permission {
-	if x != nil {
-		return x.Permissions
-	}
-	return nil
-}
-
-type UpsertScopeRequest struct {
+type ExportRBACConfigResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	Path             string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	Method           string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Config []byte `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"` // yaml
 }
 
-func (x *UpsertScopeRequest) Reset() {
-	*x = UpsertScopeRequest{}
+func (x *ExportRBACConfigResponse) Reset() {
+	*x = ExportRBACConfigResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_permission_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -245,13 +239,13 @@ func (x *UpsertScopeRequest) Reset() {
 	}
 }
 
-func (x *UpsertScopeRequest) String() string {
+func (x *ExportRBACConfigResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpsertScopeRequest) ProtoMessage() {}
+func (*ExportRBACConfigResponse) ProtoMessage() {}
 
-func (x *UpsertScopeRequest) ProtoReflect() protoreflect.Message {
+func (x *ExportRBACConfigResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_permission_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -263,44 +257,30 @@ func (x *UpsertScopeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpsertScopeRequest.ProtoReflect.Descriptor instead.
-func (*UpsertScopeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportRBACConfigResponse.ProtoReflect.Descriptor instead.
+func (*ExportRBACConfigResponse) Descriptor() ([]byte, []int) {
 	return file_permission_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UpsertScopeRequest) GetClientPlatformId() string {
-	if x != nil {
-		return x.ClientPlatformId
-	}
-	return ""
-}
-
-func (x *UpsertScopeRequest) GetPath() string {
-	if x != nil {
-		return x.Path
-	}
-	return ""
-}
-
-func (x *UpsertScopeRequest) GetMethod() string {
+func (x *ExportRBACConfigResponse) GetConfig() []byte {
 	if x != nil {
-		return x.Method
+		return x.Config
 	}
-	return ""
+	return nil
 }
 
-type ScopePrimaryKey struct {
+type ApplyRBACConfigRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	Path             string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	Method           string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Config           []byte `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`                // yaml in the format of ExportRBACConfig
+	DryRun           bool   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // return the plan without applying it
 }
 
-func (x *ScopePrimaryKey) Reset() {
-	*x = ScopePrimaryKey{}
+func (x *ApplyRBACConfigRequest) Reset() {
+	*x = ApplyRBACConfigRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_permission_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -308,13 +288,13 @@ func (x *ScopePrimaryKey) Reset() {
 	}
 }
 
-func (x *ScopePrimaryKey) String() string {
+func (x *ApplyRBACConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScopePrimaryKey) ProtoMessage() {}
+func (*ApplyRBACConfigRequest) ProtoMessage() {}
 
-func (x *ScopePrimaryKey) ProtoReflect() protoreflect.Message {
+func (x *ApplyRBACConfigRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_permission_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -326,45 +306,55 @@ func (x *ScopePrimaryKey) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScopePrimaryKey.ProtoReflect.Descriptor instead.
-func (*ScopePrimaryKey) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApplyRBACConfigRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRBACConfigRequest) Descriptor() ([]byte, []int) {
 	return file_permission_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ScopePrimaryKey) GetClientPlatformId() string {
+func (x *ApplyRBACConfigRequest) GetClientPlatformId() string {
 	if x != nil {
 		return x.ClientPlatformId
 	}
 	return ""
 }
 
-func (x *ScopePrimaryKey) GetPath() string {
+func (x *ApplyRBACConfigRequest) GetConfig() []byte {
 	if x != nil {
-		return x.Path
+		return x.Config
 	}
-	return ""
+	return nil
 }
 
-func (x *ScopePrimaryKey) GetMethod() string {
+func (x *ApplyRBACConfigRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Method
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-type AddRoleRequest struct {
+// RBACChange is one step of the plan, permissions are referred to by their path in the tree
+// and roles by the name of their client type and their own name
+type RBACChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientTypeId     string `protobuf:"bytes,2,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
-	Name             string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	ClientPlatformId string `protobuf:"bytes,4,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	ProjectId        string `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-}
-
-func (x *AddRoleRequest) Reset() {
-	*x = AddRoleRequest{}
+	Action            string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // CREATE, UPDATE or DELETE
+	Kind              string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`     // PERMISSION, SCOPE, ROLE, ROLE_PERMISSION or ROLE_PARENT
+	Permission        string `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"`
+	ParentPermission  string `protobuf:"bytes,4,opt,name=parent_permission,json=parentPermission,proto3" json:"parent_permission,omitempty"`
+	RecentAuthMinutes int32  `protobuf:"varint,5,opt,name=recent_auth_minutes,json=recentAuthMinutes,proto3" json:"recent_auth_minutes,omitempty"`
+	ScopeMethod       string `protobuf:"bytes,6,opt,name=scope_method,json=scopeMethod,proto3" json:"scope_method,omitempty"`
+	ScopePath         string `protobuf:"bytes,7,opt,name=scope_path,json=scopePath,proto3" json:"scope_path,omitempty"`
+	ClientType        string `protobuf:"bytes,8,opt,name=client_type,json=clientType,proto3" json:"client_type,omitempty"`
+	Role              string `protobuf:"bytes,9,opt,name=role,proto3" json:"role,omitempty"`
+	RelationScoped    bool   `protobuf:"varint,10,opt,name=relation_scoped,json=relationScoped,proto3" json:"relation_scoped,omitempty"`
+	ParentClientType  string `protobuf:"bytes,11,opt,name=parent_client_type,json=parentClientType,proto3" json:"parent_client_type,omitempty"` // of ROLE_PARENT, the role inherits from the parent role
+	ParentRole        string `protobuf:"bytes,12,opt,name=parent_role,json=parentRole,proto3" json:"parent_role,omitempty"`
+}
+
+func (x *RBACChange) Reset() {
+	*x = RBACChange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_permission_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -372,13 +362,13 @@ func (x *AddRoleRequest) Reset() {
 	}
 }
 
-func (x *AddRoleRequest) String() string {
+func (x *RBACChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddRoleRequest) ProtoMessage() {}
+func (*RBACChange) ProtoMessage() {}
 
-func (x *AddRoleRequest) ProtoReflect() protoreflect.Message {
+func (x *RBACChange) ProtoReflect() protoreflect.Message {
 	mi := &file_permission_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -390,143 +380,121 @@ func (x *AddRoleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddRoleRequest.ProtoReflect.Descriptor instead.
-func (*AddRoleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RBACChange.ProtoReflect.Descriptor instead.
+func (*RBACChange) Descriptor() ([]byte, []int) {
 	return file_permission_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *AddRoleRequest) GetClientTypeId() string {
+func (x *RBACChange) GetAction() string {
 	if x != nil {
-		return x.ClientTypeId
+		return x.Action
 	}
 	return ""
 }
 
-func (x *AddRoleRequest) GetName() string {
+func (x *RBACChange) GetKind() string {
 	if x != nil {
-		return x.Name
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *AddRoleRequest) GetClientPlatformId() string {
+func (x *RBACChange) GetPermission() string {
 	if x != nil {
-		return x.ClientPlatformId
+		return x.Permission
 	}
 	return ""
 }
 
-func (x *AddRoleRequest) GetProjectId() string {
+func (x *RBACChange) GetParentPermission() string {
 	if x != nil {
-		return x.ProjectId
+		return x.ParentPermission
 	}
 	return ""
 }
 
-type UpdateRoleRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id               string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ClientTypeId     string `protobuf:"bytes,2,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
-	Name             string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	ClientPlatformId string `protobuf:"bytes,4,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	ProjectId        string `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-}
-
-func (x *UpdateRoleRequest) Reset() {
-	*x = UpdateRoleRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *RBACChange) GetRecentAuthMinutes() int32 {
+	if x != nil {
+		return x.RecentAuthMinutes
 	}
+	return 0
 }
 
-func (x *UpdateRoleRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateRoleRequest) ProtoMessage() {}
-
-func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *RBACChange) GetScopeMethod() string {
+	if x != nil {
+		return x.ScopeMethod
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateRoleRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{6}
+func (x *RBACChange) GetScopePath() string {
+	if x != nil {
+		return x.ScopePath
+	}
+	return ""
 }
 
-func (x *UpdateRoleRequest) GetId() string {
+func (x *RBACChange) GetClientType() string {
 	if x != nil {
-		return x.Id
+		return x.ClientType
 	}
 	return ""
 }
 
-func (x *UpdateRoleRequest) GetClientTypeId() string {
+func (x *RBACChange) GetRole() string {
 	if x != nil {
-		return x.ClientTypeId
+		return x.Role
 	}
 	return ""
 }
 
-func (x *UpdateRoleRequest) GetName() string {
+func (x *RBACChange) GetRelationScoped() bool {
 	if x != nil {
-		return x.Name
+		return x.RelationScoped
 	}
-	return ""
+	return false
 }
 
-func (x *UpdateRoleRequest) GetClientPlatformId() string {
+func (x *RBACChange) GetParentClientType() string {
 	if x != nil {
-		return x.ClientPlatformId
+		return x.ParentClientType
 	}
 	return ""
 }
 
-func (x *UpdateRoleRequest) GetProjectId() string {
+func (x *RBACChange) GetParentRole() string {
 	if x != nil {
-		return x.ProjectId
+		return x.ParentRole
 	}
 	return ""
 }
 
-type RolePrimaryKey struct {
+type ApplyRBACConfigResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Plan    []*RBACChange `protobuf:"bytes,1,rep,name=plan,proto3" json:"plan,omitempty"`
+	Applied bool          `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
 }
 
-func (x *RolePrimaryKey) Reset() {
-	*x = RolePrimaryKey{}
+func (x *ApplyRBACConfigResponse) Reset() {
+	*x = ApplyRBACConfigResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[7]
+		mi := &file_permission_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RolePrimaryKey) String() string {
+func (x *ApplyRBACConfigResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RolePrimaryKey) ProtoMessage() {}
+func (*ApplyRBACConfigResponse) ProtoMessage() {}
 
-func (x *RolePrimaryKey) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[7]
+func (x *ApplyRBACConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,45 +505,56 @@ func (x *RolePrimaryKey) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RolePrimaryKey.ProtoReflect.Descriptor instead.
-func (*RolePrimaryKey) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ApplyRBACConfigResponse.ProtoReflect.Descriptor instead.
+func (*ApplyRBACConfigResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *RolePrimaryKey) GetId() string {
+func (x *ApplyRBACConfigResponse) GetPlan() []*RBACChange {
 	if x != nil {
-		return x.Id
+		return x.Plan
 	}
-	return ""
+	return nil
 }
 
-type CreatePermissionRequest struct {
+func (x *ApplyRBACConfigResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type GetPermissionByIDResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientPlatformId string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	ParentId         string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	Name             string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Id                string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientPlatformId  string             `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	ParentId          string             `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Name              string             `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	PermissionScopes  []*PermissionScope `protobuf:"bytes,5,rep,name=permission_scopes,json=permissionScopes,proto3" json:"permission_scopes,omitempty"`
+	RecentAuthMinutes int32              `protobuf:"varint,6,opt,name=recent_auth_minutes,json=recentAuthMinutes,proto3" json:"recent_auth_minutes,omitempty"`
+	RelationScoped    bool               `protobuf:"varint,7,opt,name=relation_scoped,json=relationScoped,proto3" json:"relation_scoped,omitempty"`
 }
 
-func (x *CreatePermissionRequest) Reset() {
-	*x = CreatePermissionRequest{}
+func (x *GetPermissionByIDResponse) Reset() {
+	*x = GetPermissionByIDResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[8]
+		mi := &file_permission_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreatePermissionRequest) String() string {
+func (x *GetPermissionByIDResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatePermissionRequest) ProtoMessage() {}
+func (*GetPermissionByIDResponse) ProtoMessage() {}
 
-func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[8]
+func (x *GetPermissionByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,57 +565,1433 @@ func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatePermissionRequest.ProtoReflect.Descriptor instead.
-func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{8}
-}
+// Deprecated: Use GetPermissionByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetPermissionByIDResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{7}
+}
 
-func (x *CreatePermissionRequest) GetClientPlatformId() string {
+func (x *GetPermissionByIDResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetPermissionByIDResponse) GetClientPlatformId() string {
 	if x != nil {
 		return x.ClientPlatformId
 	}
 	return ""
 }
 
-func (x *CreatePermissionRequest) GetParentId() string {
+func (x *GetPermissionByIDResponse) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *GetPermissionByIDResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetPermissionByIDResponse) GetPermissionScopes() []*PermissionScope {
+	if x != nil {
+		return x.PermissionScopes
+	}
+	return nil
+}
+
+func (x *GetPermissionByIDResponse) GetRecentAuthMinutes() int32 {
+	if x != nil {
+		return x.RecentAuthMinutes
+	}
+	return 0
+}
+
+func (x *GetPermissionByIDResponse) GetRelationScoped() bool {
+	if x != nil {
+		return x.RelationScoped
+	}
+	return false
+}
+
+type GetRoleByIdResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientTypeId string        `protobuf:"bytes,2,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
+	Name         string        `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ClientType   *ClientType   `protobuf:"bytes,4,opt,name=client_type,json=clientType,proto3" json:"client_type,omitempty"`
+	Permissions  []*Permission `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions,omitempty"` // effective permissions, see Permission.inherited
+	Parents      []*Role       `protobuf:"bytes,6,rep,name=parents,proto3" json:"parents,omitempty"`
+}
+
+func (x *GetRoleByIdResponse) Reset() {
+	*x = GetRoleByIdResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoleByIdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleByIdResponse) ProtoMessage() {}
+
+func (x *GetRoleByIdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleByIdResponse.ProtoReflect.Descriptor instead.
+func (*GetRoleByIdResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRoleByIdResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetRoleByIdResponse) GetClientTypeId() string {
+	if x != nil {
+		return x.ClientTypeId
+	}
+	return ""
+}
+
+func (x *GetRoleByIdResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetRoleByIdResponse) GetClientType() *ClientType {
+	if x != nil {
+		return x.ClientType
+	}
+	return nil
+}
+
+func (x *GetRoleByIdResponse) GetPermissions() []*Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *GetRoleByIdResponse) GetParents() []*Role {
+	if x != nil {
+		return x.Parents
+	}
+	return nil
+}
+
+type RoleParent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoleId   string `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	ParentId string `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+}
+
+func (x *RoleParent) Reset() {
+	*x = RoleParent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoleParent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleParent) ProtoMessage() {}
+
+func (x *RoleParent) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleParent.ProtoReflect.Descriptor instead.
+func (*RoleParent) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RoleParent) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *RoleParent) GetParentId() string {
 	if x != nil {
 		return x.ParentId
 	}
 	return ""
 }
 
-func (x *CreatePermissionRequest) GetName() string {
+type UpsertScopeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	Path             string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Method           string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *UpsertScopeRequest) Reset() {
+	*x = UpsertScopeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpsertScopeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertScopeRequest) ProtoMessage() {}
+
+func (x *UpsertScopeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertScopeRequest.ProtoReflect.Descriptor instead.
+func (*UpsertScopeRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpsertScopeRequest) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *UpsertScopeRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *UpsertScopeRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type ScopePrimaryKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	Path             string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Method           string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *ScopePrimaryKey) Reset() {
+	*x = ScopePrimaryKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScopePrimaryKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScopePrimaryKey) ProtoMessage() {}
+
+func (x *ScopePrimaryKey) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScopePrimaryKey.ProtoReflect.Descriptor instead.
+func (*ScopePrimaryKey) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ScopePrimaryKey) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *ScopePrimaryKey) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ScopePrimaryKey) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type AddRoleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientTypeId     string `protobuf:"bytes,2,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
+	Name             string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,4,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	ProjectId        string `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+}
+
+func (x *AddRoleRequest) Reset() {
+	*x = AddRoleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRoleRequest) ProtoMessage() {}
+
+func (x *AddRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRoleRequest.ProtoReflect.Descriptor instead.
+func (*AddRoleRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddRoleRequest) GetClientTypeId() string {
+	if x != nil {
+		return x.ClientTypeId
+	}
+	return ""
+}
+
+func (x *AddRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddRoleRequest) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *AddRoleRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type UpdateRoleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientTypeId     string `protobuf:"bytes,2,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
+	Name             string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,4,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	ProjectId        string `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+}
+
+func (x *UpdateRoleRequest) Reset() {
+	*x = UpdateRoleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoleRequest) ProtoMessage() {}
+
+func (x *UpdateRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoleRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateRoleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetClientTypeId() string {
+	if x != nil {
+		return x.ClientTypeId
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *UpdateRoleRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type RolePrimaryKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RolePrimaryKey) Reset() {
+	*x = RolePrimaryKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RolePrimaryKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolePrimaryKey) ProtoMessage() {}
+
+func (x *RolePrimaryKey) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolePrimaryKey.ProtoReflect.Descriptor instead.
+func (*RolePrimaryKey) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RolePrimaryKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreatePermissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientPlatformId  string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	ParentId          string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Name              string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	RecentAuthMinutes int32  `protobuf:"varint,5,opt,name=recent_auth_minutes,json=recentAuthMinutes,proto3" json:"recent_auth_minutes,omitempty"`
+	RelationScoped    bool   `protobuf:"varint,6,opt,name=relation_scoped,json=relationScoped,proto3" json:"relation_scoped,omitempty"`
+}
+
+func (x *CreatePermissionRequest) Reset() {
+	*x = CreatePermissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePermissionRequest) ProtoMessage() {}
+
+func (x *CreatePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePermissionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreatePermissionRequest) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *CreatePermissionRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreatePermissionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePermissionRequest) GetRecentAuthMinutes() int32 {
+	if x != nil {
+		return x.RecentAuthMinutes
+	}
+	return 0
+}
+
+func (x *CreatePermissionRequest) GetRelationScoped() bool {
+	if x != nil {
+		return x.RelationScoped
+	}
+	return false
+}
+
+type PermissionPrimaryKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PermissionPrimaryKey) Reset() {
+	*x = PermissionPrimaryKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PermissionPrimaryKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionPrimaryKey) ProtoMessage() {}
+
+func (x *PermissionPrimaryKey) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionPrimaryKey.ProtoReflect.Descriptor instead.
+func (*PermissionPrimaryKey) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PermissionPrimaryKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPermissionListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit  int32  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Search string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+}
+
+func (x *GetPermissionListRequest) Reset() {
+	*x = GetPermissionListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPermissionListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPermissionListRequest) ProtoMessage() {}
+
+func (x *GetPermissionListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPermissionListRequest.ProtoReflect.Descriptor instead.
+func (*GetPermissionListRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetPermissionListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPermissionListRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetPermissionListRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+type GetPermissionListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count       int32         `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Permissions []*Permission `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+}
+
+func (x *GetPermissionListResponse) Reset() {
+	*x = GetPermissionListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPermissionListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPermissionListResponse) ProtoMessage() {}
+
+func (x *GetPermissionListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPermissionListResponse.ProtoReflect.Descriptor instead.
+func (*GetPermissionListResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetPermissionListResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetPermissionListResponse) GetPermissions() []*Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type UpdatePermissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientPlatformId  string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	ParentId          string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Name              string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	RecentAuthMinutes int32  `protobuf:"varint,5,opt,name=recent_auth_minutes,json=recentAuthMinutes,proto3" json:"recent_auth_minutes,omitempty"`
+	RelationScoped    bool   `protobuf:"varint,6,opt,name=relation_scoped,json=relationScoped,proto3" json:"relation_scoped,omitempty"`
+}
+
+func (x *UpdatePermissionRequest) Reset() {
+	*x = UpdatePermissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdatePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePermissionRequest) ProtoMessage() {}
+
+func (x *UpdatePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePermissionRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdatePermissionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePermissionRequest) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *UpdatePermissionRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *UpdatePermissionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePermissionRequest) GetRecentAuthMinutes() int32 {
+	if x != nil {
+		return x.RecentAuthMinutes
+	}
+	return 0
+}
+
+func (x *UpdatePermissionRequest) GetRelationScoped() bool {
+	if x != nil {
+		return x.RelationScoped
+	}
+	return false
+}
+
+type AddPermissionScopeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PermissionId     string `protobuf:"bytes,1,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	Path             string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Method           string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *AddPermissionScopeRequest) Reset() {
+	*x = AddPermissionScopeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPermissionScopeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPermissionScopeRequest) ProtoMessage() {}
+
+func (x *AddPermissionScopeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPermissionScopeRequest.ProtoReflect.Descriptor instead.
+func (*AddPermissionScopeRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AddPermissionScopeRequest) GetPermissionId() string {
+	if x != nil {
+		return x.PermissionId
+	}
+	return ""
+}
+
+func (x *AddPermissionScopeRequest) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *AddPermissionScopeRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *AddPermissionScopeRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type PermissionScopePrimaryKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PermissionId     string `protobuf:"bytes,1,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	Path             string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Method           string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *PermissionScopePrimaryKey) Reset() {
+	*x = PermissionScopePrimaryKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PermissionScopePrimaryKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionScopePrimaryKey) ProtoMessage() {}
+
+func (x *PermissionScopePrimaryKey) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionScopePrimaryKey.ProtoReflect.Descriptor instead.
+func (*PermissionScopePrimaryKey) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PermissionScopePrimaryKey) GetPermissionId() string {
+	if x != nil {
+		return x.PermissionId
+	}
+	return ""
+}
+
+func (x *PermissionScopePrimaryKey) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *PermissionScopePrimaryKey) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PermissionScopePrimaryKey) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type AddRolePermissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoleId       string `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	PermissionId string `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+}
+
+func (x *AddRolePermissionRequest) Reset() {
+	*x = AddRolePermissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRolePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRolePermissionRequest) ProtoMessage() {}
+
+func (x *AddRolePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRolePermissionRequest.ProtoReflect.Descriptor instead.
+func (*AddRolePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AddRolePermissionRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *AddRolePermissionRequest) GetPermissionId() string {
+	if x != nil {
+		return x.PermissionId
+	}
+	return ""
+}
+
+type AddRolePermissionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Permissions []*AddRolePermissionRequest `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+}
+
+func (x *AddRolePermissionsRequest) Reset() {
+	*x = AddRolePermissionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRolePermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRolePermissionsRequest) ProtoMessage() {}
+
+func (x *AddRolePermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRolePermissionsRequest.ProtoReflect.Descriptor instead.
+func (*AddRolePermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddRolePermissionsRequest) GetPermissions() []*AddRolePermissionRequest {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type AddRolePermissionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AddedRoles int64 `protobuf:"varint,1,opt,name=added_roles,json=addedRoles,proto3" json:"added_roles,omitempty"`
+}
+
+func (x *AddRolePermissionsResponse) Reset() {
+	*x = AddRolePermissionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRolePermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRolePermissionsResponse) ProtoMessage() {}
+
+func (x *AddRolePermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRolePermissionsResponse.ProtoReflect.Descriptor instead.
+func (*AddRolePermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddRolePermissionsResponse) GetAddedRoles() int64 {
+	if x != nil {
+		return x.AddedRoles
+	}
+	return 0
+}
+
+type RolePermissionPrimaryKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoleId       string `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	PermissionId string `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+}
+
+func (x *RolePermissionPrimaryKey) Reset() {
+	*x = RolePermissionPrimaryKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RolePermissionPrimaryKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolePermissionPrimaryKey) ProtoMessage() {}
+
+func (x *RolePermissionPrimaryKey) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolePermissionPrimaryKey.ProtoReflect.Descriptor instead.
+func (*RolePermissionPrimaryKey) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RolePermissionPrimaryKey) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *RolePermissionPrimaryKey) GetPermissionId() string {
+	if x != nil {
+		return x.PermissionId
+	}
+	return ""
+}
+
+type GetRolesListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset           uint32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit            uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,3,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	ClientTypeId     string `protobuf:"bytes,4,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
+}
+
+func (x *GetRolesListRequest) Reset() {
+	*x = GetRolesListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRolesListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRolesListRequest) ProtoMessage() {}
+
+func (x *GetRolesListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRolesListRequest.ProtoReflect.Descriptor instead.
+func (*GetRolesListRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetRolesListRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetRolesListRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRolesListRequest) GetClientPlatformId() string {
+	if x != nil {
+		return x.ClientPlatformId
+	}
+	return ""
+}
+
+func (x *GetRolesListRequest) GetClientTypeId() string {
+	if x != nil {
+		return x.ClientTypeId
+	}
+	return ""
+}
+
+type GetRolesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Roles []*Role `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+}
+
+func (x *GetRolesResponse) Reset() {
+	*x = GetRolesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRolesResponse) ProtoMessage() {}
+
+func (x *GetRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRolesResponse.ProtoReflect.Descriptor instead.
+func (*GetRolesResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetRolesResponse) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type GetScopeListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit            uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset           uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Search           string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	OrderBy          string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	OrderType        string `protobuf:"bytes,5,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,6,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+}
+
+func (x *GetScopeListRequest) Reset() {
+	*x = GetScopeListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetScopeListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScopeListRequest) ProtoMessage() {}
+
+func (x *GetScopeListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScopeListRequest.ProtoReflect.Descriptor instead.
+func (*GetScopeListRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetScopeListRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetScopeListRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetScopeListRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *GetScopeListRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *GetScopeListRequest) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *GetScopeListRequest) GetClientPlatformId() string {
 	if x != nil {
-		return x.Name
+		return x.ClientPlatformId
 	}
 	return ""
 }
 
-type PermissionPrimaryKey struct {
+type GetScopesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Count  uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Scopes []*Scope `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
 }
 
-func (x *PermissionPrimaryKey) Reset() {
-	*x = PermissionPrimaryKey{}
+func (x *GetScopesResponse) Reset() {
+	*x = GetScopesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[9]
+		mi := &file_permission_service_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PermissionPrimaryKey) String() string {
+func (x *GetScopesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PermissionPrimaryKey) ProtoMessage() {}
+func (*GetScopesResponse) ProtoMessage() {}
 
-func (x *PermissionPrimaryKey) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[9]
+func (x *GetScopesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,45 +2002,56 @@ func (x *PermissionPrimaryKey) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PermissionPrimaryKey.ProtoReflect.Descriptor instead.
-func (*PermissionPrimaryKey) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetScopesResponse.ProtoReflect.Descriptor instead.
+func (*GetScopesResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *PermissionPrimaryKey) GetId() string {
+func (x *GetScopesResponse) GetCount() uint32 {
 	if x != nil {
-		return x.Id
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-type GetPermissionListRequest struct {
+func (x *GetScopesResponse) GetScopes() []*Scope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type CreateDenyRuleRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Limit  int32  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset int32  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Search string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	RoleId           string `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	UserId           string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PermissionId     string `protobuf:"bytes,4,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+	Method           string `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
+	PathPattern      string `protobuf:"bytes,6,opt,name=path_pattern,json=pathPattern,proto3" json:"path_pattern,omitempty"`
+	Reason           string `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *GetPermissionListRequest) Reset() {
-	*x = GetPermissionListRequest{}
+func (x *CreateDenyRuleRequest) Reset() {
+	*x = CreateDenyRuleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[10]
+		mi := &file_permission_service_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetPermissionListRequest) String() string {
+func (x *CreateDenyRuleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPermissionListRequest) ProtoMessage() {}
+func (*CreateDenyRuleRequest) ProtoMessage() {}
 
-func (x *GetPermissionListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[10]
+func (x *CreateDenyRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,58 +2062,85 @@ func (x *GetPermissionListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPermissionListRequest.ProtoReflect.Descriptor instead.
-func (*GetPermissionListRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CreateDenyRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateDenyRuleRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *GetPermissionListRequest) GetLimit() int32 {
+func (x *CreateDenyRuleRequest) GetClientPlatformId() string {
 	if x != nil {
-		return x.Limit
+		return x.ClientPlatformId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetPermissionListRequest) GetOffset() int32 {
+func (x *CreateDenyRuleRequest) GetRoleId() string {
 	if x != nil {
-		return x.Offset
+		return x.RoleId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetPermissionListRequest) GetSearch() string {
+func (x *CreateDenyRuleRequest) GetUserId() string {
 	if x != nil {
-		return x.Search
+		return x.UserId
 	}
 	return ""
 }
 
-type GetPermissionListResponse struct {
+func (x *CreateDenyRuleRequest) GetPermissionId() string {
+	if x != nil {
+		return x.PermissionId
+	}
+	return ""
+}
+
+func (x *CreateDenyRuleRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *CreateDenyRuleRequest) GetPathPattern() string {
+	if x != nil {
+		return x.PathPattern
+	}
+	return ""
+}
+
+func (x *CreateDenyRuleRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DenyRulePrimaryKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Count       int32         `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	Permissions []*Permission `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *GetPermissionListResponse) Reset() {
-	*x = GetPermissionListResponse{}
+func (x *DenyRulePrimaryKey) Reset() {
+	*x = DenyRulePrimaryKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[11]
+		mi := &file_permission_service_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetPermissionListResponse) String() string {
+func (x *DenyRulePrimaryKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPermissionListResponse) ProtoMessage() {}
+func (*DenyRulePrimaryKey) ProtoMessage() {}
 
-func (x *GetPermissionListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[11]
+func (x *DenyRulePrimaryKey) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -758,53 +2151,46 @@ func (x *GetPermissionListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPermissionListResponse.ProtoReflect.Descriptor instead.
-func (*GetPermissionListResponse) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *GetPermissionListResponse) GetCount() int32 {
-	if x != nil {
-		return x.Count
-	}
-	return 0
+// Deprecated: Use DenyRulePrimaryKey.ProtoReflect.Descriptor instead.
+func (*DenyRulePrimaryKey) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *GetPermissionListResponse) GetPermissions() []*Permission {
+func (x *DenyRulePrimaryKey) GetId() string {
 	if x != nil {
-		return x.Permissions
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type UpdatePermissionRequest struct {
+// GetDenyRuleListRequest role_id and user_id are optional filters
+type GetDenyRuleListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id               string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ClientPlatformId string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	ParentId         string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	Name             string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	RoleId           string `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	UserId           string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *UpdatePermissionRequest) Reset() {
-	*x = UpdatePermissionRequest{}
+func (x *GetDenyRuleListRequest) Reset() {
+	*x = GetDenyRuleListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[12]
+		mi := &file_permission_service_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdatePermissionRequest) String() string {
+func (x *GetDenyRuleListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdatePermissionRequest) ProtoMessage() {}
+func (*GetDenyRuleListRequest) ProtoMessage() {}
 
-func (x *UpdatePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[12]
+func (x *GetDenyRuleListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,67 +2201,113 @@ func (x *UpdatePermissionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdatePermissionRequest.ProtoReflect.Descriptor instead.
-func (*UpdatePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetDenyRuleListRequest.ProtoReflect.Descriptor instead.
+func (*GetDenyRuleListRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *UpdatePermissionRequest) GetId() string {
+func (x *GetDenyRuleListRequest) GetClientPlatformId() string {
 	if x != nil {
-		return x.Id
+		return x.ClientPlatformId
 	}
 	return ""
 }
 
-func (x *UpdatePermissionRequest) GetClientPlatformId() string {
+func (x *GetDenyRuleListRequest) GetRoleId() string {
 	if x != nil {
-		return x.ClientPlatformId
+		return x.RoleId
 	}
 	return ""
 }
 
-func (x *UpdatePermissionRequest) GetParentId() string {
+func (x *GetDenyRuleListRequest) GetUserId() string {
 	if x != nil {
-		return x.ParentId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *UpdatePermissionRequest) GetName() string {
+type GetDenyRuleListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DenyRules []*DenyRule `protobuf:"bytes,1,rep,name=deny_rules,json=denyRules,proto3" json:"deny_rules,omitempty"`
+}
+
+func (x *GetDenyRuleListResponse) Reset() {
+	*x = GetDenyRuleListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_permission_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDenyRuleListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDenyRuleListResponse) ProtoMessage() {}
+
+func (x *GetDenyRuleListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDenyRuleListResponse.ProtoReflect.Descriptor instead.
+func (*GetDenyRuleListResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDenyRuleListResponse) GetDenyRules() []*DenyRule {
 	if x != nil {
-		return x.Name
+		return x.DenyRules
 	}
-	return ""
+	return nil
 }
 
-type AddPermissionScopeRequest struct {
+// ExplainAccessRequest the subject is either user_id or role_id, add_role_ids and remove_role_ids
+// change the roles of the subject for the explanation only, nothing is saved
+type ExplainAccessRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PermissionId     string `protobuf:"bytes,1,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
-	ClientPlatformId string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	Path             string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	Method           string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	UserId           string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoleId           string   `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	ClientPlatformId string   `protobuf:"bytes,3,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	Path             string   `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Method           string   `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
+	RelationId       string   `protobuf:"bytes,6,opt,name=relation_id,json=relationId,proto3" json:"relation_id,omitempty"`
+	AddRoleIds       []string `protobuf:"bytes,7,rep,name=add_role_ids,json=addRoleIds,proto3" json:"add_role_ids,omitempty"`
+	RemoveRoleIds    []string `protobuf:"bytes,8,rep,name=remove_role_ids,json=removeRoleIds,proto3" json:"remove_role_ids,omitempty"`
 }
 
-func (x *AddPermissionScopeRequest) Reset() {
-	*x = AddPermissionScopeRequest{}
+func (x *ExplainAccessRequest) Reset() {
+	*x = ExplainAccessRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[13]
+		mi := &file_permission_service_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddPermissionScopeRequest) String() string {
+func (x *ExplainAccessRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddPermissionScopeRequest) ProtoMessage() {}
+func (*ExplainAccessRequest) ProtoMessage() {}
 
-func (x *AddPermissionScopeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[13]
+func (x *ExplainAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -886,67 +2318,97 @@ func (x *AddPermissionScopeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddPermissionScopeRequest.ProtoReflect.Descriptor instead.
-func (*AddPermissionScopeRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ExplainAccessRequest.ProtoReflect.Descriptor instead.
+func (*ExplainAccessRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *AddPermissionScopeRequest) GetPermissionId() string {
+func (x *ExplainAccessRequest) GetUserId() string {
 	if x != nil {
-		return x.PermissionId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *AddPermissionScopeRequest) GetClientPlatformId() string {
+func (x *ExplainAccessRequest) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *ExplainAccessRequest) GetClientPlatformId() string {
 	if x != nil {
 		return x.ClientPlatformId
 	}
 	return ""
 }
 
-func (x *AddPermissionScopeRequest) GetPath() string {
+func (x *ExplainAccessRequest) GetPath() string {
 	if x != nil {
 		return x.Path
 	}
 	return ""
 }
 
-func (x *AddPermissionScopeRequest) GetMethod() string {
+func (x *ExplainAccessRequest) GetMethod() string {
 	if x != nil {
 		return x.Method
 	}
 	return ""
 }
 
-type PermissionScopePrimaryKey struct {
+func (x *ExplainAccessRequest) GetRelationId() string {
+	if x != nil {
+		return x.RelationId
+	}
+	return ""
+}
+
+func (x *ExplainAccessRequest) GetAddRoleIds() []string {
+	if x != nil {
+		return x.AddRoleIds
+	}
+	return nil
+}
+
+func (x *ExplainAccessRequest) GetRemoveRoleIds() []string {
+	if x != nil {
+		return x.RemoveRoleIds
+	}
+	return nil
+}
+
+// AccessTracePermission is a permission having the requested scope
+type AccessTracePermission struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PermissionId     string `protobuf:"bytes,1,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
-	ClientPlatformId string `protobuf:"bytes,2,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	Path             string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	Method           string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	PermissionId   string   `protobuf:"bytes,1,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+	Name           string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RelationScoped bool     `protobuf:"varint,3,opt,name=relation_scoped,json=relationScoped,proto3" json:"relation_scoped,omitempty"`
+	RoleIds        []string `protobuf:"bytes,4,rep,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"` // roles of the subject granting it, ancestors included, nearest first
+	GrantedToUser  bool     `protobuf:"varint,5,opt,name=granted_to_user,json=grantedToUser,proto3" json:"granted_to_user,omitempty"`
 }
 
-func (x *PermissionScopePrimaryKey) Reset() {
-	*x = PermissionScopePrimaryKey{}
+func (x *AccessTracePermission) Reset() {
+	*x = AccessTracePermission{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[14]
+		mi := &file_permission_service_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PermissionScopePrimaryKey) String() string {
+func (x *AccessTracePermission) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PermissionScopePrimaryKey) ProtoMessage() {}
+func (*AccessTracePermission) ProtoMessage() {}
 
-func (x *PermissionScopePrimaryKey) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[14]
+func (x *AccessTracePermission) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -957,65 +2419,87 @@ func (x *PermissionScopePrimaryKey) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PermissionScopePrimaryKey.ProtoReflect.Descriptor instead.
-func (*PermissionScopePrimaryKey) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use AccessTracePermission.ProtoReflect.Descriptor instead.
+func (*AccessTracePermission) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *PermissionScopePrimaryKey) GetPermissionId() string {
+func (x *AccessTracePermission) GetPermissionId() string {
 	if x != nil {
 		return x.PermissionId
 	}
 	return ""
 }
 
-func (x *PermissionScopePrimaryKey) GetClientPlatformId() string {
+func (x *AccessTracePermission) GetName() string {
 	if x != nil {
-		return x.ClientPlatformId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *PermissionScopePrimaryKey) GetPath() string {
+func (x *AccessTracePermission) GetRelationScoped() bool {
 	if x != nil {
-		return x.Path
+		return x.RelationScoped
 	}
-	return ""
+	return false
 }
 
-func (x *PermissionScopePrimaryKey) GetMethod() string {
+func (x *AccessTracePermission) GetRoleIds() []string {
 	if x != nil {
-		return x.Method
+		return x.RoleIds
 	}
-	return ""
+	return nil
 }
 
-type AddRolePermissionRequest struct {
+func (x *AccessTracePermission) GetGrantedToUser() bool {
+	if x != nil {
+		return x.GrantedToUser
+	}
+	return false
+}
+
+type ExplainAccessResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoleId       string `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
-	PermissionId string `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
-}
-
-func (x *AddRolePermissionRequest) Reset() {
-	*x = AddRolePermissionRequest{}
+	Allowed           bool                     `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Reason            string                   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	PermissionId      string                   `protobuf:"bytes,3,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"` // the permission allowing the request
+	RoleId            string                   `protobuf:"bytes,4,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`                   // the role granting it, empty for a grant to the user
+	RelationScoped    bool                     `protobuf:"varint,5,opt,name=relation_scoped,json=relationScoped,proto3" json:"relation_scoped,omitempty"`
+	DenyRule          *DenyRule                `protobuf:"bytes,6,opt,name=deny_rule,json=denyRule,proto3" json:"deny_rule,omitempty"`                           // the rule the request is denied by
+	RoleIds           []string                 `protobuf:"bytes,7,rep,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`                              // roles the request was evaluated with, hypothetical changes applied
+	InheritedRoleIds  []string                 `protobuf:"bytes,8,rep,name=inherited_role_ids,json=inheritedRoleIds,proto3" json:"inherited_role_ids,omitempty"` // ancestors of role_ids
+	Permissions       []*AccessTracePermission `protobuf:"bytes,9,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	DenyRules         []*DenyRule              `protobuf:"bytes,10,rep,name=deny_rules,json=denyRules,proto3" json:"deny_rules,omitempty"`                // every rule matching the request
+	InactiveRoles     []*UserRole              `protobuf:"bytes,11,rep,name=inactive_roles,json=inactiveRoles,proto3" json:"inactive_roles,omitempty"`    // assignments out of their dates whose roles would grant the scope
+	InactiveGrants    []*UserPermission        `protobuf:"bytes,12,rep,name=inactive_grants,json=inactiveGrants,proto3" json:"inactive_grants,omitempty"` // grants of permissions of the scope out of their dates
+	UserState         UserStates               `protobuf:"varint,13,opt,name=user_state,json=userState,proto3,enum=auth_service.UserStates" json:"user_state,omitempty"`
+	UserStateError    string                   `protobuf:"bytes,14,opt,name=user_state_error,json=userStateError,proto3" json:"user_state_error,omitempty"`           // why the state of the user blocks access, empty if it doesn't
+	RelationIds       []string                 `protobuf:"bytes,15,rep,name=relation_ids,json=relationIds,proto3" json:"relation_ids,omitempty"`                      // relations of the user, descendants included
+	RecentAuthMinutes int32                    `protobuf:"varint,16,opt,name=recent_auth_minutes,json=recentAuthMinutes,proto3" json:"recent_auth_minutes,omitempty"` // re-authentication window a session would need
+	Hypothetical      bool                     `protobuf:"varint,17,opt,name=hypothetical,proto3" json:"hypothetical,omitempty"`                                      // roles were changed by the request
+}
+
+func (x *ExplainAccessResponse) Reset() {
+	*x = ExplainAccessResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[15]
+		mi := &file_permission_service_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddRolePermissionRequest) String() string {
+func (x *ExplainAccessResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddRolePermissionRequest) ProtoMessage() {}
+func (*ExplainAccessResponse) ProtoMessage() {}
 
-func (x *AddRolePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[15]
+func (x *ExplainAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,145 +2510,156 @@ func (x *AddRolePermissionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddRolePermissionRequest.ProtoReflect.Descriptor instead.
-func (*AddRolePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ExplainAccessResponse.ProtoReflect.Descriptor instead.
+func (*ExplainAccessResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ExplainAccessResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
 }
 
-func (x *AddRolePermissionRequest) GetRoleId() string {
+func (x *ExplainAccessResponse) GetReason() string {
 	if x != nil {
-		return x.RoleId
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *AddRolePermissionRequest) GetPermissionId() string {
+func (x *ExplainAccessResponse) GetPermissionId() string {
 	if x != nil {
 		return x.PermissionId
 	}
 	return ""
 }
 
-type AddRolePermissionsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Permissions []*AddRolePermissionRequest `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+func (x *ExplainAccessResponse) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
 }
 
-func (x *AddRolePermissionsRequest) Reset() {
-	*x = AddRolePermissionsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[16]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ExplainAccessResponse) GetRelationScoped() bool {
+	if x != nil {
+		return x.RelationScoped
 	}
+	return false
 }
 
-func (x *AddRolePermissionsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExplainAccessResponse) GetDenyRule() *DenyRule {
+	if x != nil {
+		return x.DenyRule
+	}
+	return nil
 }
 
-func (*AddRolePermissionsRequest) ProtoMessage() {}
-
-func (x *AddRolePermissionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[16]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ExplainAccessResponse) GetRoleIds() []string {
+	if x != nil {
+		return x.RoleIds
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use AddRolePermissionsRequest.ProtoReflect.Descriptor instead.
-func (*AddRolePermissionsRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{16}
+func (x *ExplainAccessResponse) GetInheritedRoleIds() []string {
+	if x != nil {
+		return x.InheritedRoleIds
+	}
+	return nil
 }
 
-func (x *AddRolePermissionsRequest) GetPermissions() []*AddRolePermissionRequest {
+func (x *ExplainAccessResponse) GetPermissions() []*AccessTracePermission {
 	if x != nil {
 		return x.Permissions
 	}
 	return nil
 }
 
-type AddRolePermissionsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	AddedRoles int64 `protobuf:"varint,1,opt,name=added_roles,json=addedRoles,proto3" json:"added_roles,omitempty"`
+func (x *ExplainAccessResponse) GetDenyRules() []*DenyRule {
+	if x != nil {
+		return x.DenyRules
+	}
+	return nil
 }
 
-func (x *AddRolePermissionsResponse) Reset() {
-	*x = AddRolePermissionsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[17]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ExplainAccessResponse) GetInactiveRoles() []*UserRole {
+	if x != nil {
+		return x.InactiveRoles
 	}
+	return nil
 }
 
-func (x *AddRolePermissionsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExplainAccessResponse) GetInactiveGrants() []*UserPermission {
+	if x != nil {
+		return x.InactiveGrants
+	}
+	return nil
 }
 
-func (*AddRolePermissionsResponse) ProtoMessage() {}
+func (x *ExplainAccessResponse) GetUserState() UserStates {
+	if x != nil {
+		return x.UserState
+	}
+	return UserStates_PENDING
+}
 
-func (x *AddRolePermissionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[17]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ExplainAccessResponse) GetUserStateError() string {
+	if x != nil {
+		return x.UserStateError
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use AddRolePermissionsResponse.ProtoReflect.Descriptor instead.
-func (*AddRolePermissionsResponse) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{17}
+func (x *ExplainAccessResponse) GetRelationIds() []string {
+	if x != nil {
+		return x.RelationIds
+	}
+	return nil
 }
 
-func (x *AddRolePermissionsResponse) GetAddedRoles() int64 {
+func (x *ExplainAccessResponse) GetRecentAuthMinutes() int32 {
 	if x != nil {
-		return x.AddedRoles
+		return x.RecentAuthMinutes
 	}
 	return 0
 }
 
-type RolePermissionPrimaryKey struct {
+func (x *ExplainAccessResponse) GetHypothetical() bool {
+	if x != nil {
+		return x.Hypothetical
+	}
+	return false
+}
+
+type GetPermissionMatrixRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoleId       string `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
-	PermissionId string `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"`
+	ClientPlatformId string `protobuf:"bytes,1,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	IncludeScopes    bool   `protobuf:"varint,2,opt,name=include_scopes,json=includeScopes,proto3" json:"include_scopes,omitempty"` // add the roles x scopes matrix
 }
 
-func (x *RolePermissionPrimaryKey) Reset() {
-	*x = RolePermissionPrimaryKey{}
+func (x *GetPermissionMatrixRequest) Reset() {
+	*x = GetPermissionMatrixRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[18]
+		mi := &file_permission_service_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RolePermissionPrimaryKey) String() string {
+func (x *GetPermissionMatrixRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RolePermissionPrimaryKey) ProtoMessage() {}
+func (*GetPermissionMatrixRequest) ProtoMessage() {}
 
-func (x *RolePermissionPrimaryKey) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[18]
+func (x *GetPermissionMatrixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1175,53 +2670,59 @@ func (x *RolePermissionPrimaryKey) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RolePermissionPrimaryKey.ProtoReflect.Descriptor instead.
-func (*RolePermissionPrimaryKey) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use GetPermissionMatrixRequest.ProtoReflect.Descriptor instead.
+func (*GetPermissionMatrixRequest) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *RolePermissionPrimaryKey) GetRoleId() string {
+func (x *GetPermissionMatrixRequest) GetClientPlatformId() string {
 	if x != nil {
-		return x.RoleId
+		return x.ClientPlatformId
 	}
 	return ""
 }
 
-func (x *RolePermissionPrimaryKey) GetPermissionId() string {
+func (x *GetPermissionMatrixRequest) GetIncludeScopes() bool {
 	if x != nil {
-		return x.PermissionId
+		return x.IncludeScopes
 	}
-	return ""
+	return false
 }
 
-type GetRolesListRequest struct {
+// PermissionMatrixCell is a permission or a scope a role has, directly or from an ancestor,
+// pairs without a grant have no cell
+type PermissionMatrixCell struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Offset           uint32 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
-	Limit            uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	ClientPlatformId string `protobuf:"bytes,3,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
-	ClientTypeId     string `protobuf:"bytes,4,opt,name=client_type_id,json=clientTypeId,proto3" json:"client_type_id,omitempty"`
+	RoleId          string `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	PermissionId    string `protobuf:"bytes,2,opt,name=permission_id,json=permissionId,proto3" json:"permission_id,omitempty"` // for a scope the permission allowing it
+	Method          string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`                                 // set for a scope only
+	Path            string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Allowed         bool   `protobuf:"varint,5,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	GrantedByRoleId string `protobuf:"bytes,6,opt,name=granted_by_role_id,json=grantedByRoleId,proto3" json:"granted_by_role_id,omitempty"` // the role itself or the ancestor it inherits the permission from
+	RelationScoped  bool   `protobuf:"varint,7,opt,name=relation_scoped,json=relationScoped,proto3" json:"relation_scoped,omitempty"`
+	DenyRuleId      string `protobuf:"bytes,8,opt,name=deny_rule_id,json=denyRuleId,proto3" json:"deny_rule_id,omitempty"` // the rule of the role or an ancestor overriding the grant
 }
 
-func (x *GetRolesListRequest) Reset() {
-	*x = GetRolesListRequest{}
+func (x *PermissionMatrixCell) Reset() {
+	*x = PermissionMatrixCell{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[19]
+		mi := &file_permission_service_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRolesListRequest) String() string {
+func (x *PermissionMatrixCell) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRolesListRequest) ProtoMessage() {}
+func (*PermissionMatrixCell) ProtoMessage() {}
 
-func (x *GetRolesListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[19]
+func (x *PermissionMatrixCell) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1232,116 +2733,96 @@ func (x *GetRolesListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRolesListRequest.ProtoReflect.Descriptor instead.
-func (*GetRolesListRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use PermissionMatrixCell.ProtoReflect.Descriptor instead.
+func (*PermissionMatrixCell) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetRolesListRequest) GetOffset() uint32 {
+func (x *PermissionMatrixCell) GetRoleId() string {
 	if x != nil {
-		return x.Offset
+		return x.RoleId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetRolesListRequest) GetLimit() uint32 {
+func (x *PermissionMatrixCell) GetPermissionId() string {
 	if x != nil {
-		return x.Limit
+		return x.PermissionId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetRolesListRequest) GetClientPlatformId() string {
+func (x *PermissionMatrixCell) GetMethod() string {
 	if x != nil {
-		return x.ClientPlatformId
+		return x.Method
 	}
 	return ""
 }
 
-func (x *GetRolesListRequest) GetClientTypeId() string {
+func (x *PermissionMatrixCell) GetPath() string {
 	if x != nil {
-		return x.ClientTypeId
+		return x.Path
 	}
 	return ""
 }
 
-type GetRolesResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Roles []*Role `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
-}
-
-func (x *GetRolesResponse) Reset() {
-	*x = GetRolesResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[20]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *PermissionMatrixCell) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
 	}
+	return false
 }
 
-func (x *GetRolesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetRolesResponse) ProtoMessage() {}
-
-func (x *GetRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *PermissionMatrixCell) GetGrantedByRoleId() string {
+	if x != nil {
+		return x.GrantedByRoleId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetRolesResponse.ProtoReflect.Descriptor instead.
-func (*GetRolesResponse) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{20}
+func (x *PermissionMatrixCell) GetRelationScoped() bool {
+	if x != nil {
+		return x.RelationScoped
+	}
+	return false
 }
 
-func (x *GetRolesResponse) GetRoles() []*Role {
+func (x *PermissionMatrixCell) GetDenyRuleId() string {
 	if x != nil {
-		return x.Roles
+		return x.DenyRuleId
 	}
-	return nil
+	return ""
 }
 
-type GetScopeListRequest struct {
+type GetPermissionMatrixResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Limit            uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset           uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Search           string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
-	OrderBy          string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
-	OrderType        string `protobuf:"bytes,5,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
-	ClientPlatformId string `protobuf:"bytes,6,opt,name=client_platform_id,json=clientPlatformId,proto3" json:"client_platform_id,omitempty"`
+	Roles           []*Role                 `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	Permissions     []*Permission           `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Scopes          []*Scope                `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	PermissionCells []*PermissionMatrixCell `protobuf:"bytes,4,rep,name=permission_cells,json=permissionCells,proto3" json:"permission_cells,omitempty"`
+	ScopeCells      []*PermissionMatrixCell `protobuf:"bytes,5,rep,name=scope_cells,json=scopeCells,proto3" json:"scope_cells,omitempty"`
 }
 
-func (x *GetScopeListRequest) Reset() {
-	*x = GetScopeListRequest{}
+func (x *GetPermissionMatrixResponse) Reset() {
+	*x = GetPermissionMatrixResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[21]
+		mi := &file_permission_service_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetScopeListRequest) String() string {
+func (x *GetPermissionMatrixResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScopeListRequest) ProtoMessage() {}
+func (*GetPermissionMatrixResponse) ProtoMessage() {}
 
-func (x *GetScopeListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[21]
+func (x *GetPermissionMatrixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1352,79 +2833,71 @@ func (x *GetScopeListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetScopeListRequest.ProtoReflect.Descriptor instead.
-func (*GetScopeListRequest) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *GetScopeListRequest) GetLimit() uint32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+// Deprecated: Use GetPermissionMatrixResponse.ProtoReflect.Descriptor instead.
+func (*GetPermissionMatrixResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetScopeListRequest) GetOffset() uint32 {
+func (x *GetPermissionMatrixResponse) GetRoles() []*Role {
 	if x != nil {
-		return x.Offset
+		return x.Roles
 	}
-	return 0
+	return nil
 }
 
-func (x *GetScopeListRequest) GetSearch() string {
+func (x *GetPermissionMatrixResponse) GetPermissions() []*Permission {
 	if x != nil {
-		return x.Search
+		return x.Permissions
 	}
-	return ""
+	return nil
 }
 
-func (x *GetScopeListRequest) GetOrderBy() string {
+func (x *GetPermissionMatrixResponse) GetScopes() []*Scope {
 	if x != nil {
-		return x.OrderBy
+		return x.Scopes
 	}
-	return ""
+	return nil
 }
 
-func (x *GetScopeListRequest) GetOrderType() string {
+func (x *GetPermissionMatrixResponse) GetPermissionCells() []*PermissionMatrixCell {
 	if x != nil {
-		return x.OrderType
+		return x.PermissionCells
 	}
-	return ""
+	return nil
 }
 
-func (x *GetScopeListRequest) GetClientPlatformId() string {
+func (x *GetPermissionMatrixResponse) GetScopeCells() []*PermissionMatrixCell {
 	if x != nil {
-		return x.ClientPlatformId
+		return x.ScopeCells
 	}
-	return ""
+	return nil
 }
 
-type GetScopesResponse struct {
+type ExportPermissionMatrixResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Count  uint32   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	Scopes []*Scope `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	Csv []byte `protobuf:"bytes,1,opt,name=csv,proto3" json:"csv,omitempty"` // a row per role, a column per permission and, with include_scopes, per scope
 }
 
-func (x *GetScopesResponse) Reset() {
-	*x = GetScopesResponse{}
+func (x *ExportPermissionMatrixResponse) Reset() {
+	*x = ExportPermissionMatrixResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[22]
+		mi := &file_permission_service_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetScopesResponse) String() string {
+func (x *ExportPermissionMatrixResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetScopesResponse) ProtoMessage() {}
+func (*ExportPermissionMatrixResponse) ProtoMessage() {}
 
-func (x *GetScopesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[22]
+func (x *ExportPermissionMatrixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_permission_service_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1434,22 +2907,15 @@ func (x *GetScopesResponse) ProtoReflect() protoreflect.Message {
 	}
 	return mi.MessageOf(x)
 }
-
-// Deprecated: Use GetScopesResponse.ProtoReflect.Descriptor instead.
-func (*GetScopesResponse) Descriptor() ([]byte, []int) {
-	return file_permission_service_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *GetScopesResponse) GetCount() uint32 {
-	if x != nil {
-		return x.Count
-	}
-	return 0
+
+// Deprecated: Use ExportPermissionMatrixResponse.ProtoReflect.Descriptor instead.
+func (*ExportPermissionMatrixResponse) Descriptor() ([]byte, []int) {
+	return file_permission_service_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetScopesResponse) GetScopes() []*Scope {
+func (x *ExportPermissionMatrixResponse) GetCsv() []byte {
 	if x != nil {
-		return x.Scopes
+		return x.Csv
 	}
 	return nil
 }
@@ -1467,7 +2933,7 @@ type PermissionGenerated_Permission struct {
 func (x *PermissionGenerated_Permission) Reset() {
 	*x = PermissionGenerated_Permission{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[23]
+		mi := &file_permission_service_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1480,7 +2946,7 @@ func (x *PermissionGenerated_Permission) String() string {
 func (*PermissionGenerated_Permission) ProtoMessage() {}
 
 func (x *PermissionGenerated_Permission) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[23]
+	mi := &file_permission_service_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1529,7 +2995,7 @@ type PermissionGenerated_Permission_Scope struct {
 func (x *PermissionGenerated_Permission_Scope) Reset() {
 	*x = PermissionGenerated_Permission_Scope{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_permission_service_proto_msgTypes[24]
+		mi := &file_permission_service_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1542,7 +3008,7 @@ func (x *PermissionGenerated_Permission_Scope) String() string {
 func (*PermissionGenerated_Permission_Scope) ProtoMessage() {}
 
 func (x *PermissionGenerated_Permission_Scope) ProtoReflect() protoreflect.Message {
-	mi := &file_permission_service_proto_msgTypes[24]
+	mi := &file_permission_service_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1580,221 +3046,477 @@ var file_permission_service_proto_rawDesc = []byte{
 	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xdd, 0x02, 0x0a, 0x13, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x6f, 0x22, 0xa1, 0x03, 0x0a, 0x13, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
 	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x4e, 0x0a, 0x0b, 0x70, 0x65, 0x72,
 	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c,
 	0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65,
 	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
 	0x64, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xf5, 0x01, 0x0a, 0x0a, 0x50, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x50, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x06, 0x73, 0x63,
-	0x6f, 0x70, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x1a, 0x31,
-	0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x22, 0xd6, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
-	0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a,
-	0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x4a,
-	0x0a, 0x11, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f,
-	0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x75, 0x74, 0x68,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x10, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0xd6, 0x01, 0x0a, 0x13, 0x47,
-	0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70,
-	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0b,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61,
-	0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x6e, 0x0a, 0x12, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x53, 0x63, 0x6f,
-	0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69,
 	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61,
-	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d,
-	0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x22, 0x6b, 0x0a, 0x0f, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x50, 0x72, 0x69, 0x6d,
-	0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x75, 0x6e, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x1a, 0xf5, 0x01,
+	0x0a, 0x0a, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x06,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x61,
+	0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65,
+	0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
+	0x65, 0x6e, 0x1a, 0x31, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0xd3, 0x01, 0x0a, 0x1b, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x75, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x41,
+	0x64, 0x64, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x5f, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x47, 0x0a, 0x17, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
 	0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f,
-	0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68,
-	0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
-	0x22, 0x97, 0x01, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a,
-	0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
-	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0xaa, 0x01, 0x0a, 0x11, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c,
-	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x20, 0x0a, 0x0e, 0x52, 0x6f, 0x6c, 0x65, 0x50,
-	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x78, 0x0a, 0x17, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70,
-	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
-	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x22, 0x26, 0x0a, 0x14, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x60, 0x0a, 0x18, 0x47,
-	0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f,
-	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x22, 0x6d, 0x0a,
-	0x19, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
-	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x3a, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x88, 0x01, 0x0a,
-	0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74,
-	0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x19, 0x41, 0x64, 0x64, 0x50,
-	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c,
+	0x72, 0x6d, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x18, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x42,
+	0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x77, 0x0a, 0x16, 0x41, 0x70, 0x70, 0x6c,
+	0x79, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x22, 0xa4, 0x03, 0x0a, 0x0a, 0x52, 0x42, 0x41, 0x43, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63,
+	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x41, 0x75,
+	0x74, 0x68, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x61, 0x0a, 0x17, 0x41, 0x70, 0x70, 0x6c,
+	0x79, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x52, 0x42, 0x41, 0x43, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x04, 0x70, 0x6c, 0x61,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x22, 0xaf, 0x02, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x49,
+	0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x4a, 0x0a, 0x11, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x52, 0x10, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x63,
+	0x6f, 0x70, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x69, 0x6e,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x22, 0x84, 0x02,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x39, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x70, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0x42, 0x0a, 0x0a, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x6e, 0x0a, 0x12, 0x55, 0x70, 0x73, 0x65,
+	0x72, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c,
+	0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x6b, 0x0a, 0x0f, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x97, 0x01, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22,
+	0xaa, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x20, 0x0a, 0x0e,
+	0x52, 0x6f, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xd1,
+	0x01, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c,
 	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c,
-	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
-	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x22, 0x9a, 0x01, 0x0a, 0x19, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b,
-	0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63,
+	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x41, 0x75,
+	0x74, 0x68, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x64, 0x22, 0x26, 0x0a, 0x14, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x60, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x22, 0x6d, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x3a, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe1, 0x01, 0x0a, 0x17,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e,
 	0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x22, 0x58, 0x0a, 0x18, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
-	0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70,
-	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x65, 0x0a, 0x19, 0x41,
-	0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
-	0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x64, 0x64,
+	0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x4d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x22,
+	0x9a, 0x01, 0x0a, 0x19, 0x41, 0x64, 0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x9a, 0x01, 0x0a,
+	0x19, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65,
+	0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x58, 0x0a, 0x18, 0x41, 0x64, 0x64,
 	0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x73, 0x22, 0x3d, 0x0a, 0x1a, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x52, 0x6f, 0x6c, 0x65,
-	0x73, 0x22, 0x58, 0x0a, 0x18, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x17, 0x0a,
-	0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70,
-	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x97, 0x01, 0x0a, 0x13,
-	0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74,
-	0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12,
-	0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x6f, 0x6c,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f,
-	0x6c, 0x65, 0x73, 0x22, 0xc3, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x1d, 0x0a, 0x0a,
-	0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x22, 0x65, 0x0a, 0x19, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x48, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0b, 0x70,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3d, 0x0a, 0x1a, 0x41, 0x64,
+	0x64, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x65,
+	0x64, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61,
+	0x64, 0x64, 0x65, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x18, 0x52, 0x6f, 0x6c,
+	0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x22, 0x97, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x22, 0x3c, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0xc3, 0x01, 0x0a, 0x13,
+	0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x42, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49,
+	0x64, 0x22, 0x56, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x06,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61,
+	0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0xef, 0x01, 0x0a, 0x15, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x50, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x24, 0x0a, 0x12, 0x44,
+	0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65,
+	0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x78, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63,
 	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69,
-	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50,
-	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x22, 0x56, 0x0a, 0x11, 0x47, 0x65, 0x74,
-	0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65,
-	0x73, 0x32, 0xc2, 0x0c, 0x0a, 0x11, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x6f,
-	0x6c, 0x65, 0x42, 0x79, 0x49, 0x64, 0x12, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72,
-	0x79, 0x4b, 0x65, 0x79, 0x1a, 0x21, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x79, 0x49, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0c, 0x47, 0x65, 0x74,
-	0x52, 0x6f, 0x6c, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x21, 0x2e, 0x61, 0x75, 0x74, 0x68,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65,
+	0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x50, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75,
+	0x6c, 0x65, 0x52, 0x09, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x8d, 0x02,
+	0x0a, 0x14, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x61, 0x64, 0x64, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x52, 0x6f,
+	0x6c, 0x65, 0x49, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f,
+	0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x73, 0x22, 0xbc, 0x01,
+	0x0a, 0x15, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x6f, 0x6c,
+	0x65, 0x49, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x5f,
+	0x74, 0x6f, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x67,
+	0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x55, 0x73, 0x65, 0x72, 0x22, 0x8c, 0x06, 0x0a,
+	0x15, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x12,
+	0x33, 0x0a, 0x09, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x64, 0x65, 0x6e, 0x79,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x73, 0x12,
+	0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x68, 0x65, 0x72, 0x69, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x69, 0x6e, 0x68,
+	0x65, 0x72, 0x69, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x73, 0x12, 0x45, 0x0a,
+	0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x75, 0x6c,
+	0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x09, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0e, 0x69,
+	0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x0d, 0x69, 0x6e, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x0f, 0x69, 0x6e,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x0e, 0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x47, 0x72, 0x61, 0x6e, 0x74,
+	0x73, 0x12, 0x37, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x09, 0x75, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x63, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68,
+	0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x79, 0x70, 0x6f, 0x74,
+	0x68, 0x65, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x68,
+	0x79, 0x70, 0x6f, 0x74, 0x68, 0x65, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x22, 0x71, 0x0a, 0x1a, 0x47,
+	0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x72,
+	0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0x92,
+	0x02, 0x0a, 0x14, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74,
+	0x72, 0x69, 0x78, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x12, 0x67,
+	0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64,
+	0x42, 0x79, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x6f, 0x70, 0x65,
+	0x64, 0x12, 0x20, 0x0a, 0x0c, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c,
+	0x65, 0x49, 0x64, 0x22, 0xc4, 0x02, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x3a, 0x0a,
+	0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x75, 0x74, 0x68,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x06,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x4d, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78,
+	0x43, 0x65, 0x6c, 0x6c, 0x52, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x43, 0x0a, 0x0b, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x63,
+	0x65, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x0a,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x32, 0x0a, 0x1e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61,
+	0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x73, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x63, 0x73, 0x76, 0x32, 0xfe,
+	0x13, 0x0a, 0x11, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x42,
+	0x79, 0x49, 0x64, 0x12, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65,
+	0x79, 0x1a, 0x21, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c,
+	0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x21, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x75, 0x74, 0x68,
 	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x07, 0x41,
+	0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x61, 0x75, 0x74, 0x68,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x00, 0x12,
+	0x40, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x1c, 0x2e,
+	0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c,
+	0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x1a, 0x12, 0x2e, 0x61, 0x75,
+	0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22,
+	0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x1a, 0x21, 0x2e, 0x61,
 	0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52,
-	0x6f, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d,
-	0x0a, 0x07, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68,
-	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x6f, 0x6c, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a,
-	0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x61, 0x75,
-	0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x61,
-	0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65,
-	0x22, 0x00, 0x12, 0x40, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65,
-	0x12, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x52, 0x6f, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x1a, 0x12,
-	0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f,
-	0x6c, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x65,
+	0x6f, 0x6c, 0x65, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x51, 0x0a, 0x10, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x50,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x1a,
+	0x21, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x65,
 	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f,
 	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x65,
 	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
@@ -1865,14 +3587,63 @@ var file_permission_service_proto_rawDesc = []byte{
 	0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x69,
 	0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x1a, 0x1c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73,
 	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x6d, 0x69,
 	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x21, 0x2e, 0x61, 0x75, 0x74, 0x68,
 	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x1a, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x1a, 0x29, 0x2e, 0x61,
+	0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x10, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x25, 0x2e,
+	0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60,
+	0x0a, 0x0f, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x24, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x42, 0x41, 0x43, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x42, 0x41, 0x43,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75,
+	0x6c, 0x65, 0x12, 0x23, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x22,
+	0x00, 0x12, 0x4c, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x65, 0x6e, 0x79, 0x52,
+	0x75, 0x6c, 0x65, 0x12, 0x20, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x4b, 0x65, 0x79, 0x1a, 0x16, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x22, 0x00, 0x12,
+	0x60, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x24, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6e, 0x79, 0x52,
+	0x75, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x5a, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x22, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6c, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61,
+	0x74, 0x72, 0x69, 0x78, 0x12, 0x28, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29,
+	0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65,
+	0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x72, 0x69,
+	0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x72, 0x0a, 0x16, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d,
+	0x61, 0x74, 0x72, 0x69, 0x78, 0x12, 0x28, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d,
+	0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
+	0x17, 0x5a, 0x15, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x75, 0x74, 0x68,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1887,93 +3658,149 @@ func file_permission_service_proto_rawDescGZIP() []byte {
 	return file_permission_service_proto_rawDescData
 }
 
-var file_permission_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_permission_service_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_permission_service_proto_goTypes = []interface{}{
 	(*PermissionGenerated)(nil),                  // 0: auth_service.PermissionGenerated
-	(*GetPermissionByIDResponse)(nil),            // 1: auth_service.GetPermissionByIDResponse
-	(*GetRoleByIdResponse)(nil),                  // 2: auth_service.GetRoleByIdResponse
-	(*UpsertScopeRequest)(nil),                   // 3: auth_service.UpsertScopeRequest
-	(*ScopePrimaryKey)(nil),                      // 4: auth_service.ScopePrimaryKey
-	(*AddRoleRequest)(nil),                       // 5: auth_service.AddRoleRequest
-	(*UpdateRoleRequest)(nil),                    // 6: auth_service.UpdateRoleRequest
-	(*RolePrimaryKey)(nil),                       // 7: auth_service.RolePrimaryKey
-	(*CreatePermissionRequest)(nil),              // 8: auth_service.CreatePermissionRequest
-	(*PermissionPrimaryKey)(nil),                 // 9: auth_service.PermissionPrimaryKey
-	(*GetPermissionListRequest)(nil),             // 10: auth_service.GetPermissionListRequest
-	(*GetPermissionListResponse)(nil),            // 11: auth_service.GetPermissionListResponse
-	(*UpdatePermissionRequest)(nil),              // 12: auth_service.UpdatePermissionRequest
-	(*AddPermissionScopeRequest)(nil),            // 13: auth_service.AddPermissionScopeRequest
-	(*PermissionScopePrimaryKey)(nil),            // 14: auth_service.PermissionScopePrimaryKey
-	(*AddRolePermissionRequest)(nil),             // 15: auth_service.AddRolePermissionRequest
-	(*AddRolePermissionsRequest)(nil),            // 16: auth_service.AddRolePermissionsRequest
-	(*AddRolePermissionsResponse)(nil),           // 17: auth_service.AddRolePermissionsResponse
-	(*RolePermissionPrimaryKey)(nil),             // 18: auth_service.RolePermissionPrimaryKey
-	(*GetRolesListRequest)(nil),                  // 19: auth_service.GetRolesListRequest
-	(*GetRolesResponse)(nil),                     // 20: auth_service.GetRolesResponse
-	(*GetScopeListRequest)(nil),                  // 21: auth_service.GetScopeListRequest
-	(*GetScopesResponse)(nil),                    // 22: auth_service.GetScopesResponse
-	(*PermissionGenerated_Permission)(nil),       // 23: auth_service.PermissionGenerated.Permission
-	(*PermissionGenerated_Permission_Scope)(nil), // 24: auth_service.PermissionGenerated.Permission.Scope
-	(*PermissionScope)(nil),                      // 25: auth_service.PermissionScope
-	(*ClientType)(nil),                           // 26: auth_service.ClientType
-	(*Permission)(nil),                           // 27: auth_service.Permission
-	(*Role)(nil),                                 // 28: auth_service.Role
-	(*Scope)(nil),                                // 29: auth_service.Scope
-	(*emptypb.Empty)(nil),                        // 30: google.protobuf.Empty
-	(*RolePermission)(nil),                       // 31: auth_service.RolePermission
+	(*PermissionGeneratedResponse)(nil),          // 1: auth_service.PermissionGeneratedResponse
+	(*ExportRBACConfigRequest)(nil),              // 2: auth_service.ExportRBACConfigRequest
+	(*ExportRBACConfigResponse)(nil),             // 3: auth_service.ExportRBACConfigResponse
+	(*ApplyRBACConfigRequest)(nil),               // 4: auth_service.ApplyRBACConfigRequest
+	(*RBACChange)(nil),                           // 5: auth_service.RBACChange
+	(*ApplyRBACConfigResponse)(nil),              // 6: auth_service.ApplyRBACConfigResponse
+	(*GetPermissionByIDResponse)(nil),            // 7: auth_service.GetPermissionByIDResponse
+	(*GetRoleByIdResponse)(nil),                  // 8: auth_service.GetRoleByIdResponse
+	(*RoleParent)(nil),                           // 9: auth_service.RoleParent
+	(*UpsertScopeRequest)(nil),                   // 10: auth_service.UpsertScopeRequest
+	(*ScopePrimaryKey)(nil),                      // 11: auth_service.ScopePrimaryKey
+	(*AddRoleRequest)(nil),                       // 12: auth_service.AddRoleRequest
+	(*UpdateRoleRequest)(nil),                    // 13: auth_service.UpdateRoleRequest
+	(*RolePrimaryKey)(nil),                       // 14: auth_service.RolePrimaryKey
+	(*CreatePermissionRequest)(nil),              // 15: auth_service.CreatePermissionRequest
+	(*PermissionPrimaryKey)(nil),                 // 16: auth_service.PermissionPrimaryKey
+	(*GetPermissionListRequest)(nil),             // 17: auth_service.GetPermissionListRequest
+	(*GetPermissionListResponse)(nil),            // 18: auth_service.GetPermissionListResponse
+	(*UpdatePermissionRequest)(nil),              // 19: auth_service.UpdatePermissionRequest
+	(*AddPermissionScopeRequest)(nil),            // 20: auth_service.AddPermissionScopeRequest
+	(*PermissionScopePrimaryKey)(nil),            // 21: auth_service.PermissionScopePrimaryKey
+	(*AddRolePermissionRequest)(nil),             // 22: auth_service.AddRolePermissionRequest
+	(*AddRolePermissionsRequest)(nil),            // 23: auth_service.AddRolePermissionsRequest
+	(*AddRolePermissionsResponse)(nil),           // 24: auth_service.AddRolePermissionsResponse
+	(*RolePermissionPrimaryKey)(nil),             // 25: auth_service.RolePermissionPrimaryKey
+	(*GetRolesListRequest)(nil),                  // 26: auth_service.GetRolesListRequest
+	(*GetRolesResponse)(nil),                     // 27: auth_service.GetRolesResponse
+	(*GetScopeListRequest)(nil),                  // 28: auth_service.GetScopeListRequest
+	(*GetScopesResponse)(nil),                    // 29: auth_service.GetScopesResponse
+	(*CreateDenyRuleRequest)(nil),                // 30: auth_service.CreateDenyRuleRequest
+	(*DenyRulePrimaryKey)(nil),                   // 31: auth_service.DenyRulePrimaryKey
+	(*GetDenyRuleListRequest)(nil),               // 32: auth_service.GetDenyRuleListRequest
+	(*GetDenyRuleListResponse)(nil),              // 33: auth_service.GetDenyRuleListResponse
+	(*ExplainAccessRequest)(nil),                 // 34: auth_service.ExplainAccessRequest
+	(*AccessTracePermission)(nil),                // 35: auth_service.AccessTracePermission
+	(*ExplainAccessResponse)(nil),                // 36: auth_service.ExplainAccessResponse
+	(*GetPermissionMatrixRequest)(nil),           // 37: auth_service.GetPermissionMatrixRequest
+	(*PermissionMatrixCell)(nil),                 // 38: auth_service.PermissionMatrixCell
+	(*GetPermissionMatrixResponse)(nil),          // 39: auth_service.GetPermissionMatrixResponse
+	(*ExportPermissionMatrixResponse)(nil),       // 40: auth_service.ExportPermissionMatrixResponse
+	(*PermissionGenerated_Permission)(nil),       // 41: auth_service.PermissionGenerated.Permission
+	(*PermissionGenerated_Permission_Scope)(nil), // 42: auth_service.PermissionGenerated.Permission.Scope
+	(*PermissionScope)(nil),                      // 43: auth_service.PermissionScope
+	(*ClientType)(nil),                           // 44: auth_service.ClientType
+	(*Permission)(nil),                           // 45: auth_service.Permission
+	(*Role)(nil),                                 // 46: auth_service.Role
+	(*Scope)(nil),                                // 47: auth_service.Scope
+	(*DenyRule)(nil),                             // 48: auth_service.DenyRule
+	(*UserRole)(nil),                             // 49: auth_service.UserRole
+	(*UserPermission)(nil),                       // 50: auth_service.UserPermission
+	(UserStates)(0),                              // 51: auth_service.UserStates
+	(*emptypb.Empty)(nil),                        // 52: google.protobuf.Empty
+	(*RolePermission)(nil),                       // 53: auth_service.RolePermission
 }
 var file_permission_service_proto_depIdxs = []int32{
-	23, // 0: auth_service.PermissionGenerated.permissions:type_name -> auth_service.PermissionGenerated.Permission
-	25, // 1: auth_service.GetPermissionByIDResponse.permission_scopes:type_name -> auth_service.PermissionScope
-	26, // 2: auth_service.GetRoleByIdResponse.client_type:type_name -> auth_service.ClientType
-	27, // 3: auth_service.GetRoleByIdResponse.permissions:type_name -> auth_service.Permission
-	27, // 4: auth_service.GetPermissionListResponse.permissions:type_name -> auth_service.Permission
-	15, // 5: auth_service.AddRolePermissionsRequest.permissions:type_name -> auth_service.AddRolePermissionRequest
-	28, // 6: auth_service.GetRolesResponse.roles:type_name -> auth_service.Role
-	29, // 7: auth_service.GetScopesResponse.scopes:type_name -> auth_service.Scope
-	24, // 8: auth_service.PermissionGenerated.Permission.scopes:type_name -> auth_service.PermissionGenerated.Permission.Scope
-	23, // 9: auth_service.PermissionGenerated.Permission.children:type_name -> auth_service.PermissionGenerated.Permission
-	7,  // 10: auth_service.PermissionService.GetRoleById:input_type -> auth_service.RolePrimaryKey
-	19, // 11: auth_service.PermissionService.GetRolesList:input_type -> auth_service.GetRolesListRequest
-	5,  // 12: auth_service.PermissionService.AddRole:input_type -> auth_service.AddRoleRequest
-	6,  // 13: auth_service.PermissionService.UpdateRole:input_type -> auth_service.UpdateRoleRequest
-	7,  // 14: auth_service.PermissionService.RemoveRole:input_type -> auth_service.RolePrimaryKey
-	8,  // 15: auth_service.PermissionService.CreatePermission:input_type -> auth_service.CreatePermissionRequest
-	9,  // 16: auth_service.PermissionService.GetPermissionByID:input_type -> auth_service.PermissionPrimaryKey
-	10, // 17: auth_service.PermissionService.GetPermissionList:input_type -> auth_service.GetPermissionListRequest
-	12, // 18: auth_service.PermissionService.UpdatePermission:input_type -> auth_service.UpdatePermissionRequest
-	9,  // 19: auth_service.PermissionService.DeletePermission:input_type -> auth_service.PermissionPrimaryKey
-	3,  // 20: auth_service.PermissionService.UpsertScope:input_type -> auth_service.UpsertScopeRequest
-	21, // 21: auth_service.PermissionService.GetScopeList:input_type -> auth_service.GetScopeListRequest
-	13, // 22: auth_service.PermissionService.AddPermissionScope:input_type -> auth_service.AddPermissionScopeRequest
-	14, // 23: auth_service.PermissionService.RemovePermissionScope:input_type -> auth_service.PermissionScopePrimaryKey
-	15, // 24: auth_service.PermissionService.AddRolePermission:input_type -> auth_service.AddRolePermissionRequest
-	16, // 25: auth_service.PermissionService.AddRolePermissions:input_type -> auth_service.AddRolePermissionsRequest
-	18, // 26: auth_service.PermissionService.RemoveRolePermission:input_type -> auth_service.RolePermissionPrimaryKey
-	0,  // 27: auth_service.PermissionService.PermissionList:input_type -> auth_service.PermissionGenerated
-	2,  // 28: auth_service.PermissionService.GetRoleById:output_type -> auth_service.GetRoleByIdResponse
-	20, // 29: auth_service.PermissionService.GetRolesList:output_type -> auth_service.GetRolesResponse
-	28, // 30: auth_service.PermissionService.AddRole:output_type -> auth_service.Role
-	28, // 31: auth_service.PermissionService.UpdateRole:output_type -> auth_service.Role
-	28, // 32: auth_service.PermissionService.RemoveRole:output_type -> auth_service.Role
-	1,  // 33: auth_service.PermissionService.CreatePermission:output_type -> auth_service.GetPermissionByIDResponse
-	1,  // 34: auth_service.PermissionService.GetPermissionByID:output_type -> auth_service.GetPermissionByIDResponse
-	11, // 35: auth_service.PermissionService.GetPermissionList:output_type -> auth_service.GetPermissionListResponse
-	1,  // 36: auth_service.PermissionService.UpdatePermission:output_type -> auth_service.GetPermissionByIDResponse
-	30, // 37: auth_service.PermissionService.DeletePermission:output_type -> google.protobuf.Empty
-	29, // 38: auth_service.PermissionService.UpsertScope:output_type -> auth_service.Scope
-	22, // 39: auth_service.PermissionService.GetScopeList:output_type -> auth_service.GetScopesResponse
-	25, // 40: auth_service.PermissionService.AddPermissionScope:output_type -> auth_service.PermissionScope
-	25, // 41: auth_service.PermissionService.RemovePermissionScope:output_type -> auth_service.PermissionScope
-	31, // 42: auth_service.PermissionService.AddRolePermission:output_type -> auth_service.RolePermission
-	17, // 43: auth_service.PermissionService.AddRolePermissions:output_type -> auth_service.AddRolePermissionsResponse
-	31, // 44: auth_service.PermissionService.RemoveRolePermission:output_type -> auth_service.RolePermission
-	30, // 45: auth_service.PermissionService.PermissionList:output_type -> google.protobuf.Empty
-	28, // [28:46] is the sub-list for method output_type
-	10, // [10:28] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	41, // 0: auth_service.PermissionGenerated.permissions:type_name -> auth_service.PermissionGenerated.Permission
+	5,  // 1: auth_service.ApplyRBACConfigResponse.plan:type_name -> auth_service.RBACChange
+	43, // 2: auth_service.GetPermissionByIDResponse.permission_scopes:type_name -> auth_service.PermissionScope
+	44, // 3: auth_service.GetRoleByIdResponse.client_type:type_name -> auth_service.ClientType
+	45, // 4: auth_service.GetRoleByIdResponse.permissions:type_name -> auth_service.Permission
+	46, // 5: auth_service.GetRoleByIdResponse.parents:type_name -> auth_service.Role
+	45, // 6: auth_service.GetPermissionListResponse.permissions:type_name -> auth_service.Permission
+	22, // 7: auth_service.AddRolePermissionsRequest.permissions:type_name -> auth_service.AddRolePermissionRequest
+	46, // 8: auth_service.GetRolesResponse.roles:type_name -> auth_service.Role
+	47, // 9: auth_service.GetScopesResponse.scopes:type_name -> auth_service.Scope
+	48, // 10: auth_service.GetDenyRuleListResponse.deny_rules:type_name -> auth_service.DenyRule
+	48, // 11: auth_service.ExplainAccessResponse.deny_rule:type_name -> auth_service.DenyRule
+	35, // 12: auth_service.ExplainAccessResponse.permissions:type_name -> auth_service.AccessTracePermission
+	48, // 13: auth_service.ExplainAccessResponse.deny_rules:type_name -> auth_service.DenyRule
+	49, // 14: auth_service.ExplainAccessResponse.inactive_roles:type_name -> auth_service.UserRole
+	50, // 15: auth_service.ExplainAccessResponse.inactive_grants:type_name -> auth_service.UserPermission
+	51, // 16: auth_service.ExplainAccessResponse.user_state:type_name -> auth_service.UserStates
+	46, // 17: auth_service.GetPermissionMatrixResponse.roles:type_name -> auth_service.Role
+	45, // 18: auth_service.GetPermissionMatrixResponse.permissions:type_name -> auth_service.Permission
+	47, // 19: auth_service.GetPermissionMatrixResponse.scopes:type_name -> auth_service.Scope
+	38, // 20: auth_service.GetPermissionMatrixResponse.permission_cells:type_name -> auth_service.PermissionMatrixCell
+	38, // 21: auth_service.GetPermissionMatrixResponse.scope_cells:type_name -> auth_service.PermissionMatrixCell
+	42, // 22: auth_service.PermissionGenerated.Permission.scopes:type_name -> auth_service.PermissionGenerated.Permission.Scope
+	41, // 23: auth_service.PermissionGenerated.Permission.children:type_name -> auth_service.PermissionGenerated.Permission
+	14, // 24: auth_service.PermissionService.GetRoleById:input_type -> auth_service.RolePrimaryKey
+	26, // 25: auth_service.PermissionService.GetRolesList:input_type -> auth_service.GetRolesListRequest
+	12, // 26: auth_service.PermissionService.AddRole:input_type -> auth_service.AddRoleRequest
+	13, // 27: auth_service.PermissionService.UpdateRole:input_type -> auth_service.UpdateRoleRequest
+	14, // 28: auth_service.PermissionService.RemoveRole:input_type -> auth_service.RolePrimaryKey
+	9,  // 29: auth_service.PermissionService.AddRoleParent:input_type -> auth_service.RoleParent
+	9,  // 30: auth_service.PermissionService.RemoveRoleParent:input_type -> auth_service.RoleParent
+	15, // 31: auth_service.PermissionService.CreatePermission:input_type -> auth_service.CreatePermissionRequest
+	16, // 32: auth_service.PermissionService.GetPermissionByID:input_type -> auth_service.PermissionPrimaryKey
+	17, // 33: auth_service.PermissionService.GetPermissionList:input_type -> auth_service.GetPermissionListRequest
+	19, // 34: auth_service.PermissionService.UpdatePermission:input_type -> auth_service.UpdatePermissionRequest
+	16, // 35: auth_service.PermissionService.DeletePermission:input_type -> auth_service.PermissionPrimaryKey
+	10, // 36: auth_service.PermissionService.UpsertScope:input_type -> auth_service.UpsertScopeRequest
+	28, // 37: auth_service.PermissionService.GetScopeList:input_type -> auth_service.GetScopeListRequest
+	20, // 38: auth_service.PermissionService.AddPermissionScope:input_type -> auth_service.AddPermissionScopeRequest
+	21, // 39: auth_service.PermissionService.RemovePermissionScope:input_type -> auth_service.PermissionScopePrimaryKey
+	22, // 40: auth_service.PermissionService.AddRolePermission:input_type -> auth_service.AddRolePermissionRequest
+	23, // 41: auth_service.PermissionService.AddRolePermissions:input_type -> auth_service.AddRolePermissionsRequest
+	25, // 42: auth_service.PermissionService.RemoveRolePermission:input_type -> auth_service.RolePermissionPrimaryKey
+	0,  // 43: auth_service.PermissionService.PermissionList:input_type -> auth_service.PermissionGenerated
+	2,  // 44: auth_service.PermissionService.ExportRBACConfig:input_type -> auth_service.ExportRBACConfigRequest
+	4,  // 45: auth_service.PermissionService.ApplyRBACConfig:input_type -> auth_service.ApplyRBACConfigRequest
+	30, // 46: auth_service.PermissionService.CreateDenyRule:input_type -> auth_service.CreateDenyRuleRequest
+	31, // 47: auth_service.PermissionService.DeleteDenyRule:input_type -> auth_service.DenyRulePrimaryKey
+	32, // 48: auth_service.PermissionService.GetDenyRuleList:input_type -> auth_service.GetDenyRuleListRequest
+	34, // 49: auth_service.PermissionService.ExplainAccess:input_type -> auth_service.ExplainAccessRequest
+	37, // 50: auth_service.PermissionService.GetPermissionMatrix:input_type -> auth_service.GetPermissionMatrixRequest
+	37, // 51: auth_service.PermissionService.ExportPermissionMatrix:input_type -> auth_service.GetPermissionMatrixRequest
+	8,  // 52: auth_service.PermissionService.GetRoleById:output_type -> auth_service.GetRoleByIdResponse
+	27, // 53: auth_service.PermissionService.GetRolesList:output_type -> auth_service.GetRolesResponse
+	46, // 54: auth_service.PermissionService.AddRole:output_type -> auth_service.Role
+	46, // 55: auth_service.PermissionService.UpdateRole:output_type -> auth_service.Role
+	46, // 56: auth_service.PermissionService.RemoveRole:output_type -> auth_service.Role
+	8,  // 57: auth_service.PermissionService.AddRoleParent:output_type -> auth_service.GetRoleByIdResponse
+	8,  // 58: auth_service.PermissionService.RemoveRoleParent:output_type -> auth_service.GetRoleByIdResponse
+	7,  // 59: auth_service.PermissionService.CreatePermission:output_type -> auth_service.GetPermissionByIDResponse
+	7,  // 60: auth_service.PermissionService.GetPermissionByID:output_type -> auth_service.GetPermissionByIDResponse
+	18, // 61: auth_service.PermissionService.GetPermissionList:output_type -> auth_service.GetPermissionListResponse
+	7,  // 62: auth_service.PermissionService.UpdatePermission:output_type -> auth_service.GetPermissionByIDResponse
+	52, // 63: auth_service.PermissionService.DeletePermission:output_type -> google.protobuf.Empty
+	47, // 64: auth_service.PermissionService.UpsertScope:output_type -> auth_service.Scope
+	29, // 65: auth_service.PermissionService.GetScopeList:output_type -> auth_service.GetScopesResponse
+	43, // 66: auth_service.PermissionService.AddPermissionScope:output_type -> auth_service.PermissionScope
+	43, // 67: auth_service.PermissionService.RemovePermissionScope:output_type -> auth_service.PermissionScope
+	53, // 68: auth_service.PermissionService.AddRolePermission:output_type -> auth_service.RolePermission
+	24, // 69: auth_service.PermissionService.AddRolePermissions:output_type -> auth_service.AddRolePermissionsResponse
+	53, // 70: auth_service.PermissionService.RemoveRolePermission:output_type -> auth_service.RolePermission
+	1,  // 71: auth_service.PermissionService.PermissionList:output_type -> auth_service.PermissionGeneratedResponse
+	3,  // 72: auth_service.PermissionService.ExportRBACConfig:output_type -> auth_service.ExportRBACConfigResponse
+	6,  // 73: auth_service.PermissionService.ApplyRBACConfig:output_type -> auth_service.ApplyRBACConfigResponse
+	48, // 74: auth_service.PermissionService.CreateDenyRule:output_type -> auth_service.DenyRule
+	48, // 75: auth_service.PermissionService.DeleteDenyRule:output_type -> auth_service.DenyRule
+	33, // 76: auth_service.PermissionService.GetDenyRuleList:output_type -> auth_service.GetDenyRuleListResponse
+	36, // 77: auth_service.PermissionService.ExplainAccess:output_type -> auth_service.ExplainAccessResponse
+	39, // 78: auth_service.PermissionService.GetPermissionMatrix:output_type -> auth_service.GetPermissionMatrixResponse
+	40, // 79: auth_service.PermissionService.ExportPermissionMatrix:output_type -> auth_service.ExportPermissionMatrixResponse
+	52, // [52:80] is the sub-list for method output_type
+	24, // [24:52] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_permission_service_proto_init() }
@@ -1996,7 +3823,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPermissionByIDResponse); i {
+			switch v := v.(*PermissionGeneratedResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2008,7 +3835,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRoleByIdResponse); i {
+			switch v := v.(*ExportRBACConfigRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2020,7 +3847,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpsertScopeRequest); i {
+			switch v := v.(*ExportRBACConfigResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2032,7 +3859,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScopePrimaryKey); i {
+			switch v := v.(*ApplyRBACConfigRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2044,7 +3871,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRoleRequest); i {
+			switch v := v.(*RBACChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2056,7 +3883,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRoleRequest); i {
+			switch v := v.(*ApplyRBACConfigResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2068,7 +3895,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RolePrimaryKey); i {
+			switch v := v.(*GetPermissionByIDResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2080,7 +3907,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreatePermissionRequest); i {
+			switch v := v.(*GetRoleByIdResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2092,7 +3919,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PermissionPrimaryKey); i {
+			switch v := v.(*RoleParent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2104,7 +3931,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPermissionListRequest); i {
+			switch v := v.(*UpsertScopeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2116,7 +3943,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetPermissionListResponse); i {
+			switch v := v.(*ScopePrimaryKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2128,7 +3955,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdatePermissionRequest); i {
+			switch v := v.(*AddRoleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2140,7 +3967,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddPermissionScopeRequest); i {
+			switch v := v.(*UpdateRoleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2152,7 +3979,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PermissionScopePrimaryKey); i {
+			switch v := v.(*RolePrimaryKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2164,7 +3991,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRolePermissionRequest); i {
+			switch v := v.(*CreatePermissionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2176,7 +4003,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRolePermissionsRequest); i {
+			switch v := v.(*PermissionPrimaryKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2188,7 +4015,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRolePermissionsResponse); i {
+			switch v := v.(*GetPermissionListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2200,7 +4027,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RolePermissionPrimaryKey); i {
+			switch v := v.(*GetPermissionListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2212,7 +4039,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRolesListRequest); i {
+			switch v := v.(*UpdatePermissionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2224,7 +4051,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRolesResponse); i {
+			switch v := v.(*AddPermissionScopeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2236,7 +4063,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetScopeListRequest); i {
+			switch v := v.(*PermissionScopePrimaryKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2248,7 +4075,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetScopesResponse); i {
+			switch v := v.(*AddRolePermissionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2260,7 +4087,7 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PermissionGenerated_Permission); i {
+			switch v := v.(*AddRolePermissionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2272,6 +4099,222 @@ func file_permission_service_proto_init() {
 			}
 		}
 		file_permission_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRolePermissionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RolePermissionPrimaryKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRolesListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRolesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScopeListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetScopesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDenyRuleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DenyRulePrimaryKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDenyRuleListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDenyRuleListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainAccessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccessTracePermission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainAccessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPermissionMatrixRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PermissionMatrixCell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPermissionMatrixResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportPermissionMatrixResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PermissionGenerated_Permission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_permission_service_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PermissionGenerated_Permission_Scope); i {
 			case 0:
 				return &v.state
@@ -2290,7 +4333,7 @@ func file_permission_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_permission_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
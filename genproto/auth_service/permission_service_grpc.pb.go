@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.19.4
+// - protoc             (unknown)
 // source: permission_service.proto
 
 package auth_service
@@ -28,6 +28,8 @@ type PermissionServiceClient interface {
 	AddRole(ctx context.Context, in *AddRoleRequest, opts ...grpc.CallOption) (*Role, error)
 	UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*Role, error)
 	RemoveRole(ctx context.Context, in *RolePrimaryKey, opts ...grpc.CallOption) (*Role, error)
+	AddRoleParent(ctx context.Context, in *RoleParent, opts ...grpc.CallOption) (*GetRoleByIdResponse, error)
+	RemoveRoleParent(ctx context.Context, in *RoleParent, opts ...grpc.CallOption) (*GetRoleByIdResponse, error)
 	CreatePermission(ctx context.Context, in *CreatePermissionRequest, opts ...grpc.CallOption) (*GetPermissionByIDResponse, error)
 	GetPermissionByID(ctx context.Context, in *PermissionPrimaryKey, opts ...grpc.CallOption) (*GetPermissionByIDResponse, error)
 	GetPermissionList(ctx context.Context, in *GetPermissionListRequest, opts ...grpc.CallOption) (*GetPermissionListResponse, error)
@@ -40,7 +42,15 @@ type PermissionServiceClient interface {
 	AddRolePermission(ctx context.Context, in *AddRolePermissionRequest, opts ...grpc.CallOption) (*RolePermission, error)
 	AddRolePermissions(ctx context.Context, in *AddRolePermissionsRequest, opts ...grpc.CallOption) (*AddRolePermissionsResponse, error)
 	RemoveRolePermission(ctx context.Context, in *RolePermissionPrimaryKey, opts ...grpc.CallOption) (*RolePermission, error)
-	PermissionList(ctx context.Context, in *PermissionGenerated, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PermissionList(ctx context.Context, in *PermissionGenerated, opts ...grpc.CallOption) (*PermissionGeneratedResponse, error)
+	ExportRBACConfig(ctx context.Context, in *ExportRBACConfigRequest, opts ...grpc.CallOption) (*ExportRBACConfigResponse, error)
+	ApplyRBACConfig(ctx context.Context, in *ApplyRBACConfigRequest, opts ...grpc.CallOption) (*ApplyRBACConfigResponse, error)
+	CreateDenyRule(ctx context.Context, in *CreateDenyRuleRequest, opts ...grpc.CallOption) (*DenyRule, error)
+	DeleteDenyRule(ctx context.Context, in *DenyRulePrimaryKey, opts ...grpc.CallOption) (*DenyRule, error)
+	GetDenyRuleList(ctx context.Context, in *GetDenyRuleListRequest, opts ...grpc.CallOption) (*GetDenyRuleListResponse, error)
+	ExplainAccess(ctx context.Context, in *ExplainAccessRequest, opts ...grpc.CallOption) (*ExplainAccessResponse, error)
+	GetPermissionMatrix(ctx context.Context, in *GetPermissionMatrixRequest, opts ...grpc.CallOption) (*GetPermissionMatrixResponse, error)
+	ExportPermissionMatrix(ctx context.Context, in *GetPermissionMatrixRequest, opts ...grpc.CallOption) (*ExportPermissionMatrixResponse, error)
 }
 
 type permissionServiceClient struct {
@@ -96,6 +106,24 @@ func (c *permissionServiceClient) RemoveRole(ctx context.Context, in *RolePrimar
 	return out, nil
 }
 
+func (c *permissionServiceClient) AddRoleParent(ctx context.Context, in *RoleParent, opts ...grpc.CallOption) (*GetRoleByIdResponse, error) {
+	out := new(GetRoleByIdResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/AddRoleParent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) RemoveRoleParent(ctx context.Context, in *RoleParent, opts ...grpc.CallOption) (*GetRoleByIdResponse, error) {
+	out := new(GetRoleByIdResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/RemoveRoleParent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *permissionServiceClient) CreatePermission(ctx context.Context, in *CreatePermissionRequest, opts ...grpc.CallOption) (*GetPermissionByIDResponse, error) {
 	out := new(GetPermissionByIDResponse)
 	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/CreatePermission", in, out, opts...)
@@ -204,8 +232,8 @@ func (c *permissionServiceClient) RemoveRolePermission(ctx context.Context, in *
 	return out, nil
 }
 
-func (c *permissionServiceClient) PermissionList(ctx context.Context, in *PermissionGenerated, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *permissionServiceClient) PermissionList(ctx context.Context, in *PermissionGenerated, opts ...grpc.CallOption) (*PermissionGeneratedResponse, error) {
+	out := new(PermissionGeneratedResponse)
 	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/PermissionList", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -213,6 +241,78 @@ func (c *permissionServiceClient) PermissionList(ctx context.Context, in *Permis
 	return out, nil
 }
 
+func (c *permissionServiceClient) ExportRBACConfig(ctx context.Context, in *ExportRBACConfigRequest, opts ...grpc.CallOption) (*ExportRBACConfigResponse, error) {
+	out := new(ExportRBACConfigResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/ExportRBACConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) ApplyRBACConfig(ctx context.Context, in *ApplyRBACConfigRequest, opts ...grpc.CallOption) (*ApplyRBACConfigResponse, error) {
+	out := new(ApplyRBACConfigResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/ApplyRBACConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) CreateDenyRule(ctx context.Context, in *CreateDenyRuleRequest, opts ...grpc.CallOption) (*DenyRule, error) {
+	out := new(DenyRule)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/CreateDenyRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) DeleteDenyRule(ctx context.Context, in *DenyRulePrimaryKey, opts ...grpc.CallOption) (*DenyRule, error) {
+	out := new(DenyRule)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/DeleteDenyRule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) GetDenyRuleList(ctx context.Context, in *GetDenyRuleListRequest, opts ...grpc.CallOption) (*GetDenyRuleListResponse, error) {
+	out := new(GetDenyRuleListResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/GetDenyRuleList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) ExplainAccess(ctx context.Context, in *ExplainAccessRequest, opts ...grpc.CallOption) (*ExplainAccessResponse, error) {
+	out := new(ExplainAccessResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/ExplainAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) GetPermissionMatrix(ctx context.Context, in *GetPermissionMatrixRequest, opts ...grpc.CallOption) (*GetPermissionMatrixResponse, error) {
+	out := new(GetPermissionMatrixResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/GetPermissionMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionServiceClient) ExportPermissionMatrix(ctx context.Context, in *GetPermissionMatrixRequest, opts ...grpc.CallOption) (*ExportPermissionMatrixResponse, error) {
+	out := new(ExportPermissionMatrixResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.PermissionService/ExportPermissionMatrix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PermissionServiceServer is the server API for PermissionService service.
 // All implementations must embed UnimplementedPermissionServiceServer
 // for forward compatibility
@@ -222,6 +322,8 @@ type PermissionServiceServer interface {
 	AddRole(context.Context, *AddRoleRequest) (*Role, error)
 	UpdateRole(context.Context, *UpdateRoleRequest) (*Role, error)
 	RemoveRole(context.Context, *RolePrimaryKey) (*Role, error)
+	AddRoleParent(context.Context, *RoleParent) (*GetRoleByIdResponse, error)
+	RemoveRoleParent(context.Context, *RoleParent) (*GetRoleByIdResponse, error)
 	CreatePermission(context.Context, *CreatePermissionRequest) (*GetPermissionByIDResponse, error)
 	GetPermissionByID(context.Context, *PermissionPrimaryKey) (*GetPermissionByIDResponse, error)
 	GetPermissionList(context.Context, *GetPermissionListRequest) (*GetPermissionListResponse, error)
@@ -234,7 +336,15 @@ type PermissionServiceServer interface {
 	AddRolePermission(context.Context, *AddRolePermissionRequest) (*RolePermission, error)
 	AddRolePermissions(context.Context, *AddRolePermissionsRequest) (*AddRolePermissionsResponse, error)
 	RemoveRolePermission(context.Context, *RolePermissionPrimaryKey) (*RolePermission, error)
-	PermissionList(context.Context, *PermissionGenerated) (*emptypb.Empty, error)
+	PermissionList(context.Context, *PermissionGenerated) (*PermissionGeneratedResponse, error)
+	ExportRBACConfig(context.Context, *ExportRBACConfigRequest) (*ExportRBACConfigResponse, error)
+	ApplyRBACConfig(context.Context, *ApplyRBACConfigRequest) (*ApplyRBACConfigResponse, error)
+	CreateDenyRule(context.Context, *CreateDenyRuleRequest) (*DenyRule, error)
+	DeleteDenyRule(context.Context, *DenyRulePrimaryKey) (*DenyRule, error)
+	GetDenyRuleList(context.Context, *GetDenyRuleListRequest) (*GetDenyRuleListResponse, error)
+	ExplainAccess(context.Context, *ExplainAccessRequest) (*ExplainAccessResponse, error)
+	GetPermissionMatrix(context.Context, *GetPermissionMatrixRequest) (*GetPermissionMatrixResponse, error)
+	ExportPermissionMatrix(context.Context, *GetPermissionMatrixRequest) (*ExportPermissionMatrixResponse, error)
 	mustEmbedUnimplementedPermissionServiceServer()
 }
 
@@ -257,6 +367,12 @@ func (UnimplementedPermissionServiceServer) UpdateRole(context.Context, *UpdateR
 func (UnimplementedPermissionServiceServer) RemoveRole(context.Context, *RolePrimaryKey) (*Role, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRole not implemented")
 }
+func (UnimplementedPermissionServiceServer) AddRoleParent(context.Context, *RoleParent) (*GetRoleByIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleParent not implemented")
+}
+func (UnimplementedPermissionServiceServer) RemoveRoleParent(context.Context, *RoleParent) (*GetRoleByIdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleParent not implemented")
+}
 func (UnimplementedPermissionServiceServer) CreatePermission(context.Context, *CreatePermissionRequest) (*GetPermissionByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreatePermission not implemented")
 }
@@ -293,9 +409,33 @@ func (UnimplementedPermissionServiceServer) AddRolePermissions(context.Context,
 func (UnimplementedPermissionServiceServer) RemoveRolePermission(context.Context, *RolePermissionPrimaryKey) (*RolePermission, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRolePermission not implemented")
 }
-func (UnimplementedPermissionServiceServer) PermissionList(context.Context, *PermissionGenerated) (*emptypb.Empty, error) {
+func (UnimplementedPermissionServiceServer) PermissionList(context.Context, *PermissionGenerated) (*PermissionGeneratedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PermissionList not implemented")
 }
+func (UnimplementedPermissionServiceServer) ExportRBACConfig(context.Context, *ExportRBACConfigRequest) (*ExportRBACConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRBACConfig not implemented")
+}
+func (UnimplementedPermissionServiceServer) ApplyRBACConfig(context.Context, *ApplyRBACConfigRequest) (*ApplyRBACConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyRBACConfig not implemented")
+}
+func (UnimplementedPermissionServiceServer) CreateDenyRule(context.Context, *CreateDenyRuleRequest) (*DenyRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDenyRule not implemented")
+}
+func (UnimplementedPermissionServiceServer) DeleteDenyRule(context.Context, *DenyRulePrimaryKey) (*DenyRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDenyRule not implemented")
+}
+func (UnimplementedPermissionServiceServer) GetDenyRuleList(context.Context, *GetDenyRuleListRequest) (*GetDenyRuleListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDenyRuleList not implemented")
+}
+func (UnimplementedPermissionServiceServer) ExplainAccess(context.Context, *ExplainAccessRequest) (*ExplainAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainAccess not implemented")
+}
+func (UnimplementedPermissionServiceServer) GetPermissionMatrix(context.Context, *GetPermissionMatrixRequest) (*GetPermissionMatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPermissionMatrix not implemented")
+}
+func (UnimplementedPermissionServiceServer) ExportPermissionMatrix(context.Context, *GetPermissionMatrixRequest) (*ExportPermissionMatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportPermissionMatrix not implemented")
+}
 func (UnimplementedPermissionServiceServer) mustEmbedUnimplementedPermissionServiceServer() {}
 
 // UnsafePermissionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -399,6 +539,42 @@ func _PermissionService_RemoveRole_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PermissionService_AddRoleParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleParent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).AddRoleParent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/AddRoleParent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).AddRoleParent(ctx, req.(*RoleParent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_RemoveRoleParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleParent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).RemoveRoleParent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/RemoveRoleParent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).RemoveRoleParent(ctx, req.(*RoleParent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PermissionService_CreatePermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreatePermissionRequest)
 	if err := dec(in); err != nil {
@@ -633,6 +809,150 @@ func _PermissionService_PermissionList_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PermissionService_ExportRBACConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRBACConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).ExportRBACConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/ExportRBACConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).ExportRBACConfig(ctx, req.(*ExportRBACConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_ApplyRBACConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRBACConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).ApplyRBACConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/ApplyRBACConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).ApplyRBACConfig(ctx, req.(*ApplyRBACConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_CreateDenyRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDenyRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).CreateDenyRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/CreateDenyRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).CreateDenyRule(ctx, req.(*CreateDenyRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_DeleteDenyRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyRulePrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).DeleteDenyRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/DeleteDenyRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).DeleteDenyRule(ctx, req.(*DenyRulePrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_GetDenyRuleList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDenyRuleListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).GetDenyRuleList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/GetDenyRuleList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).GetDenyRuleList(ctx, req.(*GetDenyRuleListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_ExplainAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).ExplainAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/ExplainAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).ExplainAccess(ctx, req.(*ExplainAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_GetPermissionMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPermissionMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).GetPermissionMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/GetPermissionMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).GetPermissionMatrix(ctx, req.(*GetPermissionMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionService_ExportPermissionMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPermissionMatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionServiceServer).ExportPermissionMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.PermissionService/ExportPermissionMatrix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionServiceServer).ExportPermissionMatrix(ctx, req.(*GetPermissionMatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PermissionService_ServiceDesc is the grpc.ServiceDesc for PermissionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -660,6 +980,14 @@ var PermissionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRole",
 			Handler:    _PermissionService_RemoveRole_Handler,
 		},
+		{
+			MethodName: "AddRoleParent",
+			Handler:    _PermissionService_AddRoleParent_Handler,
+		},
+		{
+			MethodName: "RemoveRoleParent",
+			Handler:    _PermissionService_RemoveRoleParent_Handler,
+		},
 		{
 			MethodName: "CreatePermission",
 			Handler:    _PermissionService_CreatePermission_Handler,
@@ -712,6 +1040,38 @@ var PermissionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PermissionList",
 			Handler:    _PermissionService_PermissionList_Handler,
 		},
+		{
+			MethodName: "ExportRBACConfig",
+			Handler:    _PermissionService_ExportRBACConfig_Handler,
+		},
+		{
+			MethodName: "ApplyRBACConfig",
+			Handler:    _PermissionService_ApplyRBACConfig_Handler,
+		},
+		{
+			MethodName: "CreateDenyRule",
+			Handler:    _PermissionService_CreateDenyRule_Handler,
+		},
+		{
+			MethodName: "DeleteDenyRule",
+			Handler:    _PermissionService_DeleteDenyRule_Handler,
+		},
+		{
+			MethodName: "GetDenyRuleList",
+			Handler:    _PermissionService_GetDenyRuleList_Handler,
+		},
+		{
+			MethodName: "ExplainAccess",
+			Handler:    _PermissionService_ExplainAccess_Handler,
+		},
+		{
+			MethodName: "GetPermissionMatrix",
+			Handler:    _PermissionService_GetPermissionMatrix_Handler,
+		},
+		{
+			MethodName: "ExportPermissionMatrix",
+			Handler:    _PermissionService_ExportPermissionMatrix_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "permission_service.proto",
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.19.4
+// - protoc             (unknown)
 // source: session_service.proto
 
 package auth_service
@@ -27,6 +27,20 @@ type SessionServiceClient interface {
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
 	HasAccess(ctx context.Context, in *HasAccessRequest, opts ...grpc.CallOption) (*HasAccessResponse, error)
+	SendReauthPasscode(ctx context.Context, in *SendReauthPasscodeRequest, opts ...grpc.CallOption) (*SendReauthPasscodeResponse, error)
+	Reauthenticate(ctx context.Context, in *ReauthenticateRequest, opts ...grpc.CallOption) (*Session, error)
+	GetRevokedSessionList(ctx context.Context, in *GetRevokedSessionListRequest, opts ...grpc.CallOption) (*GetRevokedSessionListResponse, error)
+	WatchRevokedSessions(ctx context.Context, in *WatchRevokedSessionsRequest, opts ...grpc.CallOption) (SessionService_WatchRevokedSessionsClient, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SendContactChangePasscode(ctx context.Context, in *SendContactChangePasscodeRequest, opts ...grpc.CallOption) (*SendContactChangePasscodeResponse, error)
+	ChangeContact(ctx context.Context, in *ChangeContactRequest, opts ...grpc.CallOption) (*User, error)
+	ChangeLogin(ctx context.Context, in *ChangeLoginRequest, opts ...grpc.CallOption) (*User, error)
+	BeginPasskeyRegistration(ctx context.Context, in *BeginPasskeyRegistrationRequest, opts ...grpc.CallOption) (*BeginPasskeyRegistrationResponse, error)
+	FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*PasskeyCredential, error)
+	BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginRequest, opts ...grpc.CallOption) (*BeginPasskeyLoginResponse, error)
+	FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	GetPasskeyList(ctx context.Context, in *GetPasskeyListRequest, opts ...grpc.CallOption) (*GetPasskeyListResponse, error)
+	RemovePasskey(ctx context.Context, in *RemovePasskeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type sessionServiceClient struct {
@@ -73,6 +87,155 @@ func (c *sessionServiceClient) HasAccess(ctx context.Context, in *HasAccessReque
 	return out, nil
 }
 
+func (c *sessionServiceClient) SendReauthPasscode(ctx context.Context, in *SendReauthPasscodeRequest, opts ...grpc.CallOption) (*SendReauthPasscodeResponse, error) {
+	out := new(SendReauthPasscodeResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/SendReauthPasscode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) Reauthenticate(ctx context.Context, in *ReauthenticateRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/Reauthenticate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) GetRevokedSessionList(ctx context.Context, in *GetRevokedSessionListRequest, opts ...grpc.CallOption) (*GetRevokedSessionListResponse, error) {
+	out := new(GetRevokedSessionListResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/GetRevokedSessionList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) WatchRevokedSessions(ctx context.Context, in *WatchRevokedSessionsRequest, opts ...grpc.CallOption) (SessionService_WatchRevokedSessionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SessionService_ServiceDesc.Streams[0], "/auth_service.SessionService/WatchRevokedSessions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sessionServiceWatchRevokedSessionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SessionService_WatchRevokedSessionsClient interface {
+	Recv() (*RevokedSession, error)
+	grpc.ClientStream
+}
+
+type sessionServiceWatchRevokedSessionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sessionServiceWatchRevokedSessionsClient) Recv() (*RevokedSession, error) {
+	m := new(RevokedSession)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sessionServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) SendContactChangePasscode(ctx context.Context, in *SendContactChangePasscodeRequest, opts ...grpc.CallOption) (*SendContactChangePasscodeResponse, error) {
+	out := new(SendContactChangePasscodeResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/SendContactChangePasscode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) ChangeContact(ctx context.Context, in *ChangeContactRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/ChangeContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) ChangeLogin(ctx context.Context, in *ChangeLoginRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/ChangeLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) BeginPasskeyRegistration(ctx context.Context, in *BeginPasskeyRegistrationRequest, opts ...grpc.CallOption) (*BeginPasskeyRegistrationResponse, error) {
+	out := new(BeginPasskeyRegistrationResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/BeginPasskeyRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) FinishPasskeyRegistration(ctx context.Context, in *FinishPasskeyRegistrationRequest, opts ...grpc.CallOption) (*PasskeyCredential, error) {
+	out := new(PasskeyCredential)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/FinishPasskeyRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) BeginPasskeyLogin(ctx context.Context, in *BeginPasskeyLoginRequest, opts ...grpc.CallOption) (*BeginPasskeyLoginResponse, error) {
+	out := new(BeginPasskeyLoginResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/BeginPasskeyLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) FinishPasskeyLogin(ctx context.Context, in *FinishPasskeyLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/FinishPasskeyLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) GetPasskeyList(ctx context.Context, in *GetPasskeyListRequest, opts ...grpc.CallOption) (*GetPasskeyListResponse, error) {
+	out := new(GetPasskeyListResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/GetPasskeyList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionServiceClient) RemovePasskey(ctx context.Context, in *RemovePasskeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/auth_service.SessionService/RemovePasskey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -81,6 +244,20 @@ type SessionServiceServer interface {
 	Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error)
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
 	HasAccess(context.Context, *HasAccessRequest) (*HasAccessResponse, error)
+	SendReauthPasscode(context.Context, *SendReauthPasscodeRequest) (*SendReauthPasscodeResponse, error)
+	Reauthenticate(context.Context, *ReauthenticateRequest) (*Session, error)
+	GetRevokedSessionList(context.Context, *GetRevokedSessionListRequest) (*GetRevokedSessionListResponse, error)
+	WatchRevokedSessions(*WatchRevokedSessionsRequest, SessionService_WatchRevokedSessionsServer) error
+	ChangePassword(context.Context, *ChangePasswordRequest) (*emptypb.Empty, error)
+	SendContactChangePasscode(context.Context, *SendContactChangePasscodeRequest) (*SendContactChangePasscodeResponse, error)
+	ChangeContact(context.Context, *ChangeContactRequest) (*User, error)
+	ChangeLogin(context.Context, *ChangeLoginRequest) (*User, error)
+	BeginPasskeyRegistration(context.Context, *BeginPasskeyRegistrationRequest) (*BeginPasskeyRegistrationResponse, error)
+	FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*PasskeyCredential, error)
+	BeginPasskeyLogin(context.Context, *BeginPasskeyLoginRequest) (*BeginPasskeyLoginResponse, error)
+	FinishPasskeyLogin(context.Context, *FinishPasskeyLoginRequest) (*LoginResponse, error)
+	GetPasskeyList(context.Context, *GetPasskeyListRequest) (*GetPasskeyListResponse, error)
+	RemovePasskey(context.Context, *RemovePasskeyRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -100,6 +277,48 @@ func (UnimplementedSessionServiceServer) RefreshToken(context.Context, *RefreshT
 func (UnimplementedSessionServiceServer) HasAccess(context.Context, *HasAccessRequest) (*HasAccessResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HasAccess not implemented")
 }
+func (UnimplementedSessionServiceServer) SendReauthPasscode(context.Context, *SendReauthPasscodeRequest) (*SendReauthPasscodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendReauthPasscode not implemented")
+}
+func (UnimplementedSessionServiceServer) Reauthenticate(context.Context, *ReauthenticateRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reauthenticate not implemented")
+}
+func (UnimplementedSessionServiceServer) GetRevokedSessionList(context.Context, *GetRevokedSessionListRequest) (*GetRevokedSessionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevokedSessionList not implemented")
+}
+func (UnimplementedSessionServiceServer) WatchRevokedSessions(*WatchRevokedSessionsRequest, SessionService_WatchRevokedSessionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRevokedSessions not implemented")
+}
+func (UnimplementedSessionServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedSessionServiceServer) SendContactChangePasscode(context.Context, *SendContactChangePasscodeRequest) (*SendContactChangePasscodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendContactChangePasscode not implemented")
+}
+func (UnimplementedSessionServiceServer) ChangeContact(context.Context, *ChangeContactRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeContact not implemented")
+}
+func (UnimplementedSessionServiceServer) ChangeLogin(context.Context, *ChangeLoginRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeLogin not implemented")
+}
+func (UnimplementedSessionServiceServer) BeginPasskeyRegistration(context.Context, *BeginPasskeyRegistrationRequest) (*BeginPasskeyRegistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyRegistration not implemented")
+}
+func (UnimplementedSessionServiceServer) FinishPasskeyRegistration(context.Context, *FinishPasskeyRegistrationRequest) (*PasskeyCredential, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyRegistration not implemented")
+}
+func (UnimplementedSessionServiceServer) BeginPasskeyLogin(context.Context, *BeginPasskeyLoginRequest) (*BeginPasskeyLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginPasskeyLogin not implemented")
+}
+func (UnimplementedSessionServiceServer) FinishPasskeyLogin(context.Context, *FinishPasskeyLoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishPasskeyLogin not implemented")
+}
+func (UnimplementedSessionServiceServer) GetPasskeyList(context.Context, *GetPasskeyListRequest) (*GetPasskeyListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasskeyList not implemented")
+}
+func (UnimplementedSessionServiceServer) RemovePasskey(context.Context, *RemovePasskeyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePasskey not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -185,6 +404,261 @@ func _SessionService_HasAccess_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_SendReauthPasscode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendReauthPasscodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).SendReauthPasscode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/SendReauthPasscode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).SendReauthPasscode(ctx, req.(*SendReauthPasscodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_Reauthenticate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReauthenticateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).Reauthenticate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/Reauthenticate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).Reauthenticate(ctx, req.(*ReauthenticateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_GetRevokedSessionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevokedSessionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).GetRevokedSessionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/GetRevokedSessionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).GetRevokedSessionList(ctx, req.(*GetRevokedSessionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_WatchRevokedSessions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRevokedSessionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SessionServiceServer).WatchRevokedSessions(m, &sessionServiceWatchRevokedSessionsServer{stream})
+}
+
+type SessionService_WatchRevokedSessionsServer interface {
+	Send(*RevokedSession) error
+	grpc.ServerStream
+}
+
+type sessionServiceWatchRevokedSessionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sessionServiceWatchRevokedSessionsServer) Send(m *RevokedSession) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SessionService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_SendContactChangePasscode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendContactChangePasscodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).SendContactChangePasscode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/SendContactChangePasscode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).SendContactChangePasscode(ctx, req.(*SendContactChangePasscodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_ChangeContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ChangeContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/ChangeContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ChangeContact(ctx, req.(*ChangeContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_ChangeLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ChangeLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/ChangeLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ChangeLogin(ctx, req.(*ChangeLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_BeginPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).BeginPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/BeginPasskeyRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).BeginPasskeyRegistration(ctx, req.(*BeginPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_FinishPasskeyRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).FinishPasskeyRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/FinishPasskeyRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).FinishPasskeyRegistration(ctx, req.(*FinishPasskeyRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_BeginPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginPasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).BeginPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/BeginPasskeyLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).BeginPasskeyLogin(ctx, req.(*BeginPasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_FinishPasskeyLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishPasskeyLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).FinishPasskeyLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/FinishPasskeyLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).FinishPasskeyLogin(ctx, req.(*FinishPasskeyLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_GetPasskeyList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPasskeyListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).GetPasskeyList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/GetPasskeyList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).GetPasskeyList(ctx, req.(*GetPasskeyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SessionService_RemovePasskey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePasskeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).RemovePasskey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.SessionService/RemovePasskey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).RemovePasskey(ctx, req.(*RemovePasskeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,7 +682,65 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HasAccess",
 			Handler:    _SessionService_HasAccess_Handler,
 		},
+		{
+			MethodName: "SendReauthPasscode",
+			Handler:    _SessionService_SendReauthPasscode_Handler,
+		},
+		{
+			MethodName: "Reauthenticate",
+			Handler:    _SessionService_Reauthenticate_Handler,
+		},
+		{
+			MethodName: "GetRevokedSessionList",
+			Handler:    _SessionService_GetRevokedSessionList_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _SessionService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "SendContactChangePasscode",
+			Handler:    _SessionService_SendContactChangePasscode_Handler,
+		},
+		{
+			MethodName: "ChangeContact",
+			Handler:    _SessionService_ChangeContact_Handler,
+		},
+		{
+			MethodName: "ChangeLogin",
+			Handler:    _SessionService_ChangeLogin_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyRegistration",
+			Handler:    _SessionService_BeginPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyRegistration",
+			Handler:    _SessionService_FinishPasskeyRegistration_Handler,
+		},
+		{
+			MethodName: "BeginPasskeyLogin",
+			Handler:    _SessionService_BeginPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "FinishPasskeyLogin",
+			Handler:    _SessionService_FinishPasskeyLogin_Handler,
+		},
+		{
+			MethodName: "GetPasskeyList",
+			Handler:    _SessionService_GetPasskeyList_Handler,
+		},
+		{
+			MethodName: "RemovePasskey",
+			Handler:    _SessionService_RemovePasskey_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRevokedSessions",
+			Handler:       _SessionService_WatchRevokedSessions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "session_service.proto",
 }
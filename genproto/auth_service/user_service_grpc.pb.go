@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.19.4
+// - protoc             (unknown)
 // source: user_service.proto
 
 package auth_service
@@ -29,11 +29,31 @@ type UserServiceClient interface {
 	GetUserList(ctx context.Context, in *GetUserListRequest, opts ...grpc.CallOption) (*GetUserListResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
 	DeleteUser(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error)
 	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*User, error)
 	SendMessageToEmail(ctx context.Context, in *SendMessageToEmailRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ActivateUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error)
+	SuspendUser(ctx context.Context, in *SuspendUserRequest, opts ...grpc.CallOption) (*User, error)
+	LockUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error)
+	ReinstateUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error)
+	GetUserStateHistory(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserStateHistoryResponse, error)
 	AddUserRelation(ctx context.Context, in *AddUserRelationRequest, opts ...grpc.CallOption) (*UserRelation, error)
 	RemoveUserRelation(ctx context.Context, in *UserRelationPrimaryKey, opts ...grpc.CallOption) (*UserRelation, error)
+	GetUserEffectiveRelations(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserEffectiveRelationsResponse, error)
+	AddUserRole(ctx context.Context, in *AddUserRoleRequest, opts ...grpc.CallOption) (*UserRole, error)
+	RemoveUserRole(ctx context.Context, in *UserRolePrimaryKey, opts ...grpc.CallOption) (*UserRole, error)
+	GetUserRoleList(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserRoleListResponse, error)
+	GrantUserPermission(ctx context.Context, in *GrantUserPermissionRequest, opts ...grpc.CallOption) (*UserPermission, error)
+	RevokeUserPermission(ctx context.Context, in *UserPermissionPrimaryKey, opts ...grpc.CallOption) (*UserPermission, error)
+	GetUserPermissionList(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserPermissionListResponse, error)
 	UpsertUserInfo(ctx context.Context, in *UpsertUserInfoRequest, opts ...grpc.CallOption) (*UserInfo, error)
+	SendContactVerification(ctx context.Context, in *SendContactVerificationRequest, opts ...grpc.CallOption) (*SendContactVerificationResponse, error)
+	VerifyContact(ctx context.Context, in *VerifyContactRequest, opts ...grpc.CallOption) (*User, error)
+	ImportUsers(ctx context.Context, opts ...grpc.CallOption) (UserService_ImportUsersClient, error)
+	ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (UserService_ExportUsersClient, error)
+	ExportUserData(ctx context.Context, in *DataSubjectRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	EraseUserData(ctx context.Context, in *DataSubjectRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
+	VerifyDataSubjectReceipt(ctx context.Context, in *DataSubjectReceipt, opts ...grpc.CallOption) (*VerifyDataSubjectReceiptResponse, error)
 }
 
 type userServiceClient struct {
@@ -98,6 +118,15 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, in *UserPrimaryKey,
 	return out, nil
 }
 
+func (c *userServiceClient) RestoreUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/RestoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*User, error) {
 	out := new(User)
 	err := c.cc.Invoke(ctx, "/auth_service.UserService/ResetPassword", in, out, opts...)
@@ -116,6 +145,51 @@ func (c *userServiceClient) SendMessageToEmail(ctx context.Context, in *SendMess
 	return out, nil
 }
 
+func (c *userServiceClient) ActivateUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/ActivateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SuspendUser(ctx context.Context, in *SuspendUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/SuspendUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) LockUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/LockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReinstateUser(ctx context.Context, in *ChangeUserStateRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/ReinstateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserStateHistory(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserStateHistoryResponse, error) {
+	out := new(GetUserStateHistoryResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/GetUserStateHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) AddUserRelation(ctx context.Context, in *AddUserRelationRequest, opts ...grpc.CallOption) (*UserRelation, error) {
 	out := new(UserRelation)
 	err := c.cc.Invoke(ctx, "/auth_service.UserService/AddUserRelation", in, out, opts...)
@@ -134,6 +208,69 @@ func (c *userServiceClient) RemoveUserRelation(ctx context.Context, in *UserRela
 	return out, nil
 }
 
+func (c *userServiceClient) GetUserEffectiveRelations(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserEffectiveRelationsResponse, error) {
+	out := new(GetUserEffectiveRelationsResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/GetUserEffectiveRelations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AddUserRole(ctx context.Context, in *AddUserRoleRequest, opts ...grpc.CallOption) (*UserRole, error) {
+	out := new(UserRole)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/AddUserRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemoveUserRole(ctx context.Context, in *UserRolePrimaryKey, opts ...grpc.CallOption) (*UserRole, error) {
+	out := new(UserRole)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/RemoveUserRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserRoleList(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserRoleListResponse, error) {
+	out := new(GetUserRoleListResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/GetUserRoleList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GrantUserPermission(ctx context.Context, in *GrantUserPermissionRequest, opts ...grpc.CallOption) (*UserPermission, error) {
+	out := new(UserPermission)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/GrantUserPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeUserPermission(ctx context.Context, in *UserPermissionPrimaryKey, opts ...grpc.CallOption) (*UserPermission, error) {
+	out := new(UserPermission)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/RevokeUserPermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserPermissionList(ctx context.Context, in *UserPrimaryKey, opts ...grpc.CallOption) (*GetUserPermissionListResponse, error) {
+	out := new(GetUserPermissionListResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/GetUserPermissionList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) UpsertUserInfo(ctx context.Context, in *UpsertUserInfoRequest, opts ...grpc.CallOption) (*UserInfo, error) {
 	out := new(UserInfo)
 	err := c.cc.Invoke(ctx, "/auth_service.UserService/UpsertUserInfo", in, out, opts...)
@@ -143,6 +280,117 @@ func (c *userServiceClient) UpsertUserInfo(ctx context.Context, in *UpsertUserIn
 	return out, nil
 }
 
+func (c *userServiceClient) SendContactVerification(ctx context.Context, in *SendContactVerificationRequest, opts ...grpc.CallOption) (*SendContactVerificationResponse, error) {
+	out := new(SendContactVerificationResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/SendContactVerification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyContact(ctx context.Context, in *VerifyContactRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/VerifyContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ImportUsers(ctx context.Context, opts ...grpc.CallOption) (UserService_ImportUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], "/auth_service.UserService/ImportUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceImportUsersClient{stream}
+	return x, nil
+}
+
+type UserService_ImportUsersClient interface {
+	Send(*ImportUsersRequest) error
+	CloseAndRecv() (*ImportUsersResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceImportUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceImportUsersClient) Send(m *ImportUsersRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *userServiceImportUsersClient) CloseAndRecv() (*ImportUsersResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportUsersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (UserService_ExportUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[1], "/auth_service.UserService/ExportUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceExportUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_ExportUsersClient interface {
+	Recv() (*ExportUsersResponse, error)
+	grpc.ClientStream
+}
+
+type userServiceExportUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceExportUsersClient) Recv() (*ExportUsersResponse, error) {
+	m := new(ExportUsersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userServiceClient) ExportUserData(ctx context.Context, in *DataSubjectRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/ExportUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) EraseUserData(ctx context.Context, in *DataSubjectRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/EraseUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyDataSubjectReceipt(ctx context.Context, in *DataSubjectReceipt, opts ...grpc.CallOption) (*VerifyDataSubjectReceiptResponse, error) {
+	out := new(VerifyDataSubjectReceiptResponse)
+	err := c.cc.Invoke(ctx, "/auth_service.UserService/VerifyDataSubjectReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -153,11 +401,31 @@ type UserServiceServer interface {
 	GetUserList(context.Context, *GetUserListRequest) (*GetUserListResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
 	DeleteUser(context.Context, *UserPrimaryKey) (*emptypb.Empty, error)
+	RestoreUser(context.Context, *ChangeUserStateRequest) (*User, error)
 	ResetPassword(context.Context, *ResetPasswordRequest) (*User, error)
 	SendMessageToEmail(context.Context, *SendMessageToEmailRequest) (*emptypb.Empty, error)
+	ActivateUser(context.Context, *ChangeUserStateRequest) (*User, error)
+	SuspendUser(context.Context, *SuspendUserRequest) (*User, error)
+	LockUser(context.Context, *ChangeUserStateRequest) (*User, error)
+	ReinstateUser(context.Context, *ChangeUserStateRequest) (*User, error)
+	GetUserStateHistory(context.Context, *UserPrimaryKey) (*GetUserStateHistoryResponse, error)
 	AddUserRelation(context.Context, *AddUserRelationRequest) (*UserRelation, error)
 	RemoveUserRelation(context.Context, *UserRelationPrimaryKey) (*UserRelation, error)
+	GetUserEffectiveRelations(context.Context, *UserPrimaryKey) (*GetUserEffectiveRelationsResponse, error)
+	AddUserRole(context.Context, *AddUserRoleRequest) (*UserRole, error)
+	RemoveUserRole(context.Context, *UserRolePrimaryKey) (*UserRole, error)
+	GetUserRoleList(context.Context, *UserPrimaryKey) (*GetUserRoleListResponse, error)
+	GrantUserPermission(context.Context, *GrantUserPermissionRequest) (*UserPermission, error)
+	RevokeUserPermission(context.Context, *UserPermissionPrimaryKey) (*UserPermission, error)
+	GetUserPermissionList(context.Context, *UserPrimaryKey) (*GetUserPermissionListResponse, error)
 	UpsertUserInfo(context.Context, *UpsertUserInfoRequest) (*UserInfo, error)
+	SendContactVerification(context.Context, *SendContactVerificationRequest) (*SendContactVerificationResponse, error)
+	VerifyContact(context.Context, *VerifyContactRequest) (*User, error)
+	ImportUsers(UserService_ImportUsersServer) error
+	ExportUsers(*ExportUsersRequest, UserService_ExportUsersServer) error
+	ExportUserData(context.Context, *DataSubjectRequest) (*ExportUserDataResponse, error)
+	EraseUserData(context.Context, *DataSubjectRequest) (*EraseUserDataResponse, error)
+	VerifyDataSubjectReceipt(context.Context, *DataSubjectReceipt) (*VerifyDataSubjectReceiptResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -183,21 +451,81 @@ func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserReq
 func (UnimplementedUserServiceServer) DeleteUser(context.Context, *UserPrimaryKey) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedUserServiceServer) RestoreUser(context.Context, *ChangeUserStateRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
 func (UnimplementedUserServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
 }
 func (UnimplementedUserServiceServer) SendMessageToEmail(context.Context, *SendMessageToEmailRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendMessageToEmail not implemented")
 }
+func (UnimplementedUserServiceServer) ActivateUser(context.Context, *ChangeUserStateRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateUser not implemented")
+}
+func (UnimplementedUserServiceServer) SuspendUser(context.Context, *SuspendUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendUser not implemented")
+}
+func (UnimplementedUserServiceServer) LockUser(context.Context, *ChangeUserStateRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockUser not implemented")
+}
+func (UnimplementedUserServiceServer) ReinstateUser(context.Context, *ChangeUserStateRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReinstateUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserStateHistory(context.Context, *UserPrimaryKey) (*GetUserStateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStateHistory not implemented")
+}
 func (UnimplementedUserServiceServer) AddUserRelation(context.Context, *AddUserRelationRequest) (*UserRelation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddUserRelation not implemented")
 }
 func (UnimplementedUserServiceServer) RemoveUserRelation(context.Context, *UserRelationPrimaryKey) (*UserRelation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserRelation not implemented")
 }
+func (UnimplementedUserServiceServer) GetUserEffectiveRelations(context.Context, *UserPrimaryKey) (*GetUserEffectiveRelationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserEffectiveRelations not implemented")
+}
+func (UnimplementedUserServiceServer) AddUserRole(context.Context, *AddUserRoleRequest) (*UserRole, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserRole not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveUserRole(context.Context, *UserRolePrimaryKey) (*UserRole, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserRole not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserRoleList(context.Context, *UserPrimaryKey) (*GetUserRoleListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserRoleList not implemented")
+}
+func (UnimplementedUserServiceServer) GrantUserPermission(context.Context, *GrantUserPermissionRequest) (*UserPermission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantUserPermission not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeUserPermission(context.Context, *UserPermissionPrimaryKey) (*UserPermission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserPermission not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserPermissionList(context.Context, *UserPrimaryKey) (*GetUserPermissionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserPermissionList not implemented")
+}
 func (UnimplementedUserServiceServer) UpsertUserInfo(context.Context, *UpsertUserInfoRequest) (*UserInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpsertUserInfo not implemented")
 }
+func (UnimplementedUserServiceServer) SendContactVerification(context.Context, *SendContactVerificationRequest) (*SendContactVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendContactVerification not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyContact(context.Context, *VerifyContactRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyContact not implemented")
+}
+func (UnimplementedUserServiceServer) ImportUsers(UserService_ImportUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedUserServiceServer) ExportUsers(*ExportUsersRequest, UserService_ExportUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (UnimplementedUserServiceServer) ExportUserData(context.Context, *DataSubjectRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedUserServiceServer) EraseUserData(context.Context, *DataSubjectRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUserData not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyDataSubjectReceipt(context.Context, *DataSubjectReceipt) (*VerifyDataSubjectReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyDataSubjectReceipt not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -319,6 +647,24 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeUserStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/RestoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RestoreUser(ctx, req.(*ChangeUserStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResetPasswordRequest)
 	if err := dec(in); err != nil {
@@ -355,6 +701,96 @@ func _UserService_SendMessageToEmail_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ActivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeUserStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ActivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/ActivateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ActivateUser(ctx, req.(*ChangeUserStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SuspendUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SuspendUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/SuspendUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SuspendUser(ctx, req.(*SuspendUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeUserStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/LockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LockUser(ctx, req.(*ChangeUserStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReinstateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeUserStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReinstateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/ReinstateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReinstateUser(ctx, req.(*ChangeUserStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserStateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserStateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/GetUserStateHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserStateHistory(ctx, req.(*UserPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_AddUserRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddUserRelationRequest)
 	if err := dec(in); err != nil {
@@ -391,6 +827,132 @@ func _UserService_RemoveUserRelation_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetUserEffectiveRelations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserEffectiveRelations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/GetUserEffectiveRelations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserEffectiveRelations(ctx, req.(*UserPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AddUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddUserRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/AddUserRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddUserRole(ctx, req.(*AddUserRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRolePrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveUserRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/RemoveUserRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveUserRole(ctx, req.(*UserRolePrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserRoleList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserRoleList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/GetUserRoleList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserRoleList(ctx, req.(*UserPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GrantUserPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantUserPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GrantUserPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/GrantUserPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GrantUserPermission(ctx, req.(*GrantUserPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeUserPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPermissionPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeUserPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/RevokeUserPermission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeUserPermission(ctx, req.(*UserPermissionPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserPermissionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserPermissionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/GetUserPermissionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserPermissionList(ctx, req.(*UserPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_UpsertUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpsertUserInfoRequest)
 	if err := dec(in); err != nil {
@@ -409,6 +971,143 @@ func _UserService_UpsertUserInfo_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_SendContactVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendContactVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SendContactVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/SendContactVerification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SendContactVerification(ctx, req.(*SendContactVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/VerifyContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyContact(ctx, req.(*VerifyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ImportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).ImportUsers(&userServiceImportUsersServer{stream})
+}
+
+type UserService_ImportUsersServer interface {
+	SendAndClose(*ImportUsersResponse) error
+	Recv() (*ImportUsersRequest, error)
+	grpc.ServerStream
+}
+
+type userServiceImportUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceImportUsersServer) SendAndClose(m *ImportUsersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *userServiceImportUsersServer) Recv() (*ImportUsersRequest, error) {
+	m := new(ImportUsersRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _UserService_ExportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportUsers(m, &userServiceExportUsersServer{stream})
+}
+
+type UserService_ExportUsersServer interface {
+	Send(*ExportUsersResponse) error
+	grpc.ServerStream
+}
+
+type userServiceExportUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceExportUsersServer) Send(m *ExportUsersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/ExportUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExportUserData(ctx, req.(*DataSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataSubjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EraseUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/EraseUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EraseUserData(ctx, req.(*DataSubjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyDataSubjectReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataSubjectReceipt)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyDataSubjectReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_service.UserService/VerifyDataSubjectReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyDataSubjectReceipt(ctx, req.(*DataSubjectReceipt))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -440,6 +1139,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _UserService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _UserService_RestoreUser_Handler,
+		},
 		{
 			MethodName: "ResetPassword",
 			Handler:    _UserService_ResetPassword_Handler,
@@ -448,6 +1151,26 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendMessageToEmail",
 			Handler:    _UserService_SendMessageToEmail_Handler,
 		},
+		{
+			MethodName: "ActivateUser",
+			Handler:    _UserService_ActivateUser_Handler,
+		},
+		{
+			MethodName: "SuspendUser",
+			Handler:    _UserService_SuspendUser_Handler,
+		},
+		{
+			MethodName: "LockUser",
+			Handler:    _UserService_LockUser_Handler,
+		},
+		{
+			MethodName: "ReinstateUser",
+			Handler:    _UserService_ReinstateUser_Handler,
+		},
+		{
+			MethodName: "GetUserStateHistory",
+			Handler:    _UserService_GetUserStateHistory_Handler,
+		},
 		{
 			MethodName: "AddUserRelation",
 			Handler:    _UserService_AddUserRelation_Handler,
@@ -456,11 +1179,70 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveUserRelation",
 			Handler:    _UserService_RemoveUserRelation_Handler,
 		},
+		{
+			MethodName: "GetUserEffectiveRelations",
+			Handler:    _UserService_GetUserEffectiveRelations_Handler,
+		},
+		{
+			MethodName: "AddUserRole",
+			Handler:    _UserService_AddUserRole_Handler,
+		},
+		{
+			MethodName: "RemoveUserRole",
+			Handler:    _UserService_RemoveUserRole_Handler,
+		},
+		{
+			MethodName: "GetUserRoleList",
+			Handler:    _UserService_GetUserRoleList_Handler,
+		},
+		{
+			MethodName: "GrantUserPermission",
+			Handler:    _UserService_GrantUserPermission_Handler,
+		},
+		{
+			MethodName: "RevokeUserPermission",
+			Handler:    _UserService_RevokeUserPermission_Handler,
+		},
+		{
+			MethodName: "GetUserPermissionList",
+			Handler:    _UserService_GetUserPermissionList_Handler,
+		},
 		{
 			MethodName: "UpsertUserInfo",
 			Handler:    _UserService_UpsertUserInfo_Handler,
 		},
+		{
+			MethodName: "SendContactVerification",
+			Handler:    _UserService_SendContactVerification_Handler,
+		},
+		{
+			MethodName: "VerifyContact",
+			Handler:    _UserService_VerifyContact_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _UserService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "EraseUserData",
+			Handler:    _UserService_EraseUserData_Handler,
+		},
+		{
+			MethodName: "VerifyDataSubjectReceipt",
+			Handler:    _UserService_VerifyDataSubjectReceipt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportUsers",
+			Handler:       _UserService_ImportUsers_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportUsers",
+			Handler:       _UserService_ExportUsers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user_service.proto",
 }
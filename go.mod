@@ -25,4 +25,5 @@ require (
 	golang.org/x/tools v0.1.9 // indirect
 	google.golang.org/grpc v1.39.0-dev
 	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -6,9 +6,11 @@ import (
 	"upm/udevs_go_auth_service/config"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/grpc/client"
+	"upm/udevs_go_auth_service/pkg/userinfo"
 	"upm/udevs_go_auth_service/storage"
 
 	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -129,6 +131,12 @@ func (s *clientService) DeleteClientPlatform(ctx context.Context, req *pb.Client
 func (s *clientService) CreateClientType(ctx context.Context, req *pb.CreateClientTypeRequest) (*pb.ClientType, error) {
 	s.log.Info("---CreateClientType--->", logger.Any("req", req))
 
+	err := s.checkRequireVerifiedContact(req.ConfirmBy, req.RequireVerifiedContact)
+	if err != nil {
+		s.log.Error("!!!CreateClientType--->", logger.Error(err))
+		return nil, err
+	}
+
 	pKey, err := s.strg.ClientType().Create(ctx, req)
 
 	if err != nil {
@@ -168,6 +176,12 @@ func (s *clientService) GetClientTypeList(ctx context.Context, req *pb.GetClient
 func (s *clientService) UpdateClientType(ctx context.Context, req *pb.UpdateClientTypeRequest) (*pb.ClientType, error) {
 	s.log.Info("---UpdateClientType--->", logger.Any("req", req))
 
+	err := s.checkRequireVerifiedContact(req.ConfirmBy, req.RequireVerifiedContact)
+	if err != nil {
+		s.log.Error("!!!UpdateClientType--->", logger.Error(err))
+		return nil, err
+	}
+
 	rowsAffected, err := s.strg.ClientType().Update(ctx, req)
 
 	if err != nil {
@@ -320,7 +334,19 @@ func (s *clientService) RemoveClient(ctx context.Context, req *pb.ClientPrimaryK
 func (s *clientService) AddRelation(ctx context.Context, req *pb.AddRelationRequest) (*pb.Relation, error) {
 	s.log.Info("---AddRelation--->", logger.Any("req", req))
 
+	if len(req.ParentId) > 0 && !util.IsValidUUID(req.ParentId) {
+		return nil, status.Error(codes.InvalidArgument, "parent id is an invalid uuid")
+	}
+
 	pKey, err := s.strg.Relation().Add(ctx, req)
+	if err == storage.ErrorRelationParentNotFound {
+		s.log.Error("!!!AddRelation--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err == storage.ErrorRelationParentClientType {
+		s.log.Error("!!!AddRelation--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
 		s.log.Error("!!!AddRelation--->", logger.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -365,6 +391,16 @@ func (s *clientService) RemoveRelation(ctx context.Context, req *pb.RelationPrim
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	subtree, err := s.strg.Relation().GetSubtree(ctx, req)
+	if err != nil {
+		s.log.Error("!!!RemoveRelation--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if len(subtree) > 1 {
+		return nil, status.Error(codes.FailedPrecondition, "relation has children, move or remove them first")
+	}
+
 	rowsAffected, err := s.strg.Relation().Remove(ctx, req)
 
 	if err != nil {
@@ -379,21 +415,148 @@ func (s *clientService) RemoveRelation(ctx context.Context, req *pb.RelationPrim
 	return res, nil
 }
 
+// GetRelationSubtree returns the relation and all its descendants, parents before their children
+func (s *clientService) GetRelationSubtree(ctx context.Context, req *pb.RelationPrimaryKey) (*pb.GetRelationSubtreeResponse, error) {
+	s.log.Info("---GetRelationSubtree--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "relation id is an invalid uuid")
+	}
+
+	relations, err := s.strg.Relation().GetSubtree(ctx, req)
+	if err != nil {
+		s.log.Error("!!!GetRelationSubtree--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if len(relations) == 0 {
+		return nil, status.Error(codes.NotFound, "relation not found")
+	}
+
+	return &pb.GetRelationSubtreeResponse{Relations: relations}, nil
+}
+
+// MoveRelation moves the relation with its subtree under another relation of its client type or to the root
+func (s *clientService) MoveRelation(ctx context.Context, req *pb.MoveRelationRequest) (*pb.Relation, error) {
+	s.log.Info("---MoveRelation--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "relation id is an invalid uuid")
+	}
+
+	if len(req.ParentId) > 0 && !util.IsValidUUID(req.ParentId) {
+		return nil, status.Error(codes.InvalidArgument, "parent id is an invalid uuid")
+	}
+
+	for _, id := range []string{req.Id, req.ParentId} {
+		if len(id) == 0 {
+			continue
+		}
+
+		_, err := s.strg.Relation().GetByPK(ctx, &pb.RelationPrimaryKey{Id: id})
+		if err != nil {
+			s.log.Error("!!!MoveRelation--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+
+	err := s.strg.Relation().Move(ctx, req)
+	if err == storage.ErrorRelationCycle || err == storage.ErrorRelationParentClientType {
+		s.log.Error("!!!MoveRelation--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.log.Error("!!!MoveRelation--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res, err := s.strg.Relation().GetByPK(ctx, &pb.RelationPrimaryKey{Id: req.Id})
+	if err != nil {
+		s.log.Error("!!!MoveRelation--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
 func (s *clientService) AddUserInfoField(ctx context.Context, req *pb.AddUserInfoFieldRequest) (*pb.UserInfoField, error) {
 	s.log.Info("---AddUserInfoField--->", logger.Any("req", req))
 
+	err := userinfo.CheckField(&pb.UserInfoField{
+		ClientTypeId: req.ClientTypeId,
+		FieldName:    req.FieldName,
+		FieldType:    req.FieldType,
+		DataType:     req.DataType,
+		Required:     req.Required,
+		EnumValues:   req.EnumValues,
+		Pattern:      req.Pattern,
+		MinValue:     req.MinValue,
+		MaxValue:     req.MaxValue,
+		DefaultValue: req.DefaultValue,
+	})
+	if err != nil {
+		s.log.Error("!!!AddUserInfoField--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.checkUserInfoFieldName(ctx, req.ClientTypeId, req.FieldName, "")
+	if err != nil {
+		s.log.Error("!!!AddUserInfoField--->", logger.Error(err))
+		return nil, err
+	}
+
 	pKey, err := s.strg.UserInfoField().Add(ctx, req)
 	if err != nil {
 		s.log.Error("!!!AddUserInfoField--->", logger.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if req.Searchable {
+		err = s.strg.UserInfoField().SyncSearchIndex(ctx, req.FieldName)
+		if err != nil {
+			s.log.Error("!!!AddUserInfoField--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
 	return s.strg.UserInfoField().GetByPK(ctx, pKey)
 }
 
 func (s *clientService) UpdateUserInfoField(ctx context.Context, req *pb.UpdateUserInfoFieldRequest) (*pb.UserInfoField, error) {
 	s.log.Info("---UpdateUserInfoField--->", logger.Any("req", req))
 
+	err := userinfo.CheckField(&pb.UserInfoField{
+		Id:           req.Id,
+		ClientTypeId: req.ClientTypeId,
+		FieldName:    req.FieldName,
+		FieldType:    req.FieldType,
+		DataType:     req.DataType,
+		Required:     req.Required,
+		EnumValues:   req.EnumValues,
+		Pattern:      req.Pattern,
+		MinValue:     req.MinValue,
+		MaxValue:     req.MaxValue,
+		DefaultValue: req.DefaultValue,
+	})
+	if err != nil {
+		s.log.Error("!!!UpdateUserInfoField--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	old, err := s.strg.UserInfoField().GetByPK(ctx, &pb.UserInfoFieldPrimaryKey{
+		Id: req.Id,
+	})
+	if err != nil {
+		s.log.Error("!!!UpdateUserInfoField--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	err = s.checkUserInfoFieldName(ctx, req.ClientTypeId, req.FieldName, req.Id)
+	if err != nil {
+		s.log.Error("!!!UpdateUserInfoField--->", logger.Error(err))
+		return nil, err
+	}
+
 	rowsAffected, err := s.strg.UserInfoField().Update(ctx, req)
 
 	if err != nil {
@@ -414,6 +577,14 @@ func (s *clientService) UpdateUserInfoField(ctx context.Context, req *pb.UpdateU
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
+	for _, fieldName := range []string{old.FieldName, res.FieldName} {
+		err = s.strg.UserInfoField().SyncSearchIndex(ctx, fieldName)
+		if err != nil {
+			s.log.Error("!!!UpdateUserInfoField--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
 	return res, err
 }
 
@@ -438,5 +609,67 @@ func (s *clientService) RemoveUserInfoField(ctx context.Context, req *pb.UserInf
 		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
 	}
 
+	if res.Searchable {
+		err = s.strg.UserInfoField().SyncSearchIndex(ctx, res.FieldName)
+		if err != nil {
+			s.log.Error("!!!RemoveUserInfoField--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
 	return res, nil
 }
+
+// checkRequireVerifiedContact refuses to require a verified phone while passcodes can't be sent to phones,
+// nobody of the client type could log in then
+func (s *clientService) checkRequireVerifiedContact(confirmBy pb.ConfirmStrategies, requireVerifiedContact bool) error {
+	if requireVerifiedContact && confirmBy == pb.ConfirmStrategies_PHONE && !canSendPasscode(s.cfg, confirmBy) {
+		return status.Error(codes.FailedPrecondition, "phones can't be verified without an sms hook, set SMS_HOOK_URL")
+	}
+
+	return nil
+}
+
+// checkUserInfoFieldName rejects a field name the client type already has, id is the field being updated
+func (s *clientService) checkUserInfoFieldName(ctx context.Context, clientTypeID, fieldName, id string) error {
+	fields, err := s.strg.UserInfoField().GetListByClientTypeID(ctx, clientTypeID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for _, field := range fields {
+		if field.FieldName == fieldName && field.Id != id {
+			return status.Error(codes.AlreadyExists, "client type already has a user info field named "+fieldName)
+		}
+	}
+
+	return nil
+}
+
+func (s *clientService) GetUserInfoSchema(ctx context.Context, req *pb.ClientTypePrimaryKey) (*pb.UserInfoSchema, error) {
+	s.log.Info("---GetUserInfoSchema--->", logger.Any("req", req))
+
+	fields, err := s.strg.UserInfoField().GetListByClientTypeID(ctx, req.Id)
+	if err != nil {
+		s.log.Error("!!!GetUserInfoSchema--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	schema, err := userinfo.Compile(fields)
+	if err != nil {
+		s.log.Error("!!!GetUserInfoSchema--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	jsonSchema, err := schema.JSONSchema()
+	if err != nil {
+		s.log.Error("!!!GetUserInfoSchema--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserInfoSchema{
+		ClientTypeId: req.Id,
+		Fields:       fields,
+		JsonSchema:   jsonSchema,
+	}, nil
+}
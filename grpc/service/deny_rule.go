@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/access"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateDenyRule adds a rule overriding allows, see pkg/access for the evaluation order
+func (s *permissionService) CreateDenyRule(ctx context.Context, req *pb.CreateDenyRuleRequest) (*pb.DenyRule, error) {
+	s.log.Info("---CreateDenyRule--->", logger.Any("req", req))
+
+	err := validateDenyRule(req)
+	if err != nil {
+		s.log.Error("!!!CreateDenyRule--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(req.RoleId) > 0 {
+		role, err := s.strg.Role().GetByPK(ctx, &pb.RolePrimaryKey{Id: req.RoleId})
+		if err != nil {
+			s.log.Error("!!!CreateDenyRule--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		if role.ClientPlatformId != req.ClientPlatformId {
+			return nil, status.Error(codes.FailedPrecondition, "role belongs to another client platform")
+		}
+	} else {
+		user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+		if err != nil {
+			s.log.Error("!!!CreateDenyRule--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		if user.ClientPlatformId != req.ClientPlatformId {
+			return nil, status.Error(codes.FailedPrecondition, "user belongs to another client platform")
+		}
+	}
+
+	if len(req.PermissionId) > 0 {
+		permission, err := s.strg.Permission().GetByPK(ctx, &pb.PermissionPrimaryKey{Id: req.PermissionId})
+		if err != nil {
+			s.log.Error("!!!CreateDenyRule--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		if permission.ClientPlatformId != req.ClientPlatformId {
+			return nil, status.Error(codes.FailedPrecondition, "permission belongs to another client platform")
+		}
+	}
+
+	pKey, err := s.strg.DenyRule().Create(ctx, req)
+	if err != nil {
+		s.log.Error("!!!CreateDenyRule--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res, err := s.strg.DenyRule().GetByPK(ctx, pKey)
+	if err != nil {
+		s.log.Error("!!!CreateDenyRule--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func validateDenyRule(req *pb.CreateDenyRuleRequest) error {
+	if !util.IsValidUUID(req.ClientPlatformId) {
+		return errors.New("client platform id is an invalid uuid")
+	}
+
+	if (len(req.RoleId) > 0) == (len(req.UserId) > 0) {
+		return errors.New("exactly one of role_id and user_id must be given")
+	}
+
+	if len(req.RoleId) > 0 && !util.IsValidUUID(req.RoleId) {
+		return errors.New("role id is an invalid uuid")
+	}
+
+	if len(req.UserId) > 0 && !util.IsValidUUID(req.UserId) {
+		return errors.New("user id is an invalid uuid")
+	}
+
+	req.Method = strings.ToUpper(strings.TrimSpace(req.Method))
+	req.PathPattern = strings.TrimSpace(req.PathPattern)
+
+	if len(req.PermissionId) > 0 {
+		if !util.IsValidUUID(req.PermissionId) {
+			return errors.New("permission id is an invalid uuid")
+		}
+
+		if len(req.Method) > 0 || len(req.PathPattern) > 0 {
+			return errors.New("a rule is for either a permission or a scope pattern")
+		}
+
+		return nil
+	}
+
+	if len(req.Method) == 0 || len(req.PathPattern) == 0 {
+		return errors.New("either permission_id or method and path_pattern must be given")
+	}
+
+	if !access.ValidatePattern(req.PathPattern) {
+		return errors.New("path_pattern must start with / and may only end with **")
+	}
+
+	return nil
+}
+
+func (s *permissionService) DeleteDenyRule(ctx context.Context, req *pb.DenyRulePrimaryKey) (*pb.DenyRule, error) {
+	s.log.Info("---DeleteDenyRule--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "deny rule id is an invalid uuid")
+	}
+
+	res, err := s.strg.DenyRule().GetByPK(ctx, req)
+	if err != nil {
+		s.log.Error("!!!DeleteDenyRule--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	rowsAffected, err := s.strg.DenyRule().Delete(ctx, req)
+	if err != nil {
+		s.log.Error("!!!DeleteDenyRule--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	return res, nil
+}
+
+func (s *permissionService) GetDenyRuleList(ctx context.Context, req *pb.GetDenyRuleListRequest) (*pb.GetDenyRuleListResponse, error) {
+	s.log.Info("---GetDenyRuleList--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.ClientPlatformId) {
+		return nil, status.Error(codes.InvalidArgument, "client platform id is an invalid uuid")
+	}
+
+	denyRules, err := s.strg.DenyRule().GetList(ctx, req)
+	if err != nil {
+		s.log.Error("!!!GetDenyRuleList--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetDenyRuleListResponse{DenyRules: denyRules}, nil
+}
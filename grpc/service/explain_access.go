@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/access"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExplainAccess decides a request of the user or the role the way HasAccess does and returns what the decision
+// was reached from, role changes of the request are tried out without being saved
+func (s *permissionService) ExplainAccess(ctx context.Context, req *pb.ExplainAccessRequest) (*pb.ExplainAccessResponse, error) {
+	s.log.Info("---ExplainAccess--->", logger.Any("req", req))
+
+	err := validateExplainAccess(req)
+	if err != nil {
+		s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := &pb.ExplainAccessResponse{}
+	roleIDs := []string{req.RoleId}
+
+	if len(req.UserId) > 0 {
+		user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+		if err != nil {
+			s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		res.UserState = user.State
+		if err := checkUserState(user); err != nil {
+			res.UserStateError = status.Convert(err).Message()
+		}
+
+		roles, err := s.strg.UserRole().GetActiveRoles(ctx, user.Id)
+		if err != nil {
+			s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		roleIDs = getRoleIDs(roles)
+	} else {
+		_, err := s.strg.Role().GetByPK(ctx, &pb.RolePrimaryKey{Id: req.RoleId})
+		if err != nil {
+			s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+
+	for _, roleID := range req.AddRoleIds {
+		_, err := s.strg.Role().GetByPK(ctx, &pb.RolePrimaryKey{Id: roleID})
+		if err != nil {
+			s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+
+	roleIDs = changeRoles(roleIDs, req.AddRoleIds, req.RemoveRoleIds)
+	res.Hypothetical = len(req.AddRoleIds) > 0 || len(req.RemoveRoleIds) > 0
+
+	// the roles of inactive assignments are loaded too to tell whether they would have allowed the request,
+	// the decision itself is made with roleIDs only
+	var inactive []*pb.UserRole
+	policyRoleIDs := append([]string{}, roleIDs...)
+	if len(req.UserId) > 0 {
+		inactive, err = s.strg.UserRole().GetInactiveByUserID(ctx, req.UserId)
+		if err != nil {
+			s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		for _, assignment := range inactive {
+			policyRoleIDs = append(policyRoleIDs, assignment.RoleId)
+		}
+	}
+
+	policy, err := s.strg.PermissionScope().GetAccessPolicy(ctx, req.UserId, policyRoleIDs, req.ClientPlatformId, req.Path, req.Method)
+	if err != nil {
+		s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	trace := access.Explain(policy, roleIDs, req.Method, req.Path, req.RelationId)
+
+	res.Allowed = trace.Allowed && len(res.UserStateError) == 0
+	res.PermissionId = trace.PermissionID
+	res.RoleId = trace.RoleID
+	res.RelationScoped = trace.RelationScoped
+	res.DenyRule = trace.DenyRule
+	res.RoleIds = roleIDs
+	res.InheritedRoleIds = trace.Roles[len(roleIDs):]
+	res.DenyRules = trace.Denies
+	res.RelationIds = policy.UserRelations
+
+	userGrants := map[string]bool{}
+	for _, permissionID := range trace.UserGrants {
+		userGrants[permissionID] = true
+	}
+
+	for _, permissionID := range policy.ScopePermissions {
+		permission, err := s.strg.Permission().GetByPK(ctx, &pb.PermissionPrimaryKey{Id: permissionID})
+		if err != nil {
+			s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		res.Permissions = append(res.Permissions, &pb.AccessTracePermission{
+			PermissionId:   permission.Id,
+			Name:           permission.Name,
+			RelationScoped: permission.RelationScoped,
+			RoleIds:        trace.Grants[permissionID],
+			GrantedToUser:  userGrants[permissionID],
+		})
+	}
+
+	active := map[string]bool{}
+	for _, roleID := range roleIDs {
+		active[roleID] = true
+	}
+
+	for _, assignment := range inactive {
+		if !active[assignment.RoleId] && len(access.Grants(policy, []string{assignment.RoleId})) > 0 {
+			res.InactiveRoles = append(res.InactiveRoles, assignment)
+		}
+	}
+
+	if len(req.UserId) > 0 {
+		res.InactiveGrants, err = s.strg.UserPermission().GetInactiveForPermissions(ctx, req.UserId, policy.ScopePermissions)
+		if err != nil {
+			s.log.Error("!!!ExplainAccess--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	res.RecentAuthMinutes = policy.RecentAuthMinutes[trace.PermissionID]
+
+	res.Reason = explainReason(res, trace)
+
+	return res, nil
+}
+
+func validateExplainAccess(req *pb.ExplainAccessRequest) error {
+	if !util.IsValidUUID(req.ClientPlatformId) {
+		return errors.New("client platform id is an invalid uuid")
+	}
+
+	if (len(req.RoleId) > 0) == (len(req.UserId) > 0) {
+		return errors.New("exactly one of role_id and user_id must be given")
+	}
+
+	if len(req.RoleId) > 0 && !util.IsValidUUID(req.RoleId) {
+		return errors.New("role id is an invalid uuid")
+	}
+
+	if len(req.UserId) > 0 && !util.IsValidUUID(req.UserId) {
+		return errors.New("user id is an invalid uuid")
+	}
+
+	if len(req.Method) == 0 || len(req.Path) == 0 {
+		return errors.New("method and path are required")
+	}
+
+	if len(req.RelationId) > 0 && !util.IsValidUUID(req.RelationId) {
+		return errors.New("relation id is an invalid uuid")
+	}
+
+	for _, roleID := range append(append([]string{}, req.AddRoleIds...), req.RemoveRoleIds...) {
+		if !util.IsValidUUID(roleID) {
+			return errors.New("role id " + roleID + " is an invalid uuid")
+		}
+	}
+
+	return nil
+}
+
+// changeRoles removes and then adds roles keeping the order, each role once
+func changeRoles(roleIDs, add, remove []string) []string {
+	removed := map[string]bool{}
+	for _, roleID := range remove {
+		removed[roleID] = true
+	}
+
+	res := []string{}
+	seen := map[string]bool{}
+	for _, roleID := range append(append([]string{}, roleIDs...), add...) {
+		if removed[roleID] || seen[roleID] {
+			continue
+		}
+		seen[roleID] = true
+
+		res = append(res, roleID)
+	}
+
+	return res
+}
+
+func explainReason(res *pb.ExplainAccessResponse, trace access.Trace) string {
+	switch {
+	case len(res.UserStateError) > 0:
+		return "the state of the user blocks access: " + res.UserStateError
+	case trace.DenyRule != nil:
+		return "denied by the deny rule " + trace.DenyRule.Id
+	case trace.Allowed:
+		reason := "allowed by the permission " + trace.PermissionID
+		if len(trace.RoleID) > 0 {
+			reason += " of the role " + trace.RoleID
+		} else {
+			reason += " granted to the user"
+		}
+
+		if trace.RelationScoped {
+			reason += " for resources of the relations of the user only"
+		}
+
+		return reason
+	case len(res.Permissions) == 0:
+		return "no permission has the scope"
+	case len(trace.Grants) > 0 || len(trace.UserGrants) > 0:
+		return "the permissions granted are relation scoped and the resource isn't in a relation of the user"
+	default:
+		return "no role of the subject has a permission with the scope and none is granted to the user"
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/helper"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/security"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sendPasscode stores a new code for the purpose and delivers it to the target,
+// the returned passcode carries the id and the expiry only
+func sendPasscode(ctx context.Context, strg storage.StorageI, cfg config.Config, entity *pb.CreatePasscodeRequest) (*pb.Passcode, error) {
+	if len(entity.Target) == 0 || !canSendPasscode(cfg, entity.ConfirmBy) {
+		return nil, status.Error(codes.FailedPrecondition, "passcode can't be delivered to the user")
+	}
+
+	code, err := helper.GeneratePasscode(cfg.PasscodePool, cfg.PasscodeLength)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	entity.HashedCode, err = security.HashPassword(code)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	entity.ExpiresAt = time.Now().Add(config.PasscodeExpiresInTime).Format(config.DatabaseTimeLayout)
+
+	pKey, err := strg.Passcode().Create(ctx, entity)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if entity.ConfirmBy == pb.ConfirmStrategies_PHONE {
+		err = helper.SendPasscodeSMS(cfg.SMSHookURL, entity.Target, code)
+	} else {
+		err = helper.SendPasscodeEmail("Confirmation Code", entity.Target, code)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.Passcode{
+		Id:        pKey.Id,
+		ExpiresAt: entity.ExpiresAt,
+	}, nil
+}
+
+// canSendPasscode reports whether passcodes can be delivered by the strategy, phones need the sms hook
+func canSendPasscode(cfg config.Config, confirmBy pb.ConfirmStrategies) bool {
+	switch confirmBy {
+	case pb.ConfirmStrategies_EMAIL:
+		return true
+	case pb.ConfirmStrategies_PHONE:
+		return len(cfg.SMSHookURL) > 0
+	}
+
+	return false
+}
+
+// usePasscode checks the code and marks it as confirmed, so it can't be used twice,
+// a code sent for another purpose is rejected
+func usePasscode(ctx context.Context, strg storage.StorageI, id, code, userID, purpose string) (*pb.Passcode, error) {
+	passcode, err := strg.Passcode().GetByPK(ctx, &pb.PasscodePrimaryKey{Id: id})
+	if err != nil {
+		return nil, errors.New("invalid passcode")
+	}
+
+	if passcode.UserId != userID || passcode.Purpose != purpose || passcode.State != config.PasscodeStatePending {
+		return nil, errors.New("invalid passcode")
+	}
+
+	expiresAt, err := time.Parse(config.DatabaseTimeLayout, passcode.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Unix() < time.Now().Unix() {
+		return nil, errors.New("passcode has been expired")
+	}
+
+	// the attempt is counted before the check, so concurrent guesses can't exceed the limit
+	rowsAffected, err := strg.Passcode().UseAttempt(ctx, &pb.PasscodePrimaryKey{Id: id}, config.PasscodeMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected <= 0 {
+		return nil, errors.New("too many attempts, request a new passcode")
+	}
+
+	match, err := security.ComparePassword(passcode.HashedCode, code)
+	if err != nil || !match {
+		return nil, errors.New("invalid passcode")
+	}
+
+	rowsAffected, err = strg.Passcode().UpdateState(ctx, &pb.PasscodePrimaryKey{Id: id}, config.PasscodeStateConfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected <= 0 {
+		return nil, errors.New("invalid passcode")
+	}
+
+	return passcode, nil
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"sort"
+	"strings"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/access"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPermissionMatrix shows what every role of the client platform may do, inherited permissions and deny
+// rules of the roles included, grants and rules of users and relations aren't part of it
+func (s *permissionService) GetPermissionMatrix(ctx context.Context, req *pb.GetPermissionMatrixRequest) (*pb.GetPermissionMatrixResponse, error) {
+	s.log.Info("---GetPermissionMatrix--->", logger.Any("req", req))
+
+	res, err := s.getPermissionMatrix(ctx, req)
+	if err != nil {
+		s.log.Error("!!!GetPermissionMatrix--->", logger.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ExportPermissionMatrix writes the matrix of GetPermissionMatrix as csv
+func (s *permissionService) ExportPermissionMatrix(ctx context.Context, req *pb.GetPermissionMatrixRequest) (*pb.ExportPermissionMatrixResponse, error) {
+	s.log.Info("---ExportPermissionMatrix--->", logger.Any("req", req))
+
+	matrix, err := s.getPermissionMatrix(ctx, req)
+	if err != nil {
+		s.log.Error("!!!ExportPermissionMatrix--->", logger.Error(err))
+		return nil, err
+	}
+
+	paths, err := s.strg.RBAC().GetPermissionPaths(ctx, req.ClientPlatformId)
+	if err != nil {
+		s.log.Error("!!!ExportPermissionMatrix--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	data, err := permissionMatrixCSV(matrix, paths)
+	if err != nil {
+		s.log.Error("!!!ExportPermissionMatrix--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ExportPermissionMatrixResponse{
+		Csv: data,
+	}, nil
+}
+
+// getPermissionMatrix decides every role alone with its ancestors, for every permission and with
+// include_scopes for every scope of the client platform, see access.EvaluateRole
+func (s *permissionService) getPermissionMatrix(ctx context.Context, req *pb.GetPermissionMatrixRequest) (*pb.GetPermissionMatrixResponse, error) {
+	if !util.IsValidUUID(req.ClientPlatformId) {
+		return nil, status.Error(codes.InvalidArgument, "client platform id is an invalid uuid")
+	}
+
+	_, err := s.strg.ClientPlatform().GetByPK(ctx, &pb.ClientPlatformPrimaryKey{Id: req.ClientPlatformId})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	roles, err := s.strg.Role().GetList(ctx, &pb.GetRolesListRequest{ClientPlatformId: req.ClientPlatformId})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	sort.SliceStable(roles.Roles, func(i, j int) bool {
+		return roles.Roles[i].Name < roles.Roles[j].Name
+	})
+
+	permissions, err := s.strg.Permission().GetListByClientPlatformId(ctx, req.ClientPlatformId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	policy, err := s.strg.PermissionScope().GetPlatformPolicy(ctx, req.ClientPlatformId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	relationScoped := map[string]bool{}
+	for _, permission := range permissions {
+		relationScoped[permission.Id] = permission.RelationScoped
+	}
+
+	res := &pb.GetPermissionMatrixResponse{
+		Roles:       roles.Roles,
+		Permissions: permissions,
+	}
+
+	for _, role := range res.Roles {
+		for _, permission := range permissions {
+			cell := access.EvaluateRole(policy, role.Id, []string{permission.Id}, "", "")
+			if cell.Granted {
+				res.PermissionCells = append(res.PermissionCells, permissionMatrixCell(role.Id, cell, relationScoped))
+			}
+		}
+	}
+
+	if !req.IncludeScopes {
+		return res, nil
+	}
+
+	permissionScopes, err := s.strg.PermissionScope().GetListByClientPlatformID(ctx, req.ClientPlatformId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// a scope may belong to several permissions, any of them allows it
+	scopePermissions := map[string][]string{}
+	for _, permissionScope := range permissionScopes {
+		key := permissionScope.Method + " " + permissionScope.Path
+		if _, ok := scopePermissions[key]; !ok {
+			res.Scopes = append(res.Scopes, &pb.Scope{
+				ClientPlatformId: permissionScope.ClientPlatformId,
+				Path:             permissionScope.Path,
+				Method:           permissionScope.Method,
+			})
+		}
+
+		scopePermissions[key] = append(scopePermissions[key], permissionScope.PermissionId)
+	}
+
+	for _, role := range res.Roles {
+		for _, scope := range res.Scopes {
+			cell := access.EvaluateRole(policy, role.Id, scopePermissions[scope.Method+" "+scope.Path], scope.Method, scope.Path)
+			if !cell.Granted {
+				continue
+			}
+
+			scopeCell := permissionMatrixCell(role.Id, cell, relationScoped)
+			scopeCell.Method = scope.Method
+			scopeCell.Path = scope.Path
+
+			res.ScopeCells = append(res.ScopeCells, scopeCell)
+		}
+	}
+
+	return res, nil
+}
+
+func permissionMatrixCell(roleID string, cell access.MatrixCell, relationScoped map[string]bool) *pb.PermissionMatrixCell {
+	res := &pb.PermissionMatrixCell{
+		RoleId:          roleID,
+		PermissionId:    cell.PermissionID,
+		Allowed:         cell.Allowed,
+		GrantedByRoleId: cell.RoleID,
+		RelationScoped:  relationScoped[cell.PermissionID],
+	}
+
+	if cell.DenyRule != nil {
+		res.DenyRuleId = cell.DenyRule.Id
+	}
+
+	return res
+}
+
+// permissionMatrixCSV writes a row per role and a column per permission, named by its path in the tree,
+// and per scope, named by its method and path, a cell says how the role has it and is empty if it doesn't
+func permissionMatrixCSV(matrix *pb.GetPermissionMatrixResponse, paths map[string]string) ([]byte, error) {
+	roleNames := map[string]string{}
+	for _, role := range matrix.Roles {
+		roleNames[role.Id] = role.Name
+	}
+
+	header := []string{"role_id", "role"}
+	columns := map[string]int{}
+	for _, permission := range matrix.Permissions {
+		columns[permission.Id] = len(header)
+		header = append(header, paths[permission.Id])
+	}
+
+	for _, scope := range matrix.Scopes {
+		columns[scope.Method+" "+scope.Path] = len(header)
+		header = append(header, scope.Method+" "+scope.Path)
+	}
+
+	records := map[string][]string{}
+	for _, role := range matrix.Roles {
+		records[role.Id] = make([]string, len(header))
+		records[role.Id][0] = role.Id
+		records[role.Id][1] = role.Name
+	}
+
+	for _, cell := range matrix.PermissionCells {
+		records[cell.RoleId][columns[cell.PermissionId]] = permissionMatrixValue(cell, roleNames)
+	}
+
+	for _, cell := range matrix.ScopeCells {
+		records[cell.RoleId][columns[cell.Method+" "+cell.Path]] = permissionMatrixValue(cell, roleNames)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	err := writer.Write(csvSafe(header))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range matrix.Roles {
+		err = writer.Write(csvSafe(records[role.Id]))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	return buf.Bytes(), writer.Error()
+}
+
+func permissionMatrixValue(cell *pb.PermissionMatrixCell, roleNames map[string]string) string {
+	value := "granted"
+	if cell.GrantedByRoleId != cell.RoleId {
+		value = "inherited from " + roleNames[cell.GrantedByRoleId]
+	}
+
+	if len(cell.DenyRuleId) > 0 {
+		return value + ", denied by rule " + cell.DenyRuleId
+	}
+
+	if cell.RelationScoped {
+		value += ", relation scoped"
+	}
+
+	return value
+}
+
+// csvSafe quotes the values a spreadsheet would take for a formula, names come from users
+func csvSafe(record []string) []string {
+	for i, value := range record {
+		if len(value) > 0 && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+			record[i] = "'" + value
+		}
+	}
+
+	return record
+}
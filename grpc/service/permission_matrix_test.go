@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionMatrixCSV(t *testing.T) {
+	matrix := &pb.GetPermissionMatrixResponse{
+		Roles: []*pb.Role{
+			{Id: "admin", Name: "=HYPERLINK(\"http://example.com\")"},
+			{Id: "viewer", Name: "viewer"},
+		},
+		Permissions: []*pb.Permission{
+			{Id: "reports", Name: "reports"},
+			{Id: "read", ParentId: "reports", Name: "reports/read"},
+		},
+		Scopes: []*pb.Scope{
+			{Method: "GET", Path: "/reports"},
+		},
+		PermissionCells: []*pb.PermissionMatrixCell{
+			{RoleId: "admin", PermissionId: "read", Allowed: true, GrantedByRoleId: "admin"},
+			{RoleId: "viewer", PermissionId: "read", GrantedByRoleId: "viewer", DenyRuleId: "deny"},
+		},
+		ScopeCells: []*pb.PermissionMatrixCell{
+			{RoleId: "viewer", PermissionId: "read", Allowed: true, GrantedByRoleId: "admin", Method: "GET", Path: "/reports"},
+		},
+	}
+
+	data, err := permissionMatrixCSV(matrix, map[string]string{
+		"reports": "/reports",
+		"read":    "/reports/read",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, `role_id,role,/reports,/reports/read,GET /reports
+admin,"'=HYPERLINK(""http://example.com"")",,granted,
+viewer,viewer,,"granted, denied by rule deny","inherited from =HYPERLINK(""http://example.com"")"
+`, string(data))
+}
@@ -7,6 +7,7 @@ import (
 	"upm/udevs_go_auth_service/storage"
 
 	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
 
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 
@@ -120,6 +121,63 @@ func (s *permissionService) RemoveRole(ctx context.Context, req *pb.RolePrimaryK
 	return res, nil
 }
 
+// AddRoleParent makes the role inherit the permissions of the parent and of its ancestors
+func (s *permissionService) AddRoleParent(ctx context.Context, req *pb.RoleParent) (*pb.GetRoleByIdResponse, error) {
+	s.log.Info("---AddRoleParent--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.RoleId) || !util.IsValidUUID(req.ParentId) {
+		return nil, status.Error(codes.InvalidArgument, "role id and parent id must be valid uuids")
+	}
+
+	for _, id := range []string{req.RoleId, req.ParentId} {
+		_, err := s.strg.Role().GetByPK(ctx, &pb.RolePrimaryKey{Id: id})
+		if err != nil {
+			s.log.Error("!!!AddRoleParent--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
+
+	err := s.strg.Role().AddParent(ctx, req)
+	if err == storage.ErrorRoleCycle || err == storage.ErrorRoleParentPlatform {
+		s.log.Error("!!!AddRoleParent--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.log.Error("!!!AddRoleParent--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := s.strg.Role().GetRoleByIdDetailed(ctx, &pb.RolePrimaryKey{Id: req.RoleId})
+	if err != nil {
+		s.log.Error("!!!AddRoleParent--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func (s *permissionService) RemoveRoleParent(ctx context.Context, req *pb.RoleParent) (*pb.GetRoleByIdResponse, error) {
+	s.log.Info("---RemoveRoleParent--->", logger.Any("req", req))
+
+	rowsAffected, err := s.strg.Role().RemoveParent(ctx, req)
+	if err != nil {
+		s.log.Error("!!!RemoveRoleParent--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	res, err := s.strg.Role().GetRoleByIdDetailed(ctx, &pb.RolePrimaryKey{Id: req.RoleId})
+	if err != nil {
+		s.log.Error("!!!RemoveRoleParent--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return res, nil
+}
+
 func (s *permissionService) CreatePermission(ctx context.Context, req *pb.CreatePermissionRequest) (*pb.GetPermissionByIDResponse, error) {
 	s.log.Info("---CreatePermission--->", logger.Any("req", req))
 
@@ -312,3 +370,27 @@ func (s *permissionService) RemoveRolePermission(ctx context.Context, req *pb.Ro
 
 	return res, nil
 }
+
+// PermissionList syncs the permissions of a client platform with a generated tree, only the existence of
+// the permissions and their scopes are reconciled, see GeneratePermission
+func (s *permissionService) PermissionList(ctx context.Context, req *pb.PermissionGenerated) (*pb.PermissionGeneratedResponse, error) {
+	s.log.Info("---PermissionList--->", logger.Any("client_platform_id", req.ClientPlatformId), logger.Any("prune", req.Prune))
+
+	if !util.IsValidUUID(req.ClientPlatformId) {
+		return nil, status.Error(codes.InvalidArgument, "client platform id is an invalid uuid")
+	}
+
+	_, err := s.strg.ClientPlatform().GetByPK(ctx, &pb.ClientPlatformPrimaryKey{Id: req.ClientPlatformId})
+	if err != nil {
+		s.log.Error("!!!PermissionList--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	res, err := s.strg.Permission().GeneratePermission(ctx, req)
+	if err != nil {
+		s.log.Error("!!!PermissionList--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return res, nil
+}
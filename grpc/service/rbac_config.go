@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/pkg/rbac"
+
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportRBACConfig writes the permission tree, scopes and roles of the client platform as yaml,
+// ApplyRBACConfig takes the same yaml back
+func (s *permissionService) ExportRBACConfig(ctx context.Context, req *pb.ExportRBACConfigRequest) (*pb.ExportRBACConfigResponse, error) {
+	s.log.Info("---ExportRBACConfig--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.ClientPlatformId) {
+		return nil, status.Error(codes.InvalidArgument, "client platform id is an invalid uuid")
+	}
+
+	config, err := s.strg.RBAC().GetConfig(ctx, req.ClientPlatformId)
+	if err != nil {
+		s.log.Error("!!!ExportRBACConfig--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	data, err := rbac.Marshal(config)
+	if err != nil {
+		s.log.Error("!!!ExportRBACConfig--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ExportRBACConfigResponse{
+		Config: data,
+	}, nil
+}
+
+// ApplyRBACConfig makes the client platform match the yaml, everything missing from it is deleted,
+// the plan is returned either way and nothing is changed if any step of it fails
+func (s *permissionService) ApplyRBACConfig(ctx context.Context, req *pb.ApplyRBACConfigRequest) (*pb.ApplyRBACConfigResponse, error) {
+	s.log.Info("---ApplyRBACConfig--->", logger.Any("client_platform_id", req.ClientPlatformId), logger.Any("dry_run", req.DryRun))
+
+	if !util.IsValidUUID(req.ClientPlatformId) {
+		return nil, status.Error(codes.InvalidArgument, "client platform id is an invalid uuid")
+	}
+
+	_, err := s.strg.ClientPlatform().GetByPK(ctx, &pb.ClientPlatformPrimaryKey{Id: req.ClientPlatformId})
+	if err != nil {
+		s.log.Error("!!!ApplyRBACConfig--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	config, err := rbac.Unmarshal(req.Config)
+	if err != nil {
+		s.log.Error("!!!ApplyRBACConfig--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	plan, err := s.strg.RBAC().Apply(ctx, req.ClientPlatformId, config, req.DryRun)
+	if err != nil {
+		s.log.Error("!!!ApplyRBACConfig--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &pb.ApplyRBACConfigResponse{
+		Plan:    plan,
+		Applied: !req.DryRun,
+	}, nil
+}
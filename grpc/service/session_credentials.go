@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/contact"
+	"upm/udevs_go_auth_service/pkg/helper"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/security"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ChangePassword lets the user of the session replace the password, the current one is required
+func (s *sessionService) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*emptypb.Empty, error) {
+	user, err := s.getCredentialOwner(ctx, req.AccessToken, req.CurrentPassword)
+	if err != nil {
+		s.log.Error("!!!ChangePassword--->", logger.Error(err))
+		return nil, err
+	}
+
+	if len(req.NewPassword) < 6 {
+		err := errors.New("password must not be less than 6 characters")
+		s.log.Error("!!!ChangePassword--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	hashedPassword, err := security.HashPassword(req.NewPassword)
+	if err != nil {
+		s.log.Error("!!!ChangePassword--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	rowsAffected, err := s.strg.User().ResetPassword(ctx, &pb.ResetPasswordRequest{
+		UserId:   user.Id,
+		Password: hashedPassword,
+	})
+	if err != nil {
+		s.log.Error("!!!ChangePassword--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	s.notifyCredentialChange(user, "Password Changed", "The password of your account has been changed")
+
+	return &emptypb.Empty{}, nil
+}
+
+// SendContactChangePasscode sends a passcode to the new email or phone, ChangeContact sets it with the code,
+// so a contact is never set to a value its owner hasn't confirmed, phones get the code through the sms hook
+func (s *sessionService) SendContactChangePasscode(ctx context.Context, req *pb.SendContactChangePasscodeRequest) (*pb.SendContactChangePasscodeResponse, error) {
+	s.log.Info("---SendContactChangePasscode--->", logger.Any("contact", req.Contact), logger.Any("value", req.Value))
+
+	user, err := s.getCredentialOwner(ctx, req.AccessToken, req.CurrentPassword)
+	if err != nil {
+		s.log.Error("!!!SendContactChangePasscode--->", logger.Error(err))
+		return nil, err
+	}
+
+	var value, current string
+	switch req.Contact {
+	case pb.ConfirmStrategies_EMAIL:
+		value, err = contact.NormalizeEmail(req.Value)
+		current = user.Email
+	case pb.ConfirmStrategies_PHONE:
+		value, err = contact.NormalizePhone(req.Value, s.cfg.DefaultPhoneRegion)
+		current = user.Phone
+	default:
+		err = errors.New("contact must be EMAIL or PHONE")
+	}
+	if err != nil {
+		s.log.Error("!!!SendContactChangePasscode--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if value == current {
+		err := fmt.Errorf("%s is the same as the current one", req.Contact)
+		s.log.Error("!!!SendContactChangePasscode--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	passcode, err := sendPasscode(ctx, s.strg, s.cfg, &pb.CreatePasscodeRequest{
+		ProjectId:        user.ProjectId,
+		ClientPlatformId: user.ClientPlatformId,
+		ClientTypeId:     user.ClientTypeId,
+		UserId:           user.Id,
+		ConfirmBy:        req.Contact,
+		Purpose:          config.PasscodePurposeChangeContact,
+		Target:           value,
+	})
+	if err != nil {
+		s.log.Error("!!!SendContactChangePasscode--->", logger.Error(err))
+		return nil, err
+	}
+
+	return &pb.SendContactChangePasscodeResponse{
+		PasscodeId: passcode.Id,
+		ConfirmBy:  req.Contact,
+		ExpiresAt:  passcode.ExpiresAt,
+	}, nil
+}
+
+func (s *sessionService) ChangeContact(ctx context.Context, req *pb.ChangeContactRequest) (*pb.User, error) {
+	user, err := s.getUserByAccessToken(ctx, req.AccessToken)
+	if err != nil {
+		s.log.Error("!!!ChangeContact--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	passcode, err := usePasscode(ctx, s.strg, req.PasscodeId, req.Passcode, user.Id, config.PasscodePurposeChangeContact)
+	if err != nil {
+		s.log.Error("!!!ChangeContact--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rowsAffected, err := s.strg.User().ChangeContact(ctx, user.Id, passcode.ConfirmBy, passcode.Target)
+	if err != nil {
+		s.log.Error("!!!ChangeContact--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	// user still holds the old values, so the old email or phone is notified
+	name := strings.ToLower(passcode.ConfirmBy.String())
+	s.notifyCredentialChange(user, "Contact Changed", fmt.Sprintf("The %s of your account has been changed to %s", name, passcode.Target))
+
+	res, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: user.Id})
+	if err != nil {
+		s.log.Error("!!!ChangeContact--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func (s *sessionService) ChangeLogin(ctx context.Context, req *pb.ChangeLoginRequest) (*pb.User, error) {
+	s.log.Info("---ChangeLogin--->", logger.Any("login", req.Login))
+
+	user, err := s.getCredentialOwner(ctx, req.AccessToken, req.CurrentPassword)
+	if err != nil {
+		s.log.Error("!!!ChangeLogin--->", logger.Error(err))
+		return nil, err
+	}
+
+	login := strings.TrimSpace(req.Login)
+	if len(login) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "login is required")
+	}
+
+	if login == user.Login {
+		return nil, status.Error(codes.InvalidArgument, "login is the same as the current one")
+	}
+
+	err = contact.CheckLogin(login, s.cfg.DefaultPhoneRegion)
+	if err != nil {
+		s.log.Error("!!!ChangeLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rowsAffected, err := s.strg.User().ChangeLogin(ctx, user.Id, login)
+	if err == storage.ErrorUserContactTaken {
+		s.log.Error("!!!ChangeLogin--->", logger.Error(err))
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		s.log.Error("!!!ChangeLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	s.notifyCredentialChange(user, "Login Changed", "The login of your account has been changed to "+login)
+
+	res, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: user.Id})
+	if err != nil {
+		s.log.Error("!!!ChangeLogin--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+// getCredentialOwner returns the user of the session once the current password is confirmed
+func (s *sessionService) getCredentialOwner(ctx context.Context, accessToken, currentPassword string) (*pb.User, error) {
+	user, err := s.getUserByAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = checkUserState(user)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.comparePassword(ctx, user.Id, currentPassword)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if !match {
+		return nil, status.Error(codes.InvalidArgument, "current password is wrong")
+	}
+
+	return user, nil
+}
+
+// notifyCredentialChange tells the user about the change through the contact it had before,
+// the change is already done so a failed notification is only logged
+func (s *sessionService) notifyCredentialChange(user *pb.User, subject, text string) {
+	text = text + ". If it wasn't you, contact the support at once."
+
+	var err error
+	switch {
+	case len(user.Email) > 0:
+		err = helper.SendNotificationEmail(subject, user.Email, text)
+	case len(user.Phone) > 0 && len(s.cfg.SMSHookURL) > 0:
+		err = helper.SendNotificationSMS(s.cfg.SMSHookURL, user.Phone, text)
+	default:
+		err = fmt.Errorf("user %s has no contact to be notified about %q through", user.Id, subject)
+	}
+	if err != nil {
+		s.log.Error("!!!notifyCredentialChange--->", logger.Error(err))
+	}
+}
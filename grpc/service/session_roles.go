@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+)
+
+// sessionRoleIDs returns the roles the session acts with now, so an assignment that expired
+// or was removed after the login stops counting without a new login
+func (s *sessionService) sessionRoleIDs(ctx context.Context, session *pb.Session) ([]string, error) {
+	roles, err := s.strg.UserRole().GetActiveRoles(ctx, session.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.ActiveRoleOnly {
+		roles = filterRoles(roles, session.RoleId)
+	}
+
+	return getRoleIDs(roles), nil
+}
+
+// sessionClaims are the claims of the access and refresh tokens of the session,
+// role_id is the primary or chosen role and role_ids every role the session acts with
+func sessionClaims(session *pb.Session, roleIDs []string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                 session.Id,
+		"project_id":         session.ProjectId,
+		"client_platform_id": session.ClientPlatformId,
+		"client_type_id":     session.ClientTypeId,
+		"user_id":            session.UserId,
+		"role_id":            session.RoleId,
+		"role_ids":           roleIDs,
+		"ip":                 session.Data,
+		"data":               session.Data,
+	}
+}
+
+func filterRoles(roles []*pb.Role, roleID string) []*pb.Role {
+	for _, role := range roles {
+		if role.Id == roleID {
+			return []*pb.Role{role}
+		}
+	}
+
+	return nil
+}
+
+func getRoleIDs(roles []*pb.Role) []string {
+	res := make([]string, 0, len(roles))
+	for _, role := range roles {
+		res = append(res, role.Id)
+	}
+
+	return res
+}
+
+// mergeGrantedPermissions adds the permissions granted to the user directly that none of the roles has,
+// grants apply to the session whatever role it acts with
+func mergeGrantedPermissions(permissions, granted []*pb.Permission) []*pb.Permission {
+	ids := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		ids[permission.Id] = true
+	}
+
+	for _, permission := range granted {
+		if !ids[permission.Id] {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
@@ -1,16 +1,21 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"time"
 	"upm/udevs_go_auth_service/config"
 	"upm/udevs_go_auth_service/grpc/client"
+	"upm/udevs_go_auth_service/pkg/access"
+	"upm/udevs_go_auth_service/pkg/contact"
+	"upm/udevs_go_auth_service/pkg/webauthn"
 	"upm/udevs_go_auth_service/storage"
 
 	"github.com/saidamir98/udevs_pkg/security"
 
 	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
 
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 
@@ -37,8 +42,6 @@ func NewSessionService(cfg config.Config, log logger.LoggerI, strg storage.Stora
 }
 
 func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	res := &pb.LoginResponse{}
-
 	if len(req.Username) < 6 {
 		err := errors.New("invalid username")
 		s.log.Error("!!!Login--->", logger.Error(err))
@@ -51,14 +54,20 @@ func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	user, err := s.strg.User().GetByUsername(ctx, req.Username)
+	if len(req.RoleId) > 0 && !util.IsValidUUID(req.RoleId) {
+		err := errors.New("role id is not valid uuid")
+		s.log.Error("!!!Login--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := s.strg.User().GetByUsername(ctx, contact.NormalizeUsername(req.Username, s.cfg.DefaultPhoneRegion))
 	if err != nil {
 		err := errors.New("invalid username or password")
 		s.log.Error("!!!Login--->", logger.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	match, err := security.ComparePassword(user.Password, req.Password)
+	match, err := s.comparePassword(ctx, user.Id, req.Password)
 	if err != nil {
 		s.log.Error("!!!Login--->", logger.Error(err))
 		return nil, status.Error(codes.Internal, err.Error())
@@ -70,28 +79,18 @@ func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if user.Active < 0 {
-		err := errors.New("user is not active")
-		s.log.Error("!!!Login--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
+	return s.login(ctx, user, req.RoleId, pb.LoginStrategies_STANDARD)
+}
 
-	if user.Active == 0 {
-		err := errors.New("user hasn't been activated yet")
-		s.log.Error("!!!Login--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
+// login checks the user state and client login strategy, then opens a new session acting with
+// every active role of the user, or with activeRoleID only when it's given
+func (s *sessionService) login(ctx context.Context, user *pb.User, activeRoleID string, strategies ...pb.LoginStrategies) (*pb.LoginResponse, error) {
+	res := &pb.LoginResponse{}
 
-	expiresAt, err := time.Parse(config.DatabaseTimeLayout, user.ExpiresAt)
+	err := checkUserState(user)
 	if err != nil {
 		s.log.Error("!!!Login--->", logger.Error(err))
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
-	if expiresAt.Unix() < time.Now().Unix() {
-		err := errors.New("user has been expired")
-		s.log.Error("!!!Login--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
 	res.UserFound = true
@@ -106,6 +105,12 @@ func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 	}
 	res.ClientType = clientType
 
+	err = checkVerifiedContact(user, clientType)
+	if err != nil {
+		s.log.Error("!!!Login--->", logger.Error(err))
+		return nil, err
+	}
+
 	clientPlatform, err := s.strg.ClientPlatform().GetByPK(ctx, &pb.ClientPlatformPrimaryKey{
 		Id: user.ClientPlatformId,
 	})
@@ -125,21 +130,57 @@ func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	permissions, err := s.strg.Permission().GetListByRoleId(ctx, user.RoleId)
+	roles, err := s.strg.UserRole().GetActiveRoles(ctx, user.Id)
+	if err != nil {
+		s.log.Error("!!!Login--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	sessionRoleID := user.RoleId
+	if len(activeRoleID) > 0 {
+		roles = filterRoles(roles, activeRoleID)
+		if len(roles) == 0 {
+			err := errors.New("the role isn't assigned to the user")
+			s.log.Error("!!!Login--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		sessionRoleID = activeRoleID
+	}
+
+	res.Roles = roles
+
+	permissions, err := s.strg.Permission().GetListByRoleIds(ctx, getRoleIDs(roles))
 	if err != nil {
 		s.log.Error("!!!Login--->", logger.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	grantedPermissions, err := s.strg.UserPermission().GetActivePermissions(ctx, user.Id)
+	if err != nil {
+		s.log.Error("!!!Login--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	permissions = mergeGrantedPermissions(permissions, grantedPermissions)
+
+	denies, err := s.strg.DenyRule().GetListForSubjects(ctx, user.ClientPlatformId, user.Id, getRoleIDs(roles))
+	if err != nil {
+		s.log.Error("!!!Login--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	permissions = access.FilterDenied(permissions, denies)
+
 	res.Permissions = permissions
 
-	if client.LoginStrategy != pb.LoginStrategies_STANDARD {
+	if !hasLoginStrategy(client.LoginStrategy, strategies) {
 		err := errors.New("incorrect login strategy")
 		s.log.Error("!!!Login--->", logger.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	role, err := s.strg.Role().GetByPK(ctx, &pb.RolePrimaryKey{Id: user.RoleId})
+	role, err := s.strg.Role().GetByPK(ctx, &pb.RolePrimaryKey{Id: sessionRoleID})
 	if err != nil {
 		s.log.Error("!!!Login--->", logger.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -168,10 +209,11 @@ func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 		ClientPlatformId: user.ClientPlatformId,
 		ClientTypeId:     user.ClientTypeId,
 		UserId:           user.Id,
-		RoleId:           user.RoleId,
+		RoleId:           sessionRoleID,
 		Ip:               "0.0.0.0",
 		Data:             "additional json data",
 		ExpiresAt:        time.Now().Add(config.RefreshTokenExpiresInTime).Format(config.DatabaseTimeLayout),
+		ActiveRoleOnly:   len(activeRoleID) > 0,
 	})
 	if err != nil {
 		s.log.Error("!!!Login--->", logger.Error(err))
@@ -184,17 +226,7 @@ func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	// TODO - wrap in a function
-	m := map[string]interface{}{
-		"id":                 session.Id,
-		"project_id":         session.ProjectId,
-		"client_platform_id": session.ClientPlatformId,
-		"client_type_id":     session.ClientTypeId,
-		"user_id":            session.UserId,
-		"role_id":            session.RoleId,
-		"ip":                 session.Data,
-		"data":               session.Data,
-	}
+	m := sessionClaims(session, getRoleIDs(roles))
 
 	accessToken, err := security.GenerateJWT(m, config.AccessTokenExpiresInTime, s.cfg.SecretKey)
 	if err != nil {
@@ -220,6 +252,16 @@ func (s *sessionService) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 	return res, nil
 }
 
+func hasLoginStrategy(strategy pb.LoginStrategies, strategies []pb.LoginStrategies) bool {
+	for _, v := range strategies {
+		if v == strategy {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *sessionService) Logout(ctx context.Context, req *pb.LogoutRequest) (*emptypb.Empty, error) {
 	tokenInfo, err := security.ParseClaims(req.AccessToken, s.cfg.SecretKey)
 	if err != nil {
@@ -260,41 +302,19 @@ func (s *sessionService) RefreshToken(ctx context.Context, req *pb.RefreshTokenR
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if user.Active < 0 {
-		err := errors.New("user is not active")
-		s.log.Error("!!!RefreshToken--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-
-	if user.Active == 0 {
-		err := errors.New("user hasn't been activated yet")
+	err = checkUserState(user)
+	if err != nil {
 		s.log.Error("!!!RefreshToken--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
-	expiresAt, err := time.Parse(config.DatabaseTimeLayout, user.ExpiresAt)
+	roleIDs, err := s.sessionRoleIDs(ctx, session)
 	if err != nil {
 		s.log.Error("!!!RefreshToken--->", logger.Error(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	if expiresAt.Unix() < time.Now().Unix() {
-		err := errors.New("user has been expired")
-		s.log.Error("!!!RefreshToken--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-
-	// TODO - wrap in a function
-	m := map[string]interface{}{
-		"id":                 session.Id,
-		"project_id":         session.ProjectId,
-		"client_platform_id": session.ClientPlatformId,
-		"client_type_id":     session.ClientTypeId,
-		"user_id":            session.UserId,
-		"role_id":            session.RoleId,
-		"ip":                 session.Data,
-		"data":               session.Data,
-	}
+	m := sessionClaims(session, roleIDs)
 
 	accessToken, err := security.GenerateJWT(m, config.AccessTokenExpiresInTime, s.cfg.SecretKey)
 	if err != nil {
@@ -321,6 +341,11 @@ func (s *sessionService) RefreshToken(ctx context.Context, req *pb.RefreshTokenR
 }
 
 func (s *sessionService) HasAccess(ctx context.Context, req *pb.HasAccessRequest) (*pb.HasAccessResponse, error) {
+	if len(req.RelationId) > 0 && !util.IsValidUUID(req.RelationId) {
+		err := errors.New("relation id is not valid uuid")
+		s.log.Error("!!!HasAccess--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	tokenInfo, err := security.ParseClaims(req.AccessToken, s.cfg.SecretKey)
 	if err != nil {
@@ -340,53 +365,61 @@ func (s *sessionService) HasAccess(ctx context.Context, req *pb.HasAccessRequest
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if user.Active < 0 {
-		err := errors.New("user is not active")
-		s.log.Error("!!!HasAccess--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-
-	if user.Active == 0 {
-		err := errors.New("user hasn't been activated yet")
+	err = checkUserState(user)
+	if err != nil {
 		s.log.Error("!!!HasAccess--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
-	expiresAt, err := time.Parse(config.DatabaseTimeLayout, user.ExpiresAt)
+	_, err = s.strg.Scope().Upsert(ctx, &pb.UpsertScopeRequest{
+		ClientPlatformId: req.ClientPlatformId,
+		Path:             req.Path,
+		Method:           req.Method,
+	})
 	if err != nil {
 		s.log.Error("!!!HasAccess--->", logger.Error(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	if expiresAt.Unix() < time.Now().Unix() {
-		err := errors.New("user has been expired")
+	roleIDs, err := s.sessionRoleIDs(ctx, session)
+	if err != nil {
 		s.log.Error("!!!HasAccess--->", logger.Error(err))
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	_, err = s.strg.Scope().Upsert(ctx, &pb.UpsertScopeRequest{
-		ClientPlatformId: req.ClientPlatformId,
-		Path:             req.Path,
-		Method:           req.Method,
-	})
+	policy, err := s.strg.PermissionScope().GetAccessPolicy(ctx, session.UserId, roleIDs, req.ClientPlatformId, req.Path, req.Method)
 	if err != nil {
 		s.log.Error("!!!HasAccess--->", logger.Error(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	// DONT FORGET TO UNCOMMENT THIS!!!
-
-	// hasAccess, err := s.strg.PermissionScope().HasAccess(ctx, user.RoleId, req.ClientPlatformId, req.Path, req.Method)
-	// if err != nil {
-	// 	s.log.Error("!!!HasAccess--->", logger.Error(err))
-	// 	return nil, status.Error(codes.InvalidArgument, err.Error())
-	// }
+	// deny rules first, then permissions of the roles, inherited ones included, and grants to the user
+	decision := access.Evaluate(policy, roleIDs, req.Method, req.Path, req.RelationId)
+	if !decision.Allowed {
+		err = errors.New("access denied")
+		if decision.DenyRule != nil {
+			s.log.Error("!!!HasAccess--->", logger.Error(err), logger.String("deny_rule_id", decision.DenyRule.Id))
+		} else {
+			s.log.Error("!!!HasAccess--->", logger.Error(err))
+		}
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
 
-	// if !hasAccess {
-	// 	err = errors.New("access denied")
-	// 	s.log.Error("!!!HasAccess--->", logger.Error(err))
-	// 	return nil, status.Error(codes.InvalidArgument, err.Error())
-	// }
+	// only the permission allowing the request decides whether a recent authentication is required
+	recentAuthMinutes := policy.RecentAuthMinutes[decision.PermissionID]
+	if recentAuthMinutes > 0 {
+		lastAuthAt, err := time.Parse(config.DatabaseTimeLayout, session.LastAuthAt)
+		if err != nil {
+			s.log.Error("!!!HasAccess--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if time.Since(lastAuthAt) > time.Duration(recentAuthMinutes)*time.Minute {
+			err := errors.New("step-up authentication required")
+			s.log.Error("!!!HasAccess--->", logger.Error(err))
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+	}
 
 	return &pb.HasAccessResponse{
 		Id:               session.Id,
@@ -400,5 +433,663 @@ func (s *sessionService) HasAccess(ctx context.Context, req *pb.HasAccessRequest
 		ExpiresAt:        session.ExpiresAt,
 		CreatedAt:        session.CreatedAt,
 		UpdatedAt:        session.UpdatedAt,
+		LastAuthAt:       session.LastAuthAt,
+		RoleIds:          roleIDs,
+		RelationIds:      policy.UserRelations,
+		RelationScoped:   decision.RelationScoped,
 	}, nil
 }
+
+func (s *sessionService) SendReauthPasscode(ctx context.Context, req *pb.SendReauthPasscodeRequest) (*pb.SendReauthPasscodeResponse, error) {
+	session, err := s.getSessionByAccessToken(ctx, req.AccessToken)
+	if err != nil {
+		s.log.Error("!!!SendReauthPasscode--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: session.UserId})
+	if err != nil {
+		s.log.Error("!!!SendReauthPasscode--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	clientType, err := s.strg.ClientType().GetByPK(ctx, &pb.ClientTypePrimaryKey{
+		Id: session.ClientTypeId,
+	})
+	if err != nil {
+		s.log.Error("!!!SendReauthPasscode--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	confirmBy := clientType.ConfirmBy
+	if confirmBy == pb.ConfirmStrategies_UNDECIDED {
+		confirmBy = pb.ConfirmStrategies_EMAIL
+	}
+
+	target := user.Email
+	if confirmBy == pb.ConfirmStrategies_PHONE {
+		target = user.Phone
+	}
+
+	if len(target) == 0 || !canSendPasscode(s.cfg, confirmBy) {
+		err := errors.New("passcode can't be delivered to the user, use password instead")
+		s.log.Error("!!!SendReauthPasscode--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	passcode, err := sendPasscode(ctx, s.strg, s.cfg, &pb.CreatePasscodeRequest{
+		ProjectId:        session.ProjectId,
+		ClientPlatformId: session.ClientPlatformId,
+		ClientTypeId:     session.ClientTypeId,
+		UserId:           session.UserId,
+		ConfirmBy:        confirmBy,
+		Purpose:          config.PasscodePurposeReauth,
+		Target:           target,
+	})
+	if err != nil {
+		s.log.Error("!!!SendReauthPasscode--->", logger.Error(err))
+		return nil, err
+	}
+
+	return &pb.SendReauthPasscodeResponse{
+		PasscodeId: passcode.Id,
+		ConfirmBy:  confirmBy,
+		ExpiresAt:  passcode.ExpiresAt,
+	}, nil
+}
+
+// Reauthenticate refreshes last_auth_at of the current session, no new session is created
+func (s *sessionService) Reauthenticate(ctx context.Context, req *pb.ReauthenticateRequest) (*pb.Session, error) {
+	session, err := s.getSessionByAccessToken(ctx, req.AccessToken)
+	if err != nil {
+		s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: session.UserId})
+	if err != nil {
+		s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	switch {
+	case len(req.Password) > 0:
+		match, err := s.comparePassword(ctx, user.Id, req.Password)
+		if err != nil {
+			s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if !match {
+			err := errors.New("username or password is wrong")
+			s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	case len(req.PasscodeId) > 0:
+		_, err = usePasscode(ctx, s.strg, req.PasscodeId, req.Passcode, user.Id, config.PasscodePurposeReauth)
+		if err != nil {
+			s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	default:
+		err := errors.New("password or passcode is required")
+		s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rowsAffected, err := s.strg.Session().UpdateLastAuthAt(ctx, &pb.SessionPrimaryKey{Id: session.Id})
+	if err != nil {
+		s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	session, err = s.strg.Session().GetByPK(ctx, &pb.SessionPrimaryKey{Id: session.Id})
+	if err != nil {
+		s.log.Error("!!!Reauthenticate--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return session, nil
+}
+
+// GetRevokedSessionList returns one page of the feed. A revocation may commit after ones with higher
+// sequence numbers, so pollers should ask from a little before the last_seq they got and skip known ids
+func (s *sessionService) GetRevokedSessionList(ctx context.Context, req *pb.GetRevokedSessionListRequest) (*pb.GetRevokedSessionListResponse, error) {
+	res, err := s.strg.RevokedSession().GetList(ctx, req)
+	if err != nil {
+		s.log.Error("!!!GetRevokedSessionList--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+// WatchRevokedSessions sends the revocations after the given sequence number first,
+// then keeps polling for new ones until the client goes away.
+// Sequence numbers are taken on insert but become visible on commit, so every poll reads the last
+// config.RevocationFeedLookback of them again and sends the late ones only. Right after subscribing
+// that window is sent whole, a client may receive revocations it already has
+func (s *sessionService) WatchRevokedSessions(req *pb.WatchRevokedSessionsRequest, stream pb.SessionService_WatchRevokedSessionsServer) error {
+	s.log.Info("---WatchRevokedSessions--->", logger.Any("req", req))
+
+	ctx := stream.Context()
+	lastSeq := req.AfterSeq
+	sent := map[int64]bool{}
+
+	ticker := time.NewTicker(config.RevocationFeedPollInterval)
+	defer ticker.Stop()
+
+	for {
+		res, err := s.strg.RevokedSession().GetList(ctx, &pb.GetRevokedSessionListRequest{
+			AfterSeq: lastSeq - config.RevocationFeedLookback,
+			Limit:    config.RevokedSessionListLimit,
+		})
+		if err != nil {
+			s.log.Error("!!!WatchRevokedSessions--->", logger.Error(err))
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		for _, revokedSession := range res.RevokedSessions {
+			if sent[revokedSession.Seq] {
+				continue
+			}
+
+			if err := stream.Send(revokedSession); err != nil {
+				s.log.Error("!!!WatchRevokedSessions--->", logger.Error(err))
+				return err
+			}
+			sent[revokedSession.Seq] = true
+		}
+
+		if res.LastSeq > lastSeq {
+			lastSeq = res.LastSeq
+		}
+
+		for seq := range sent {
+			if seq <= lastSeq-config.RevocationFeedLookback {
+				delete(sent, seq)
+			}
+		}
+
+		// a full page means there is more to catch up on, don't wait for the ticker
+		if int32(len(res.RevokedSessions)) == config.RevokedSessionListLimit {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *sessionService) BeginPasskeyRegistration(ctx context.Context, req *pb.BeginPasskeyRegistrationRequest) (*pb.BeginPasskeyRegistrationResponse, error) {
+	user, err := s.getUserByAccessToken(ctx, req.AccessToken)
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	clientPlatform, err := s.strg.ClientPlatform().GetByPK(ctx, &pb.ClientPlatformPrimaryKey{
+		Id: user.ClientPlatformId,
+	})
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rpID, err := webauthn.RPIDFromSubdomain(clientPlatform.Subdomain)
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pKey, err := s.strg.PasskeyChallenge().Create(ctx, &pb.CreatePasskeyChallengeRequest{
+		ClientPlatformId: clientPlatform.Id,
+		UserId:           user.Id,
+		Ceremony:         config.PasskeyCeremonyRegistration,
+		Challenge:        challenge,
+		RpId:             rpID,
+		ExpiresAt:        time.Now().Add(config.PasskeyChallengeExpiresInTime).Format(config.DatabaseTimeLayout),
+	})
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	credentials, err := s.strg.PasskeyCredential().GetListByUserID(ctx, user.Id)
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &pb.BeginPasskeyRegistrationResponse{
+		ChallengeId:     pKey.Id,
+		Challenge:       challenge,
+		RpId:            rpID,
+		RpName:          clientPlatform.Name,
+		UserId:          webauthn.EncodeToString([]byte(user.Id)),
+		UserName:        user.Login,
+		UserDisplayName: user.Name,
+		TimeoutSeconds:  int32(config.PasskeyChallengeExpiresInTime.Seconds()),
+	}
+
+	for _, v := range credentials {
+		res.ExcludeCredentialIds = append(res.ExcludeCredentialIds, v.CredentialId)
+	}
+
+	return res, nil
+}
+
+func (s *sessionService) FinishPasskeyRegistration(ctx context.Context, req *pb.FinishPasskeyRegistrationRequest) (*pb.PasskeyCredential, error) {
+	user, err := s.getUserByAccessToken(ctx, req.AccessToken)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	challenge, err := s.usePasskeyChallenge(ctx, req.ChallengeId, config.PasskeyCeremonyRegistration)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if challenge.UserId != user.Id {
+		err := errors.New("challenge was issued for another user")
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	credentialID, err := webauthn.DecodeString(req.CredentialId)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	clientDataJSON, err := webauthn.DecodeString(req.ClientDataJson)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rawAuthData, err := webauthn.DecodeString(req.AuthenticatorData)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	publicKey, err := webauthn.DecodeString(req.PublicKey)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = webauthn.ParseClientData(clientDataJSON, webauthn.CeremonyCreate, challenge.Challenge, challenge.RpId)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	authData, err := webauthn.ParseAuthenticatorData(rawAuthData, challenge.RpId)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if !bytes.Equal(authData.CredentialID, credentialID) {
+		err := errors.New("credential id doesn't match authenticator data")
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = webauthn.VerifyPublicKey(publicKey)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pKey, err := s.strg.PasskeyCredential().Create(ctx, &pb.CreatePasskeyCredentialRequest{
+		UserId:             user.Id,
+		CredentialId:       webauthn.EncodeToString(credentialID),
+		Name:               req.Name,
+		PublicKey:          publicKey,
+		PublicKeyAlgorithm: req.PublicKeyAlgorithm,
+		SignCount:          int64(authData.SignCount),
+		Transports:         req.Transports,
+		Aaguid:             authData.AAGUIDString(),
+	})
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := s.strg.PasskeyCredential().GetByPK(ctx, pKey)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyRegistration--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res.PublicKey = nil
+
+	return res, nil
+}
+
+func (s *sessionService) BeginPasskeyLogin(ctx context.Context, req *pb.BeginPasskeyLoginRequest) (*pb.BeginPasskeyLoginResponse, error) {
+	clientPlatform, err := s.strg.ClientPlatform().GetByPK(ctx, &pb.ClientPlatformPrimaryKey{
+		Id: req.ClientPlatformId,
+	})
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rpID, err := webauthn.RPIDFromSubdomain(clientPlatform.Subdomain)
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	res := &pb.BeginPasskeyLoginResponse{
+		RpId:           rpID,
+		TimeoutSeconds: int32(config.PasskeyChallengeExpiresInTime.Seconds()),
+	}
+
+	// without a username the browser offers discoverable credentials
+	var userID string
+	if len(req.Username) > 0 {
+		user, err := s.strg.User().GetByUsername(ctx, contact.NormalizeUsername(req.Username, s.cfg.DefaultPhoneRegion))
+		if err != nil || user.ClientPlatformId != clientPlatform.Id {
+			err := errors.New("invalid username")
+			s.log.Error("!!!BeginPasskeyLogin--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		userID = user.Id
+
+		credentials, err := s.strg.PasskeyCredential().GetListByUserID(ctx, user.Id)
+		if err != nil {
+			s.log.Error("!!!BeginPasskeyLogin--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		for _, v := range credentials {
+			res.AllowCredentialIds = append(res.AllowCredentialIds, v.CredentialId)
+		}
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pKey, err := s.strg.PasskeyChallenge().Create(ctx, &pb.CreatePasskeyChallengeRequest{
+		ClientPlatformId: clientPlatform.Id,
+		UserId:           userID,
+		Ceremony:         config.PasskeyCeremonyLogin,
+		Challenge:        challenge,
+		RpId:             rpID,
+		ExpiresAt:        time.Now().Add(config.PasskeyChallengeExpiresInTime).Format(config.DatabaseTimeLayout),
+	})
+	if err != nil {
+		s.log.Error("!!!BeginPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res.ChallengeId = pKey.Id
+	res.Challenge = challenge
+
+	return res, nil
+}
+
+// FinishPasskeyLogin verifies an assertion. Alone it is accepted by PASSKEY clients,
+// together with the password it also satisfies STANDARD_PASSKEY clients
+func (s *sessionService) FinishPasskeyLogin(ctx context.Context, req *pb.FinishPasskeyLoginRequest) (*pb.LoginResponse, error) {
+	challenge, err := s.usePasskeyChallenge(ctx, req.ChallengeId, config.PasskeyCeremonyLogin)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// credential ids are stored base64url encoded without padding
+	credentialID, err := webauthn.DecodeString(req.CredentialId)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	credential, err := s.strg.PasskeyCredential().GetByCredentialID(ctx, webauthn.EncodeToString(credentialID))
+	if err != nil {
+		err := errors.New("unknown credential")
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(challenge.UserId) > 0 && challenge.UserId != credential.UserId {
+		err := errors.New("credential doesn't belong to the user")
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(req.UserHandle) > 0 {
+		userHandle, err := webauthn.DecodeString(req.UserHandle)
+		if err != nil || string(userHandle) != credential.UserId {
+			err := errors.New("user handle doesn't match the credential")
+			s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	clientDataJSON, err := webauthn.DecodeString(req.ClientDataJson)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rawAuthData, err := webauthn.DecodeString(req.AuthenticatorData)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	signature, err := webauthn.DecodeString(req.Signature)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, err = webauthn.ParseClientData(clientDataJSON, webauthn.CeremonyGet, challenge.Challenge, challenge.RpId)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	authData, err := webauthn.ParseAuthenticatorData(rawAuthData, challenge.RpId)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// a passkey replaces the password, so presence alone is not enough
+	if !authData.UserVerified() {
+		err := webauthn.ErrUserNotVerified
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = webauthn.VerifySignature(credential.PublicKey, rawAuthData, clientDataJSON, signature)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = webauthn.VerifySignCount(credential.SignCount, authData.SignCount)
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	rowsAffected, err := s.strg.PasskeyCredential().UpdateSignCount(ctx, &pb.PasskeyCredentialPrimaryKey{Id: credential.Id}, int64(authData.SignCount))
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// a concurrent assertion with the same or a higher counter got in first
+	if rowsAffected <= 0 {
+		err := webauthn.ErrSignCountRollback
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: credential.UserId})
+	if err != nil {
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if user.ClientPlatformId != challenge.ClientPlatformId {
+		err := errors.New("credential doesn't belong to the client platform")
+		s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	strategies := []pb.LoginStrategies{pb.LoginStrategies_PASSKEY}
+
+	if len(req.Password) > 0 {
+		match, err := s.comparePassword(ctx, user.Id, req.Password)
+		if err != nil {
+			s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if !match {
+			err := errors.New("username or password is wrong")
+			s.log.Error("!!!FinishPasskeyLogin--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		strategies = append(strategies, pb.LoginStrategies_STANDARD_PASSKEY)
+	}
+
+	return s.login(ctx, user, "", strategies...)
+}
+
+func (s *sessionService) GetPasskeyList(ctx context.Context, req *pb.GetPasskeyListRequest) (*pb.GetPasskeyListResponse, error) {
+	user, err := s.getUserByAccessToken(ctx, req.AccessToken)
+	if err != nil {
+		s.log.Error("!!!GetPasskeyList--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	credentials, err := s.strg.PasskeyCredential().GetListByUserID(ctx, user.Id)
+	if err != nil {
+		s.log.Error("!!!GetPasskeyList--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetPasskeyListResponse{
+		Credentials: credentials,
+	}, nil
+}
+
+func (s *sessionService) RemovePasskey(ctx context.Context, req *pb.RemovePasskeyRequest) (*emptypb.Empty, error) {
+	user, err := s.getUserByAccessToken(ctx, req.AccessToken)
+	if err != nil {
+		s.log.Error("!!!RemovePasskey--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	credential, err := s.strg.PasskeyCredential().GetByPK(ctx, &pb.PasskeyCredentialPrimaryKey{Id: req.Id})
+	if err != nil || credential.UserId != user.Id {
+		err := errors.New("passkey not found")
+		s.log.Error("!!!RemovePasskey--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	rowsAffected, err := s.strg.PasskeyCredential().Delete(ctx, &pb.PasskeyCredentialPrimaryKey{Id: credential.Id})
+	if err != nil {
+		s.log.Error("!!!RemovePasskey--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *sessionService) getSessionByAccessToken(ctx context.Context, accessToken string) (*pb.Session, error) {
+	tokenInfo, err := security.ParseClaims(accessToken, s.cfg.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.strg.Session().GetByPK(ctx, &pb.SessionPrimaryKey{Id: tokenInfo.ID})
+}
+
+func (s *sessionService) getUserByAccessToken(ctx context.Context, accessToken string) (*pb.User, error) {
+	session, err := s.getSessionByAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: session.UserId})
+}
+
+// comparePassword reads the hash through the credential lookup, it is the only place
+// the hash is loaded so it can't leak into user messages
+func (s *sessionService) comparePassword(ctx context.Context, userID, password string) (bool, error) {
+	hash, err := s.strg.User().GetPasswordHash(ctx, &pb.UserPrimaryKey{Id: userID})
+	if err != nil {
+		return false, err
+	}
+
+	if len(hash) == 0 {
+		return false, nil
+	}
+
+	return security.ComparePassword(hash, password)
+}
+
+// usePasskeyChallenge loads a challenge and deletes it right away, so it can't be replayed
+func (s *sessionService) usePasskeyChallenge(ctx context.Context, id, ceremony string) (*pb.PasskeyChallenge, error) {
+	challenge, err := s.strg.PasskeyChallenge().GetByPK(ctx, &pb.PasskeyChallengePrimaryKey{Id: id})
+	if err != nil {
+		return nil, errors.New("invalid challenge")
+	}
+
+	rowsAffected, err := s.strg.PasskeyChallenge().Delete(ctx, &pb.PasskeyChallengePrimaryKey{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected <= 0 {
+		return nil, errors.New("challenge has already been used")
+	}
+
+	if challenge.Ceremony != ceremony {
+		return nil, errors.New("invalid challenge")
+	}
+
+	expiresAt, err := time.Parse(config.DatabaseTimeLayout, challenge.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Unix() < time.Now().Unix() {
+		return nil, errors.New("challenge has been expired")
+	}
+
+	return challenge, nil
+}
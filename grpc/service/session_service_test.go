@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/access"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/security"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accessStorage is the part of the storage HasAccess reads, the rest of StorageI is left nil
+type accessStorage struct {
+	storage.StorageI
+	sessions map[string]*pb.Session
+	users    map[string]*pb.User
+	roles    []*pb.Role
+	policy   *access.Policy
+	err      error
+
+	policyRequest []interface{}
+}
+
+func (s *accessStorage) Session() storage.SessionRepoI {
+	return accessSessionRepo{strg: s}
+}
+
+func (s *accessStorage) User() storage.UserRepoI {
+	return accessUserRepo{strg: s}
+}
+
+func (s *accessStorage) Scope() storage.ScopeRepoI {
+	return accessScopeRepo{}
+}
+
+func (s *accessStorage) UserRole() storage.UserRoleRepoI {
+	return accessUserRoleRepo{strg: s}
+}
+
+func (s *accessStorage) PermissionScope() storage.PermissionScopeRepoI {
+	return accessPermissionScopeRepo{strg: s}
+}
+
+type accessSessionRepo struct {
+	storage.SessionRepoI
+	strg *accessStorage
+}
+
+func (r accessSessionRepo) GetByPK(ctx context.Context, pKey *pb.SessionPrimaryKey) (*pb.Session, error) {
+	session, ok := r.strg.sessions[pKey.Id]
+	if !ok {
+		return nil, errors.New("no rows in result set")
+	}
+
+	return session, nil
+}
+
+type accessUserRepo struct {
+	storage.UserRepoI
+	strg *accessStorage
+}
+
+func (r accessUserRepo) GetByPK(ctx context.Context, pKey *pb.UserPrimaryKey) (*pb.User, error) {
+	user, ok := r.strg.users[pKey.Id]
+	if !ok {
+		return nil, errors.New("no rows in result set")
+	}
+
+	return user, nil
+}
+
+type accessScopeRepo struct {
+	storage.ScopeRepoI
+}
+
+func (accessScopeRepo) Upsert(ctx context.Context, entity *pb.UpsertScopeRequest) (*pb.ScopePrimaryKey, error) {
+	return &pb.ScopePrimaryKey{ClientPlatformId: entity.ClientPlatformId, Path: entity.Path, Method: entity.Method}, nil
+}
+
+type accessUserRoleRepo struct {
+	storage.UserRoleRepoI
+	strg *accessStorage
+}
+
+func (r accessUserRoleRepo) GetActiveRoles(ctx context.Context, userID string) ([]*pb.Role, error) {
+	return r.strg.roles, nil
+}
+
+type accessPermissionScopeRepo struct {
+	storage.PermissionScopeRepoI
+	strg *accessStorage
+}
+
+func (r accessPermissionScopeRepo) GetAccessPolicy(ctx context.Context, userID string, roleIDs []string, clientPlatformID, path, method string) (*access.Policy, error) {
+	r.strg.policyRequest = []interface{}{userID, roleIDs, clientPlatformID, path, method}
+
+	return r.strg.policy, r.strg.err
+}
+
+func TestHasAccess(t *testing.T) {
+	cfg := config.Config{SecretKey: "secret"}
+	now := time.Now()
+
+	cases := []struct {
+		name   string
+		token  string
+		change func(strg *accessStorage)
+		code   codes.Code
+	}{
+		{
+			name: "allowed by a permission of a role of the user",
+		},
+		{
+			name:  "a token of another key is rejected",
+			token: "other",
+			code:  codes.InvalidArgument,
+		},
+		{
+			name: "a token of a deleted session is rejected",
+			change: func(strg *accessStorage) {
+				delete(strg.sessions, "session")
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "a locked user is rejected",
+			change: func(strg *accessStorage) {
+				strg.users["user"].State = pb.UserStates_LOCKED
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "denied without a permission with the scope",
+			change: func(strg *accessStorage) {
+				strg.roles = []*pb.Role{{Id: "guest"}}
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "a session acting with its role only loses the other roles",
+			change: func(strg *accessStorage) {
+				strg.roles = []*pb.Role{{Id: "guest"}, {Id: "employee"}}
+				strg.sessions["session"].RoleId = "guest"
+				strg.sessions["session"].ActiveRoleOnly = true
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "denied by a deny rule",
+			change: func(strg *accessStorage) {
+				strg.policy.Denies = []*pb.DenyRule{{Id: "deny", UserId: "user", PermissionId: "read"}}
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "a step-up is required once the authentication isn't recent",
+			change: func(strg *accessStorage) {
+				strg.policy.RecentAuthMinutes = map[string]int32{"read": 5}
+				strg.sessions["session"].LastAuthAt = now.Add(-10 * time.Minute).Format(config.DatabaseTimeLayout)
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "a recent authentication passes the step-up",
+			change: func(strg *accessStorage) {
+				strg.policy.RecentAuthMinutes = map[string]int32{"read": 5}
+				strg.sessions["session"].LastAuthAt = now.Add(-time.Minute).Format(config.DatabaseTimeLayout)
+			},
+		},
+		{
+			name: "the step-up of a permission that doesn't allow the request doesn't apply",
+			change: func(strg *accessStorage) {
+				strg.policy.RecentAuthMinutes = map[string]int32{"audit": 5}
+				strg.sessions["session"].LastAuthAt = now.Add(-10 * time.Minute).Format(config.DatabaseTimeLayout)
+			},
+		},
+		{
+			name: "a storage failure is internal",
+			change: func(strg *accessStorage) {
+				strg.err = errors.New("connection refused")
+			},
+			code: codes.Internal,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			strg := &accessStorage{
+				sessions: map[string]*pb.Session{
+					"session": {
+						Id:               "session",
+						ClientPlatformId: "platform",
+						UserId:           "user",
+						RoleId:           "employee",
+						LastAuthAt:       now.Format(config.DatabaseTimeLayout),
+					},
+				},
+				users: map[string]*pb.User{
+					"user": {
+						Id:        "user",
+						State:     pb.UserStates_ACTIVE,
+						ExpiresAt: now.Add(time.Hour).Format(config.DatabaseTimeLayout),
+					},
+				},
+				roles: []*pb.Role{{Id: "employee"}},
+				policy: &access.Policy{
+					ScopePermissions: []string{"read", "audit"},
+					RolePermissions: map[string][]string{
+						"employee": {"read"},
+						"auditor":  {"audit"},
+					},
+				},
+			}
+			if c.change != nil {
+				c.change(strg)
+			}
+
+			secretKey := cfg.SecretKey
+			if len(c.token) > 0 {
+				secretKey = c.token
+			}
+
+			token, err := security.GenerateJWT(map[string]interface{}{"id": "session"}, time.Hour, secretKey)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			s := NewSessionService(cfg, logger.NewLogger("test", logger.LevelDebug), strg, nil)
+
+			res, err := s.HasAccess(context.Background(), &pb.HasAccessRequest{
+				AccessToken:      token,
+				ClientPlatformId: "platform",
+				Path:             "/reports/2022/summary",
+				Method:           "GET",
+			})
+
+			assert.Equal(t, c.code, status.Code(err), err)
+			if c.code != codes.OK {
+				return
+			}
+
+			assert.Equal(t, []interface{}{"user", []string{"employee"}, "platform", "/reports/2022/summary", "GET"}, strg.policyRequest)
+			if assert.NotNil(t, res) {
+				assert.Equal(t, "session", res.Id)
+				assert.Equal(t, "user", res.UserId)
+				assert.Equal(t, []string{"employee"}, res.RoleIds)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SendContactVerification sends a passcode to the contact, VerifyContact marks it verified with the code
+func (s *userService) SendContactVerification(ctx context.Context, req *pb.SendContactVerificationRequest) (*pb.SendContactVerificationResponse, error) {
+	s.log.Info("---SendContactVerification--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.UserId) {
+		return nil, status.Error(codes.InvalidArgument, "user id is an invalid uuid")
+	}
+
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err != nil {
+		s.log.Error("!!!SendContactVerification--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if len(user.DeletedAt) > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "user has been deleted")
+	}
+
+	var target, verifiedAt string
+	switch req.Contact {
+	case pb.ConfirmStrategies_EMAIL:
+		target, verifiedAt = user.Email, user.EmailVerifiedAt
+	case pb.ConfirmStrategies_PHONE:
+		target, verifiedAt = user.Phone, user.PhoneVerifiedAt
+	default:
+		return nil, status.Error(codes.InvalidArgument, "contact must be EMAIL or PHONE")
+	}
+
+	if len(target) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "user has no %s", req.Contact)
+	}
+
+	if len(verifiedAt) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s has already been verified", req.Contact)
+	}
+
+	passcode, err := sendPasscode(ctx, s.strg, s.cfg, &pb.CreatePasscodeRequest{
+		ProjectId:        user.ProjectId,
+		ClientPlatformId: user.ClientPlatformId,
+		ClientTypeId:     user.ClientTypeId,
+		UserId:           user.Id,
+		ConfirmBy:        req.Contact,
+		Purpose:          config.PasscodePurposeVerifyContact,
+		Target:           target,
+	})
+	if err != nil {
+		s.log.Error("!!!SendContactVerification--->", logger.Error(err))
+		return nil, err
+	}
+
+	return &pb.SendContactVerificationResponse{
+		PasscodeId: passcode.Id,
+		ConfirmBy:  req.Contact,
+		ExpiresAt:  passcode.ExpiresAt,
+	}, nil
+}
+
+func (s *userService) VerifyContact(ctx context.Context, req *pb.VerifyContactRequest) (*pb.User, error) {
+	s.log.Info("---VerifyContact--->", logger.Any("user_id", req.UserId), logger.Any("passcode_id", req.PasscodeId))
+
+	passcode, err := usePasscode(ctx, s.strg, req.PasscodeId, req.Passcode, req.UserId, config.PasscodePurposeVerifyContact)
+	if err != nil {
+		s.log.Error("!!!VerifyContact--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rowsAffected, err := s.strg.User().SetContactVerified(ctx, req.UserId, passcode.ConfirmBy, passcode.Target)
+	if err != nil {
+		s.log.Error("!!!VerifyContact--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s has been changed since the passcode was sent", passcode.ConfirmBy)
+	}
+
+	res, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err != nil {
+		s.log.Error("!!!VerifyContact--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return res, nil
+}
+
+// checkVerifiedContact enforces require_verified_contact of the client type at login,
+// the contact is the one of confirm_by and either one if it's undecided
+func checkVerifiedContact(user *pb.User, clientType *pb.ClientType) error {
+	if !clientType.RequireVerifiedContact {
+		return nil
+	}
+
+	switch clientType.ConfirmBy {
+	case pb.ConfirmStrategies_EMAIL:
+		if len(user.EmailVerifiedAt) == 0 {
+			return status.Error(codes.FailedPrecondition, "email has to be verified before login")
+		}
+	case pb.ConfirmStrategies_PHONE:
+		if len(user.PhoneVerifiedAt) == 0 {
+			return status.Error(codes.FailedPrecondition, "phone has to be verified before login")
+		}
+	default:
+		if len(user.EmailVerifiedAt) == 0 && len(user.PhoneVerifiedAt) == 0 {
+			return status.Error(codes.FailedPrecondition, "email or phone has to be verified before login")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	DataSubjectActionExport  = "EXPORT"
+	DataSubjectActionErasure = "ERASURE"
+)
+
+// ExportUserData assembles everything stored about the user into one json document
+func (s *userService) ExportUserData(ctx context.Context, req *pb.DataSubjectRequest) (*pb.ExportUserDataResponse, error) {
+	s.log.Info("---ExportUserData--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.UserId) {
+		return nil, status.Error(codes.InvalidArgument, "user id is an invalid uuid")
+	}
+
+	err := s.checkReceiptSigningKey()
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, err
+	}
+
+	// an erased user is still read, its contacts are NULL and come back empty, so a repeated request works
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err == pgx.ErrNoRows {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	data := &pb.UserDataExport{
+		User:       user,
+		ExportedAt: time.Now().Format(config.DatabaseTimeLayout),
+	}
+
+	// a user without user info has no row for it
+	userInfo, err := s.strg.UserInfo().GetByPK(ctx, &pb.UserInfoPrimaryKey{UserId: req.UserId})
+	if err != nil && err != pgx.ErrNoRows {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err == nil {
+		data.UserInfo = userInfo.Data
+	}
+
+	data.Relations, err = s.strg.UserRelation().GetListByUserID(ctx, req.UserId)
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	sessions, err := s.strg.Session().GetSessionListByUserID(ctx, req.UserId)
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	data.Sessions = sessions.Sessions
+
+	data.Passcodes, err = s.strg.Passcode().GetListByUserID(ctx, req.UserId)
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	history, err := s.strg.User().GetStateHistory(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	data.StateHistory = history.History
+
+	data.Passkeys, err = s.strg.PasskeyCredential().GetListByUserID(ctx, req.UserId)
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	document, err := protojson.Marshal(data)
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	receipt, err := s.createReceipt(ctx, DataSubjectActionExport, req, document)
+	if err != nil {
+		s.log.Error("!!!ExportUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ExportUserDataResponse{
+		Document: document,
+		Receipt:  receipt,
+	}, nil
+}
+
+// EraseUserData anonymizes the user at once, the user row, its state history and the receipts are kept
+// so references and the audit trail stay intact
+func (s *userService) EraseUserData(ctx context.Context, req *pb.DataSubjectRequest) (*pb.EraseUserDataResponse, error) {
+	s.log.Info("---EraseUserData--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.UserId) {
+		return nil, status.Error(codes.InvalidArgument, "user id is an invalid uuid")
+	}
+
+	err := s.checkReceiptSigningKey()
+	if err != nil {
+		s.log.Error("!!!EraseUserData--->", logger.Error(err))
+		return nil, err
+	}
+
+	// an erased user is still read, its contacts are NULL and come back empty, so a repeated request works
+	_, err = s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err == pgx.ErrNoRows {
+		s.log.Error("!!!EraseUserData--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		s.log.Error("!!!EraseUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	erased, err := s.strg.User().Erase(ctx, req)
+	if err != nil {
+		s.log.Error("!!!EraseUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// keys of the map are sorted by encoding/json, so the summary is the same for the same counts
+	summary, err := json.Marshal(erased)
+	if err != nil {
+		s.log.Error("!!!EraseUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	receipt, err := s.createReceipt(ctx, DataSubjectActionErasure, req, summary)
+	if err != nil {
+		s.log.Error("!!!EraseUserData--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.EraseUserDataResponse{
+		Erased:  erased,
+		Receipt: receipt,
+	}, nil
+}
+
+// VerifyDataSubjectReceipt checks the signature of the receipt and that it was issued by the service
+func (s *userService) VerifyDataSubjectReceipt(ctx context.Context, req *pb.DataSubjectReceipt) (*pb.VerifyDataSubjectReceiptResponse, error) {
+	s.log.Info("---VerifyDataSubjectReceipt--->", logger.Any("req", req))
+
+	res := &pb.VerifyDataSubjectReceiptResponse{}
+
+	err := s.checkReceiptSigningKey()
+	if err != nil {
+		s.log.Error("!!!VerifyDataSubjectReceipt--->", logger.Error(err))
+		return nil, err
+	}
+
+	if !util.IsValidUUID(req.Id) {
+		return res, nil
+	}
+
+	if !hmac.Equal([]byte(req.Signature), []byte(s.signReceipt(req))) {
+		return res, nil
+	}
+
+	stored, err := s.strg.DataSubjectRequest().GetByPK(ctx, req.Id)
+	if err != nil {
+		s.log.Error("!!!VerifyDataSubjectReceipt--->", logger.Error(err))
+		return res, nil
+	}
+
+	res.Valid = stored.Signature == req.Signature && stored.UserId == req.UserId && stored.Digest == req.Digest
+
+	return res, nil
+}
+
+// createReceipt signs the digest of the content and stores the receipt
+func (s *userService) createReceipt(ctx context.Context, action string, req *pb.DataSubjectRequest, content []byte) (*pb.DataSubjectReceipt, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(content)
+
+	receipt := &pb.DataSubjectReceipt{
+		Id:          id.String(),
+		UserId:      req.UserId,
+		Action:      action,
+		Digest:      hex.EncodeToString(digest[:]),
+		RequestedBy: req.RequestedBy,
+		Reason:      req.Reason,
+		CreatedAt:   time.Now().Format(config.DatabaseTimeLayout),
+	}
+	receipt.Signature = s.signReceipt(receipt)
+
+	err = s.strg.DataSubjectRequest().Create(ctx, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// checkReceiptSigningKey refuses to sign with a missing key or with the key tokens are signed with
+func (s *userService) checkReceiptSigningKey() error {
+	if len(s.cfg.ReceiptSigningKey) == 0 || s.cfg.ReceiptSigningKey == s.cfg.SecretKey {
+		return status.Error(codes.FailedPrecondition, "receipt signing key is not configured, set RECEIPT_SIGNING_KEY to a key other than SECRET_KEY")
+	}
+
+	return nil
+}
+
+// signReceipt is an hmac-sha256 of the receipt fields, fields are separated by new lines
+func (s *userService) signReceipt(receipt *pb.DataSubjectReceipt) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.ReceiptSigningKey))
+	mac.Write([]byte(strings.Join([]string{
+		receipt.Id,
+		receipt.UserId,
+		receipt.Action,
+		receipt.Digest,
+		receipt.RequestedBy,
+		receipt.Reason,
+		receipt.CreatedAt,
+	}, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/userinfo"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	ExportFormatCSV    = "CSV"
+	ExportFormatNDJSON = "NDJSON"
+)
+
+var exportColumns = []string{
+	"id",
+	"project_id",
+	"client_platform_id",
+	"client_type_id",
+	"role_id",
+	"role_name",
+	"name",
+	"photo_url",
+	"phone",
+	"email",
+	"login",
+	"state",
+	"state_reason",
+	"expires_at",
+	"created_at",
+	"updated_at",
+	"relation_ids",
+	"relation_names",
+}
+
+func (s *userService) ExportUsers(req *pb.ExportUsersRequest, stream pb.UserService_ExportUsersServer) error {
+	s.log.Info("---ExportUsers--->", logger.Any("req", req))
+
+	ctx := stream.Context()
+
+	filter := req.Filter
+	if filter == nil {
+		filter = &pb.GetUserListRequest{}
+	}
+
+	format := strings.ToUpper(req.Format)
+	if format != ExportFormatCSV && format != ExportFormatNDJSON {
+		return status.Error(codes.InvalidArgument, "format must be CSV or NDJSON")
+	}
+
+	// pages are read by created_at, an order by user info can't be continued from the last user
+	if len(filter.UserInfoOrderBy) > 0 {
+		return status.Error(codes.InvalidArgument, "exports are ordered by created_at, user info order is not supported")
+	}
+
+	err := s.checkUserInfoFilters(ctx, filter)
+	if err != nil {
+		s.log.Error("!!!ExportUsers--->", logger.Error(err))
+		return err
+	}
+
+	// user info fields become columns when they are known, otherwise data is written as json
+	userInfoFields := []string{}
+	if len(filter.ClientTypeId) > 0 {
+		fields, err := s.strg.UserInfoField().GetListByClientTypeID(ctx, filter.ClientTypeId)
+		if err != nil {
+			s.log.Error("!!!ExportUsers--->", logger.Error(err))
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		for _, f := range fields {
+			userInfoFields = append(userInfoFields, f.FieldName)
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	send := func(force bool) error {
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+
+		if buf.Len() == 0 || (!force && buf.Len() < config.FileChunkSize) {
+			return nil
+		}
+
+		err := stream.Send(&pb.ExportUsersResponse{
+			Chunk: buf.Bytes(),
+		})
+		buf.Reset()
+
+		return err
+	}
+
+	if format == ExportFormatCSV {
+		header := append([]string{}, exportColumns...)
+		if len(userInfoFields) > 0 {
+			for _, name := range userInfoFields {
+				header = append(header, userInfoColumnPrefix+name)
+			}
+		} else {
+			header = append(header, "user_info")
+		}
+
+		if err = writer.Write(header); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	page := proto.Clone(filter).(*pb.GetUserListRequest)
+	page.Limit = config.ExportPageSize
+	page.Offset = 0
+
+	var afterCreatedAt, afterID string
+	for {
+		users, lastCreatedAt, err := s.strg.User().GetExportList(ctx, page, afterCreatedAt, afterID)
+		if err != nil {
+			s.log.Error("!!!ExportUsers--->", logger.Error(err))
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		for _, user := range users {
+			if format == ExportFormatNDJSON {
+				data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(user)
+				if err != nil {
+					return status.Error(codes.Internal, err.Error())
+				}
+				buf.Write(data)
+				buf.WriteByte('\n')
+			} else if err = writer.Write(exportRecord(user, userInfoFields)); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+
+			if err = send(false); err != nil {
+				s.log.Error("!!!ExportUsers--->", logger.Error(err))
+				return err
+			}
+		}
+
+		if int32(len(users)) < page.Limit {
+			break
+		}
+		afterCreatedAt, afterID = lastCreatedAt, users[len(users)-1].Id
+	}
+
+	if err = send(true); err != nil {
+		s.log.Error("!!!ExportUsers--->", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// exportRecord writes a user as a csv row, arrays are joined the way ImportUsers splits them
+// so an export can be imported back
+func exportRecord(user *pb.ExportedUser, userInfoFields []string) []string {
+	relationIDs := []string{}
+	relationNames := []string{}
+	for _, r := range user.Relations {
+		relationIDs = append(relationIDs, r.Id)
+		relationNames = append(relationNames, r.Name)
+	}
+
+	record := []string{
+		user.Id,
+		user.ProjectId,
+		user.ClientPlatformId,
+		user.ClientTypeId,
+		user.RoleId,
+		user.RoleName,
+		user.Name,
+		user.PhotoUrl,
+		user.Phone,
+		user.Email,
+		user.Login,
+		user.State.String(),
+		user.StateReason,
+		user.ExpiresAt,
+		user.CreatedAt,
+		user.UpdatedAt,
+		strings.Join(relationIDs, userinfo.ArraySeparator),
+		strings.Join(relationNames, userinfo.ArraySeparator),
+	}
+
+	if len(userInfoFields) == 0 {
+		data := ""
+		if user.UserInfo != nil {
+			if b, err := protojson.Marshal(user.UserInfo); err == nil {
+				data = string(b)
+			}
+		}
+
+		return append(record, data)
+	}
+
+	for _, name := range userInfoFields {
+		record = append(record, exportValue(user.UserInfo.GetFields()[name]))
+	}
+
+	return record
+}
+
+func exportValue(value *structpb.Value) string {
+	if value == nil {
+		return ""
+	}
+
+	switch v := value.Kind.(type) {
+	case *structpb.Value_StringValue:
+		return v.StringValue
+	case *structpb.Value_NumberValue:
+		return strconv.FormatFloat(v.NumberValue, 'f', -1, 64)
+	case *structpb.Value_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *structpb.Value_ListValue:
+		items := []string{}
+		for _, item := range v.ListValue.Values {
+			items = append(items, exportValue(item))
+		}
+		return strings.Join(items, userinfo.ArraySeparator)
+	case *structpb.Value_StructValue:
+		b, _ := protojson.Marshal(v.StructValue)
+		return string(b)
+	}
+
+	return ""
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/contact"
+	"upm/udevs_go_auth_service/pkg/tabular"
+	"upm/udevs_go_auth_service/pkg/userinfo"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/security"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const userInfoColumnPrefix = "user_info."
+
+var importColumns = map[string]bool{
+	"phone":        true,
+	"email":        true,
+	"login":        true,
+	"password":     true,
+	"name":         true,
+	"photo_url":    true,
+	"expires_at":   true,
+	"role_id":      true,
+	"relation_ids": true,
+}
+
+// importColumn is a column of the file mapped to a user field
+type importColumn struct {
+	index  int
+	header string
+	target string
+}
+
+func (s *userService) ImportUsers(stream pb.UserService_ImportUsersServer) error {
+	header, data, err := receiveImport(stream)
+	if err != nil {
+		s.log.Error("!!!ImportUsers--->", logger.Error(err))
+		return err
+	}
+
+	s.log.Info("---ImportUsers--->", logger.Any("header", header), logger.Any("size", len(data)))
+
+	if len(header.ProjectId) == 0 || len(header.ClientPlatformId) == 0 || len(header.ClientTypeId) == 0 {
+		return status.Error(codes.InvalidArgument, "project_id, client_platform_id and client_type_id are required")
+	}
+
+	if header.State != pb.UserStates_PENDING && header.State != pb.UserStates_ACTIVE {
+		return status.Error(codes.InvalidArgument, "user can be created either pending or active")
+	}
+
+	records, err := tabular.Read(header.Format, data)
+	if err != nil {
+		s.log.Error("!!!ImportUsers--->", logger.Error(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(records) == 0 {
+		return status.Error(codes.InvalidArgument, "file is empty")
+	}
+
+	fields, err := s.strg.UserInfoField().GetListByClientTypeID(stream.Context(), header.ClientTypeId)
+	if err != nil {
+		s.log.Error("!!!ImportUsers--->", logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	schema, err := userinfo.Compile(fields)
+	if err != nil {
+		s.log.Error("!!!ImportUsers--->", logger.Error(err))
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	columns, err := mapImportColumns(records[0], header.ColumnMapping, schema)
+	if err != nil {
+		s.log.Error("!!!ImportUsers--->", logger.Error(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := &pb.ImportUsersResponse{
+		DryRun: header.DryRun,
+	}
+
+	batch := []*pb.ImportUserRow{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		results, err := s.strg.User().Import(stream.Context(), batch, header.DryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			switch {
+			case len(result.Error) > 0:
+				res.Failed++
+				res.Errors = append(res.Errors, &pb.ImportUserRowError{
+					Row:     result.Row,
+					Message: result.Error,
+				})
+			case result.Created:
+				res.Created++
+			default:
+				res.Updated++
+			}
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for i, record := range records[1:] {
+		if isEmptyRecord(record) {
+			continue
+		}
+		res.Total++
+
+		// rows are numbered as in the file, the header is the first row
+		row, rowErrors := parseImportRow(int32(i+2), record, columns, header, schema, s.cfg.DefaultPhoneRegion)
+		if len(rowErrors) > 0 {
+			res.Failed++
+			res.Errors = append(res.Errors, rowErrors...)
+			continue
+		}
+
+		batch = append(batch, row)
+		if len(batch) >= config.ImportBatchSize {
+			if err = flush(); err != nil {
+				s.log.Error("!!!ImportUsers--->", logger.Error(err))
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+
+	if err = flush(); err != nil {
+		s.log.Error("!!!ImportUsers--->", logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return stream.SendAndClose(res)
+}
+
+// receiveImport reads the header and the chunks of the file from the stream
+func receiveImport(stream pb.UserService_ImportUsersServer) (*pb.ImportUsersHeader, []byte, error) {
+	var (
+		header *pb.ImportUsersHeader
+		data   bytes.Buffer
+	)
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch payload := req.Payload.(type) {
+		case *pb.ImportUsersRequest_Header:
+			if header != nil {
+				return nil, nil, status.Error(codes.InvalidArgument, "header must be sent once")
+			}
+			header = payload.Header
+		case *pb.ImportUsersRequest_Chunk:
+			if header == nil {
+				return nil, nil, status.Error(codes.InvalidArgument, "header must be sent before the file")
+			}
+
+			if data.Len()+len(payload.Chunk) > config.ImportMaxFileSize {
+				return nil, nil, status.Error(codes.InvalidArgument, fmt.Sprintf("file must not be larger than %d bytes", config.ImportMaxFileSize))
+			}
+			data.Write(payload.Chunk)
+		}
+	}
+
+	if header == nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "header is required")
+	}
+
+	return header, data.Bytes(), nil
+}
+
+// mapImportColumns resolves the target of every column, headers are targets themselves when
+// there is no mapping and unmapped columns are skipped otherwise
+func mapImportColumns(headers []string, mapping map[string]string, schema *userinfo.Schema) ([]importColumn, error) {
+	columns := []importColumn{}
+	targets := map[string]bool{}
+
+	for i, h := range headers {
+		h = strings.TrimSpace(h)
+
+		target := h
+		if len(mapping) > 0 {
+			var ok bool
+			if target, ok = mapping[h]; !ok {
+				continue
+			}
+		}
+
+		if len(target) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(target, userInfoColumnPrefix) {
+			if schema.Field(strings.TrimPrefix(target, userInfoColumnPrefix)) == nil {
+				return nil, fmt.Errorf("column %s: user info field %s is not defined", h, strings.TrimPrefix(target, userInfoColumnPrefix))
+			}
+		} else if !importColumns[target] {
+			return nil, fmt.Errorf("column %s: unknown target %s", h, target)
+		}
+
+		if targets[target] {
+			return nil, fmt.Errorf("column %s: %s is mapped more than once", h, target)
+		}
+		targets[target] = true
+
+		columns = append(columns, importColumn{
+			index:  i,
+			header: h,
+			target: target,
+		})
+	}
+
+	if !targets["phone"] && !targets["email"] && !targets["login"] {
+		return nil, fmt.Errorf("one of phone, email or login columns is required")
+	}
+
+	for _, name := range schema.Required() {
+		if !targets[userInfoColumnPrefix+name] {
+			return nil, fmt.Errorf("user info field %s is required but no column is mapped to it", name)
+		}
+	}
+
+	return columns, nil
+}
+
+func parseImportRow(number int32, record []string, columns []importColumn, header *pb.ImportUsersHeader, schema *userinfo.Schema, phoneRegion string) (*pb.ImportUserRow, []*pb.ImportUserRowError) {
+	rowErrors := []*pb.ImportUserRowError{}
+	addError := func(column, message string) {
+		rowErrors = append(rowErrors, &pb.ImportUserRowError{
+			Row:     number,
+			Column:  column,
+			Message: message,
+		})
+	}
+
+	user := &pb.CreateUserRequest{
+		ProjectId:        header.ProjectId,
+		ClientPlatformId: header.ClientPlatformId,
+		ClientTypeId:     header.ClientTypeId,
+		State:            header.State,
+	}
+	row := &pb.ImportUserRow{
+		Row:              number,
+		User:             user,
+		DefaultRoleId:    header.RoleId,
+		DefaultExpiresAt: header.ExpiresAt,
+		UserInfo: &structpb.Struct{
+			Fields: map[string]*structpb.Value{},
+		},
+	}
+	userInfoColumns := map[string]string{}
+
+	for _, c := range columns {
+		value := ""
+		if c.index < len(record) {
+			value = strings.TrimSpace(record[c.index])
+		}
+
+		if strings.HasPrefix(c.target, userInfoColumnPrefix) {
+			name := strings.TrimPrefix(c.target, userInfoColumnPrefix)
+			userInfoColumns[name] = c.header
+
+			v, err := schema.ParseValue(name, value)
+			if err != nil {
+				addError(c.header, err.Error())
+				continue
+			}
+
+			if v == nil {
+				if f := schema.Field(name); f.Required && f.DefaultValue == nil {
+					addError(c.header, "is required")
+				}
+				continue
+			}
+
+			row.UserInfo.Fields[name] = v
+			continue
+		}
+
+		if len(value) == 0 {
+			continue
+		}
+
+		switch c.target {
+		case "phone":
+			phone, err := contact.NormalizePhone(value, phoneRegion)
+			if err != nil {
+				addError(c.header, err.Error())
+			}
+			user.Phone = phone
+		case "email":
+			email, err := contact.NormalizeEmail(value)
+			if err != nil {
+				addError(c.header, err.Error())
+			}
+			user.Email = email
+		case "login":
+			if err := contact.CheckLogin(value, phoneRegion); err != nil {
+				addError(c.header, err.Error())
+			}
+			user.Login = value
+		case "password":
+			if len(value) < 6 {
+				addError(c.header, "password must not be less than 6 characters")
+				continue
+			}
+
+			// hashing is the slowest part of a row and nothing is stored on a dry run
+			if header.DryRun {
+				user.Password = value
+				continue
+			}
+
+			hashedPassword, err := security.HashPassword(value)
+			if err != nil {
+				addError(c.header, err.Error())
+				continue
+			}
+			user.Password = hashedPassword
+		case "name":
+			user.Name = value
+		case "photo_url":
+			user.PhotoUrl = value
+		case "expires_at":
+			user.ExpiresAt = value
+		case "role_id":
+			if !util.IsValidUUID(value) {
+				addError(c.header, "role id is an invalid uuid")
+			}
+			user.RoleId = value
+		case "relation_ids":
+			for _, id := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+				id = strings.TrimSpace(id)
+				if !util.IsValidUUID(id) {
+					addError(c.header, fmt.Sprintf("relation id %s is an invalid uuid", id))
+					continue
+				}
+				row.RelationIds = append(row.RelationIds, id)
+			}
+		}
+	}
+
+	if len(user.Phone) == 0 && len(user.Email) == 0 && len(user.Login) == 0 {
+		addError("", "one of phone, email or login is required")
+	}
+
+	for _, fieldError := range schema.ValidatePartial(row.UserInfo) {
+		addError(userInfoColumns[fieldError.FieldName], fieldError.Message)
+	}
+
+	return row, rowErrors
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, v := range record {
+		if len(strings.TrimSpace(v)) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GrantUserPermission grants a permission of the client platform of the user to the user directly,
+// the grant counts from starts_at, now by default, until ends_at and is kept after that
+func (s *userService) GrantUserPermission(ctx context.Context, req *pb.GrantUserPermissionRequest) (*pb.UserPermission, error) {
+	s.log.Info("---GrantUserPermission--->", logger.Any("req", req))
+
+	err := validateUserPermissionGrant(req)
+	if err != nil {
+		s.log.Error("!!!GrantUserPermission--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err != nil {
+		s.log.Error("!!!GrantUserPermission--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if len(user.DeletedAt) > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "user has been deleted")
+	}
+
+	permission, err := s.strg.Permission().GetByPK(ctx, &pb.PermissionPrimaryKey{Id: req.PermissionId})
+	if err != nil {
+		s.log.Error("!!!GrantUserPermission--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if permission.ClientPlatformId != user.ClientPlatformId {
+		return nil, status.Error(codes.FailedPrecondition, "permission belongs to another client platform than the user")
+	}
+
+	pKey, err := s.strg.UserPermission().Create(ctx, req)
+	if err != nil {
+		s.log.Error("!!!GrantUserPermission--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res, err := s.strg.UserPermission().GetByPK(ctx, pKey)
+	if err != nil {
+		s.log.Error("!!!GrantUserPermission--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func validateUserPermissionGrant(req *pb.GrantUserPermissionRequest) error {
+	if !util.IsValidUUID(req.UserId) {
+		return errors.New("user id is an invalid uuid")
+	}
+
+	if !util.IsValidUUID(req.PermissionId) {
+		return errors.New("permission id is an invalid uuid")
+	}
+
+	if !util.IsValidUUID(req.GrantedBy) {
+		return errors.New("granted_by must be the id of the granting admin")
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) == 0 {
+		return errors.New("reason is required")
+	}
+
+	startsAt := time.Now()
+	if len(req.StartsAt) > 0 {
+		t, err := time.Parse(config.DatabaseTimeLayout, req.StartsAt)
+		if err != nil {
+			return errors.New("starts_at must be in RFC3339")
+		}
+		startsAt = t
+	}
+
+	endsAt, err := time.Parse(config.DatabaseTimeLayout, req.EndsAt)
+	if err != nil {
+		return errors.New("ends_at is required in RFC3339")
+	}
+
+	if !endsAt.After(startsAt) || !endsAt.After(time.Now()) {
+		return errors.New("ends_at must be in the future and after starts_at")
+	}
+
+	return nil
+}
+
+func (s *userService) RevokeUserPermission(ctx context.Context, req *pb.UserPermissionPrimaryKey) (*pb.UserPermission, error) {
+	s.log.Info("---RevokeUserPermission--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "id is an invalid uuid")
+	}
+
+	res, err := s.strg.UserPermission().GetByPK(ctx, req)
+	if err != nil {
+		s.log.Error("!!!RevokeUserPermission--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	rowsAffected, err := s.strg.UserPermission().Delete(ctx, req)
+	if err != nil {
+		s.log.Error("!!!RevokeUserPermission--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	return res, nil
+}
+
+// GetUserPermissionList returns the grants of the user that haven't ended, future ones included
+func (s *userService) GetUserPermissionList(ctx context.Context, req *pb.UserPrimaryKey) (*pb.GetUserPermissionListResponse, error) {
+	s.log.Info("---GetUserPermissionList--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "user id is an invalid uuid")
+	}
+
+	userPermissions, err := s.strg.UserPermission().GetListByUserID(ctx, req.Id)
+	if err != nil {
+		s.log.Error("!!!GetUserPermissionList--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetUserPermissionListResponse{UserPermissions: userPermissions}, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddUserRole assigns a role of the client platform of the user besides the primary one,
+// calling it again for the same role replaces the dates of the assignment
+func (s *userService) AddUserRole(ctx context.Context, req *pb.AddUserRoleRequest) (*pb.UserRole, error) {
+	s.log.Info("---AddUserRole--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.UserId) {
+		return nil, status.Error(codes.InvalidArgument, "user id is an invalid uuid")
+	}
+
+	if !util.IsValidUUID(req.RoleId) {
+		return nil, status.Error(codes.InvalidArgument, "role id is an invalid uuid")
+	}
+
+	var startsAt, endsAt time.Time
+	var err error
+	if len(req.StartsAt) > 0 {
+		startsAt, err = time.Parse(config.DatabaseTimeLayout, req.StartsAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "starts_at must be in RFC3339")
+		}
+	}
+
+	if len(req.EndsAt) > 0 {
+		endsAt, err = time.Parse(config.DatabaseTimeLayout, req.EndsAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "ends_at must be in RFC3339")
+		}
+
+		if !startsAt.IsZero() && !endsAt.After(startsAt) {
+			return nil, status.Error(codes.InvalidArgument, "ends_at must be after starts_at")
+		}
+	}
+
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err != nil {
+		s.log.Error("!!!AddUserRole--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	role, err := s.strg.Role().GetByPK(ctx, &pb.RolePrimaryKey{Id: req.RoleId})
+	if err != nil {
+		s.log.Error("!!!AddUserRole--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if role.ClientPlatformId != user.ClientPlatformId {
+		return nil, status.Error(codes.FailedPrecondition, "role belongs to another client platform than the user")
+	}
+
+	if role.Id == user.RoleId {
+		return nil, status.Error(codes.FailedPrecondition, "role is the primary role of the user")
+	}
+
+	err = s.strg.UserRole().Upsert(ctx, req)
+	if err != nil {
+		s.log.Error("!!!AddUserRole--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res, err := s.strg.UserRole().GetByPK(ctx, &pb.UserRolePrimaryKey{UserId: req.UserId, RoleId: req.RoleId})
+	if err != nil {
+		s.log.Error("!!!AddUserRole--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func (s *userService) RemoveUserRole(ctx context.Context, req *pb.UserRolePrimaryKey) (*pb.UserRole, error) {
+	s.log.Info("---RemoveUserRole--->", logger.Any("req", req))
+
+	res, err := s.strg.UserRole().GetByPK(ctx, req)
+	if err != nil {
+		s.log.Error("!!!RemoveUserRole--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	rowsAffected, err := s.strg.UserRole().Remove(ctx, req)
+	if err != nil {
+		s.log.Error("!!!RemoveUserRole--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	}
+
+	return res, nil
+}
+
+// GetUserRoleList returns the assignments of the user, expired and future ones included,
+// the primary role of the user isn't among them
+func (s *userService) GetUserRoleList(ctx context.Context, req *pb.UserPrimaryKey) (*pb.GetUserRoleListResponse, error) {
+	s.log.Info("---GetUserRoleList--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "user id is an invalid uuid")
+	}
+
+	userRoles, err := s.strg.UserRole().GetListByUserID(ctx, req.Id)
+	if err != nil {
+		s.log.Error("!!!GetUserRoleList--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetUserRoleListResponse{UserRoles: userRoles}, nil
+}
@@ -3,21 +3,26 @@ package service
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"strings"
 	"time"
 	"upm/udevs_go_auth_service/config"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/grpc/client"
+	"upm/udevs_go_auth_service/pkg/contact"
 	"upm/udevs_go_auth_service/pkg/helper"
+	"upm/udevs_go_auth_service/pkg/userinfo"
 	"upm/udevs_go_auth_service/storage"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/saidamir98/udevs_pkg/security"
 
 	"github.com/saidamir98/udevs_pkg/logger"
+	"github.com/saidamir98/udevs_pkg/util"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type userService struct {
@@ -53,20 +58,30 @@ func (s *userService) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	}
 	req.Password = hashedPassword
 
-	emailRegex := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
-	email := emailRegex.MatchString(req.Email)
-	if !email {
-		err = fmt.Errorf("email is not valid")
+	req.Email, err = contact.NormalizeEmail(req.Email)
+	if err != nil {
 		s.log.Error("!!!CreateUser--->", logger.Error(err))
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	phoneRegex := regexp.MustCompile(`^[+]?(\d{1,2})?[\s.-]?\(?\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{4}$`)
-	phone := phoneRegex.MatchString(req.Phone)
-	if !phone {
-		err = fmt.Errorf("phone number is not valid")
+	req.Phone, err = contact.NormalizePhone(req.Phone, s.cfg.DefaultPhoneRegion)
+	if err != nil {
 		s.log.Error("!!!CreateUser--->", logger.Error(err))
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(req.Login) > 0 {
+		err = contact.CheckLogin(req.Login, s.cfg.DefaultPhoneRegion)
+		if err != nil {
+			s.log.Error("!!!CreateUser--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if req.State != pb.UserStates_PENDING && req.State != pb.UserStates_ACTIVE {
+		err = fmt.Errorf("user can be created either pending or active")
+		s.log.Error("!!!CreateUser--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	pKey, err := s.strg.User().Create(ctx, req)
@@ -108,6 +123,12 @@ func (s *userService) GetUserListByIDs(ctx context.Context, req *pb.UserPrimaryK
 func (s *userService) GetUserList(ctx context.Context, req *pb.GetUserListRequest) (*pb.GetUserListResponse, error) {
 	s.log.Info("---GetUserList--->", logger.Any("req", req))
 
+	err := s.checkUserInfoFilters(ctx, req)
+	if err != nil {
+		s.log.Error("!!!GetUserList--->", logger.Error(err))
+		return nil, err
+	}
+
 	res, err := s.strg.User().GetList(ctx, req)
 
 	if err != nil {
@@ -121,30 +142,36 @@ func (s *userService) GetUserList(ctx context.Context, req *pb.GetUserListReques
 func (s *userService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
 	s.log.Info("---UpdateUser--->", logger.Any("req", req))
 
-	rowsAffected, err := s.strg.User().Update(ctx, req)
+	var err error
+	req.Email, err = contact.NormalizeEmail(req.Email)
+	if err != nil {
+		s.log.Error("!!!UpdateUser--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
+	req.Phone, err = contact.NormalizePhone(req.Phone, s.cfg.DefaultPhoneRegion)
 	if err != nil {
 		s.log.Error("!!!UpdateUser--->", logger.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if rowsAffected <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
+	if len(req.Login) > 0 {
+		err = contact.CheckLogin(req.Login, s.cfg.DefaultPhoneRegion)
+		if err != nil {
+			s.log.Error("!!!UpdateUser--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 	}
-	emailRegex := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
-	email := emailRegex.MatchString(req.Email)
-	if !email {
-		err = fmt.Errorf("email is not valid")
+
+	rowsAffected, err := s.strg.User().Update(ctx, req)
+
+	if err != nil {
 		s.log.Error("!!!UpdateUser--->", logger.Error(err))
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	phoneRegex := regexp.MustCompile(`^[+]?(\d{1,2})?[\s.-]?\(?\d{3}\)?[\s.-]?\d{3}[\s.-]?\d{4}$`)
-	phone := phoneRegex.MatchString(req.Phone)
-	if !phone {
-		err = fmt.Errorf("phone number is not valid")
-		s.log.Error("!!!UpdateUser--->", logger.Error(err))
-		return nil, err
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "no rows were affected")
 	}
 
 	res, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.Id})
@@ -175,6 +202,51 @@ func (s *userService) DeleteUser(ctx context.Context, req *pb.UserPrimaryKey) (*
 	return res, nil
 }
 
+// RestoreUser brings a deleted user back in the state it was deleted in, it's possible until the
+// purge job anonymizes the user after the retention
+func (s *userService) RestoreUser(ctx context.Context, req *pb.ChangeUserStateRequest) (*pb.User, error) {
+	s.log.Info("---RestoreUser--->", logger.Any("req", req))
+
+	deletedAfter := time.Now().AddDate(0, 0, -s.cfg.UserDeleteRetentionDays).Format(config.DatabaseTimeLayout)
+
+	rowsAffected, err := s.strg.User().Restore(ctx, req, deletedAfter)
+	if err == storage.ErrorUserContactTaken {
+		s.log.Error("!!!RestoreUser--->", logger.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.log.Error("!!!RestoreUser--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		// anonymized users are read as well, their contacts are NULL and come back empty
+		user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+		if err == pgx.ErrNoRows {
+			s.log.Error("!!!RestoreUser--->", logger.Error(err))
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		if err != nil {
+			s.log.Error("!!!RestoreUser--->", logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if len(user.DeletedAt) == 0 {
+			return nil, status.Error(codes.FailedPrecondition, "user is not deleted")
+		}
+
+		return nil, status.Error(codes.FailedPrecondition, "retention of the deleted user has passed")
+	}
+
+	res, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err != nil {
+		s.log.Error("!!!RestoreUser--->", logger.Error(err))
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return res, nil
+}
+
 func (s *userService) AddUserRelation(ctx context.Context, req *pb.AddUserRelationRequest) (*pb.UserRelation, error) {
 	s.log.Info("---AddUserRelation--->", logger.Any("req", req))
 
@@ -211,9 +283,34 @@ func (s *userService) RemoveUserRelation(ctx context.Context, req *pb.UserRelati
 	return res, nil
 }
 
+// GetUserEffectiveRelations returns the relations of the user with all their descendants,
+// relation scoped permissions allow for resources of any of them
+func (s *userService) GetUserEffectiveRelations(ctx context.Context, req *pb.UserPrimaryKey) (*pb.GetUserEffectiveRelationsResponse, error) {
+	s.log.Info("---GetUserEffectiveRelations--->", logger.Any("req", req))
+
+	if !util.IsValidUUID(req.Id) {
+		return nil, status.Error(codes.InvalidArgument, "user id is an invalid uuid")
+	}
+
+	relations, err := s.strg.UserRelation().GetEffectiveRelations(ctx, req.Id)
+	if err != nil {
+		s.log.Error("!!!GetUserEffectiveRelations--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetUserEffectiveRelationsResponse{Relations: relations}, nil
+}
+
 func (s *userService) UpsertUserInfo(ctx context.Context, req *pb.UpsertUserInfoRequest) (*pb.UserInfo, error) {
 	s.log.Info("---UpsertUserInfo--->", logger.Any("req", req))
 
+	data, err := s.validateUserInfo(ctx, req.UserId, req.Data)
+	if err != nil {
+		s.log.Error("!!!UpsertUserInfo--->", logger.Error(err))
+		return nil, err
+	}
+	req.Data = data
+
 	pKey, err := s.strg.UserInfo().Upsert(ctx, req)
 
 	if err != nil {
@@ -262,7 +359,7 @@ func (s *userService) ResetPassword(ctx context.Context, req *pb.ResetPasswordRe
 }
 
 func (s *userService) SendMessageToEmail(ctx context.Context, req *pb.SendMessageToEmailRequest) (*emptypb.Empty, error) {
-	user, err := s.strg.User().GetByUsername(context.Background(), req.GetEmail())
+	user, err := s.strg.User().GetByUsername(context.Background(), contact.NormalizeUsername(req.GetEmail(), s.cfg.DefaultPhoneRegion))
 	if err != nil {
 		s.log.Error("error while getting user by email", logger.Error(err), logger.Any("req", req))
 		return nil, status.Error(codes.NotFound, err.Error())
@@ -285,3 +382,227 @@ func (s *userService) SendMessageToEmail(ctx context.Context, req *pb.SendMessag
 	}
 	return &emptypb.Empty{}, nil
 }
+
+func (s *userService) ActivateUser(ctx context.Context, req *pb.ChangeUserStateRequest) (*pb.User, error) {
+	s.log.Info("---ActivateUser--->", logger.Any("req", req))
+
+	user, err := s.changeUserState(ctx, &pb.UpdateUserStateRequest{
+		UserId:    req.UserId,
+		ToState:   pb.UserStates_ACTIVE,
+		Reason:    req.Reason,
+		ChangedBy: req.ChangedBy,
+	}, pb.UserStates_PENDING)
+	if err != nil {
+		s.log.Error("!!!ActivateUser--->", logger.Error(err))
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.User, error) {
+	s.log.Info("---SuspendUser--->", logger.Any("req", req))
+
+	if len(req.Reason) == 0 {
+		err := fmt.Errorf("reason is required")
+		s.log.Error("!!!SuspendUser--->", logger.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(req.Until) > 0 {
+		until, err := time.Parse(config.DatabaseTimeLayout, req.Until)
+		if err != nil {
+			s.log.Error("!!!SuspendUser--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if until.Before(time.Now()) {
+			err := fmt.Errorf("until must be in the future")
+			s.log.Error("!!!SuspendUser--->", logger.Error(err))
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	user, err := s.changeUserState(ctx, &pb.UpdateUserStateRequest{
+		UserId:         req.UserId,
+		ToState:        pb.UserStates_SUSPENDED,
+		Reason:         req.Reason,
+		SuspendedUntil: req.Until,
+		ChangedBy:      req.ChangedBy,
+	}, pb.UserStates_PENDING, pb.UserStates_ACTIVE)
+	if err != nil {
+		s.log.Error("!!!SuspendUser--->", logger.Error(err))
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) LockUser(ctx context.Context, req *pb.ChangeUserStateRequest) (*pb.User, error) {
+	s.log.Info("---LockUser--->", logger.Any("req", req))
+
+	user, err := s.changeUserState(ctx, &pb.UpdateUserStateRequest{
+		UserId:    req.UserId,
+		ToState:   pb.UserStates_LOCKED,
+		Reason:    req.Reason,
+		ChangedBy: req.ChangedBy,
+	}, pb.UserStates_PENDING, pb.UserStates_ACTIVE, pb.UserStates_SUSPENDED)
+	if err != nil {
+		s.log.Error("!!!LockUser--->", logger.Error(err))
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) ReinstateUser(ctx context.Context, req *pb.ChangeUserStateRequest) (*pb.User, error) {
+	s.log.Info("---ReinstateUser--->", logger.Any("req", req))
+
+	user, err := s.changeUserState(ctx, &pb.UpdateUserStateRequest{
+		UserId:    req.UserId,
+		ToState:   pb.UserStates_ACTIVE,
+		Reason:    req.Reason,
+		ChangedBy: req.ChangedBy,
+	}, pb.UserStates_SUSPENDED, pb.UserStates_LOCKED, pb.UserStates_EXPIRED)
+	if err != nil {
+		s.log.Error("!!!ReinstateUser--->", logger.Error(err))
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) GetUserStateHistory(ctx context.Context, req *pb.UserPrimaryKey) (*pb.GetUserStateHistoryResponse, error) {
+	s.log.Info("---GetUserStateHistory--->", logger.Any("req", req))
+
+	res, err := s.strg.User().GetStateHistory(ctx, req)
+	if err != nil {
+		s.log.Error("!!!GetUserStateHistory--->", logger.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+// changeUserState validates the transition against the states the rpc accepts and the lifecycle itself,
+// sessions of users who can't log in anymore are dropped so their tokens end up in the revocation feed
+func (s *userService) changeUserState(ctx context.Context, req *pb.UpdateUserStateRequest, from ...pb.UserStates) (*pb.User, error) {
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	allowed := false
+	for _, state := range from {
+		if user.State == state {
+			allowed = true
+		}
+	}
+
+	if !allowed || !canChangeUserState(user.State, req.ToState) {
+		return nil, status.Errorf(codes.FailedPrecondition, "user can't be moved from %s to %s", user.State, req.ToState)
+	}
+
+	if user.State == pb.UserStates_EXPIRED {
+		expiresAt, err := time.Parse(config.DatabaseTimeLayout, user.ExpiresAt)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if expiresAt.Before(time.Now()) {
+			return nil, status.Error(codes.FailedPrecondition, "expires_at must be extended before the user is reinstated")
+		}
+	}
+
+	req.FromState = user.State
+
+	rowsAffected, err := s.strg.User().UpdateState(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if rowsAffected <= 0 {
+		return nil, status.Error(codes.Aborted, "user state has been changed concurrently")
+	}
+
+	if req.ToState != pb.UserStates_ACTIVE {
+		_, err = s.strg.Session().DeleteByUserID(ctx, user.Id)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: req.UserId})
+}
+
+// checkUserInfoFilters checks the user info filters and sort of the list against the fields of the client type
+func (s *userService) checkUserInfoFilters(ctx context.Context, req *pb.GetUserListRequest) error {
+	if len(req.Arrangement) > 0 && strings.ToUpper(req.Arrangement) != "ASC" && strings.ToUpper(req.Arrangement) != "DESC" {
+		return status.Error(codes.InvalidArgument, "arrangement must be ASC or DESC")
+	}
+
+	if len(req.UserInfoFilters) == 0 && len(req.UserInfoOrderBy) == 0 {
+		return nil
+	}
+
+	if len(req.ClientTypeId) == 0 {
+		return status.Error(codes.InvalidArgument, "client_type_id is required to filter or sort by user info")
+	}
+
+	fields, err := s.strg.UserInfoField().GetListByClientTypeID(ctx, req.ClientTypeId)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	schema, err := userinfo.Compile(fields)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	for _, filter := range req.UserInfoFilters {
+		if err := schema.CheckFilter(filter); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if len(req.UserInfoOrderBy) > 0 {
+		if err := schema.CheckOrderBy(req.UserInfoOrderBy); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// validateUserInfo checks data against the user_info_field rows of the user's client type
+// and returns it with defaults filled in, violations are attached as UserInfoFieldErrors
+func (s *userService) validateUserInfo(ctx context.Context, userID string, data *structpb.Struct) (*structpb.Struct, error) {
+	user, err := s.strg.User().GetByPK(ctx, &pb.UserPrimaryKey{Id: userID})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	fields, err := s.strg.UserInfoField().GetListByClientTypeID(ctx, user.ClientTypeId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	schema, err := userinfo.Compile(fields)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	res, fieldErrors := schema.Validate(data)
+	if len(fieldErrors) > 0 {
+		st, err := status.New(codes.InvalidArgument, "user info is not valid").WithDetails(&pb.UserInfoFieldErrors{
+			Errors: fieldErrors,
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return nil, st.Err()
+	}
+
+	return res, nil
+}
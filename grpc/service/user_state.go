@@ -0,0 +1,77 @@
+package service
+
+import (
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userStateTransitions lists the states a user can be moved to from each state,
+// DELETED is only left by RestoreUser within the retention
+var userStateTransitions = map[pb.UserStates][]pb.UserStates{
+	pb.UserStates_PENDING:   {pb.UserStates_ACTIVE, pb.UserStates_SUSPENDED, pb.UserStates_LOCKED, pb.UserStates_DELETED},
+	pb.UserStates_ACTIVE:    {pb.UserStates_SUSPENDED, pb.UserStates_LOCKED, pb.UserStates_EXPIRED, pb.UserStates_DELETED},
+	pb.UserStates_SUSPENDED: {pb.UserStates_ACTIVE, pb.UserStates_LOCKED, pb.UserStates_DELETED},
+	pb.UserStates_LOCKED:    {pb.UserStates_ACTIVE, pb.UserStates_DELETED},
+	pb.UserStates_EXPIRED:   {pb.UserStates_ACTIVE, pb.UserStates_DELETED},
+}
+
+func canChangeUserState(from, to pb.UserStates) bool {
+	for _, state := range userStateTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkUserState is shared by everything that lets a user in: Login, RefreshToken and HasAccess
+func checkUserState(user *pb.User) error {
+	switch user.State {
+	case pb.UserStates_ACTIVE:
+	case pb.UserStates_PENDING:
+		return status.Error(codes.InvalidArgument, "user hasn't been activated yet")
+	case pb.UserStates_SUSPENDED:
+		if !suspensionIsOver(user) {
+			return status.Error(codes.InvalidArgument, "user has been suspended: "+user.StateReason)
+		}
+	case pb.UserStates_LOCKED:
+		return status.Error(codes.InvalidArgument, "user has been locked")
+	case pb.UserStates_EXPIRED:
+		return status.Error(codes.InvalidArgument, "user has been expired")
+	case pb.UserStates_DELETED:
+		return status.Error(codes.InvalidArgument, "user has been deleted")
+	default:
+		return status.Error(codes.InvalidArgument, "user is not active")
+	}
+
+	expiresAt, err := time.Parse(config.DatabaseTimeLayout, user.ExpiresAt)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if expiresAt.Unix() < time.Now().Unix() {
+		return status.Error(codes.InvalidArgument, "user has been expired")
+	}
+
+	return nil
+}
+
+// suspensionIsOver reports whether a temporary suspension has run out,
+// the state itself is moved back to ACTIVE by the next reinstatement
+func suspensionIsOver(user *pb.User) bool {
+	if len(user.SuspendedUntil) == 0 {
+		return false
+	}
+
+	suspendedUntil, err := time.Parse(config.DatabaseTimeLayout, user.SuspendedUntil)
+	if err != nil {
+		return false
+	}
+
+	return suspendedUntil.Before(time.Now())
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "passkey_challenge";
+DROP TYPE IF EXISTS "passkey_ceremonies";
+DROP INDEX IF EXISTS "idx_passkey_credential_user_id";
+DROP TABLE IF EXISTS "passkey_credential";
+
+-- enum values can't be dropped, fall back to the password strategy instead
+UPDATE "client" SET "login_strategy" = 'STANDARD' WHERE "login_strategy" IN ('PASSKEY', 'STANDARD_PASSKEY');
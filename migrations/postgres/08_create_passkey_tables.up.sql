@@ -0,0 +1,31 @@
+ALTER TYPE "login_strategies" ADD VALUE IF NOT EXISTS 'PASSKEY';
+ALTER TYPE "login_strategies" ADD VALUE IF NOT EXISTS 'STANDARD_PASSKEY';
+
+CREATE TABLE IF NOT EXISTS "passkey_credential" (
+    "id" UUID PRIMARY KEY,
+    "user_id" UUID REFERENCES "user"("id") NOT NULL,
+    "credential_id" VARCHAR(1024) NOT NULL UNIQUE,
+    "name" VARCHAR DEFAULT '' NOT NULL,
+    "public_key" BYTEA NOT NULL,
+    "public_key_algorithm" INTEGER NOT NULL,
+    "sign_count" BIGINT DEFAULT 0 NOT NULL,
+    "transports" VARCHAR[] DEFAULT '{}' NOT NULL,
+    "aaguid" UUID,
+    "last_used_at" TIMESTAMP,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX "idx_passkey_credential_user_id" ON "passkey_credential"("user_id");
+
+CREATE TYPE "passkey_ceremonies" AS ENUM ('REGISTRATION', 'LOGIN');
+
+CREATE TABLE IF NOT EXISTS "passkey_challenge" (
+    "id" UUID PRIMARY KEY,
+    "client_platform_id" UUID REFERENCES "client_platform"("id") NOT NULL,
+    "user_id" UUID REFERENCES "user"("id"),
+    "ceremony" passkey_ceremonies NOT NULL,
+    "challenge" VARCHAR(128) NOT NULL,
+    "rp_id" VARCHAR NOT NULL,
+    "expires_at" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
@@ -0,0 +1,5 @@
+ALTER TABLE "passcode" DROP COLUMN IF EXISTS "attempts";
+
+ALTER TABLE "session" DROP COLUMN IF EXISTS "last_auth_at";
+
+ALTER TABLE "permission" DROP COLUMN IF EXISTS "recent_auth_minutes";
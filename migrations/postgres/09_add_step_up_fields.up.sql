@@ -0,0 +1,5 @@
+ALTER TABLE "permission" ADD COLUMN IF NOT EXISTS "recent_auth_minutes" INTEGER DEFAULT 0 NOT NULL;
+
+ALTER TABLE "session" ADD COLUMN IF NOT EXISTS "last_auth_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL;
+
+ALTER TABLE "passcode" ADD COLUMN IF NOT EXISTS "attempts" INTEGER DEFAULT 0 NOT NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "trg_session_revoke" ON "session";
+DROP FUNCTION IF EXISTS revoke_session();
+DROP TABLE IF EXISTS "revoked_session";
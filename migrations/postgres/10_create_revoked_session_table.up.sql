@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS "revoked_session" (
+    "id" UUID PRIMARY KEY,
+    "seq" BIGSERIAL NOT NULL,
+    "user_id" UUID,
+    "integration_id" UUID,
+    "expires_at" TIMESTAMP NOT NULL,
+    "revoked_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE UNIQUE INDEX "idx_revoked_session_seq" ON "revoked_session"("seq");
+CREATE INDEX "idx_revoked_session_expires_at" ON "revoked_session"("expires_at");
+
+-- every way of deleting a session (logout, integration, user removal) ends up in the feed,
+-- expired sessions are left out since their tokens are rejected anyway
+CREATE OR REPLACE FUNCTION revoke_session() RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD."expires_at" > now() THEN
+        INSERT INTO "revoked_session" ("id", "user_id", "integration_id", "expires_at")
+        VALUES (OLD."id", OLD."user_id", OLD."integration_id", OLD."expires_at")
+        ON CONFLICT ("id") DO NOTHING;
+    END IF;
+
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "trg_session_revoke" AFTER DELETE ON "session"
+    FOR EACH ROW EXECUTE PROCEDURE revoke_session();
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS "user_state_history";
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "active" SMALLINT;
+
+UPDATE "user" SET "active" = 1 WHERE "state" IN ('ACTIVE', 'EXPIRED');
+UPDATE "user" SET "active" = 0 WHERE "state" = 'PENDING';
+UPDATE "user" SET "active" = -1 WHERE "state" IN ('SUSPENDED', 'LOCKED', 'DELETED');
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "suspended_until";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "state_reason";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "state";
+
+DROP TYPE IF EXISTS "user_states";
@@ -0,0 +1,23 @@
+CREATE TYPE "user_states" AS ENUM ('PENDING', 'ACTIVE', 'SUSPENDED', 'LOCKED', 'EXPIRED', 'DELETED');
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "state" user_states DEFAULT 'PENDING' NOT NULL;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "state_reason" VARCHAR DEFAULT '' NOT NULL;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "suspended_until" TIMESTAMP;
+
+UPDATE "user" SET "state" = 'ACTIVE' WHERE "active" > 0;
+UPDATE "user" SET "state" = 'SUSPENDED', "state_reason" = 'deactivated before user states were introduced' WHERE "active" < 0;
+UPDATE "user" SET "state" = 'EXPIRED' WHERE "state" = 'ACTIVE' AND "expires_at" < now();
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "active";
+
+CREATE TABLE IF NOT EXISTS "user_state_history" (
+    "id" UUID PRIMARY KEY,
+    "user_id" UUID REFERENCES "user"("id") ON DELETE CASCADE NOT NULL,
+    "from_state" user_states NOT NULL,
+    "to_state" user_states NOT NULL,
+    "reason" VARCHAR DEFAULT '' NOT NULL,
+    "suspended_until" TIMESTAMP,
+    "changed_by" UUID,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX "idx_user_state_history_user_id" ON "user_state_history"("user_id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_passcode_expires_at";
+DROP INDEX IF EXISTS "idx_session_expires_at";
+DROP INDEX IF EXISTS "idx_user_expires_at";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "expiry_notified_at";
@@ -0,0 +1,5 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "expiry_notified_at" TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS "idx_user_expires_at" ON "user"("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_session_expires_at" ON "session"("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_passcode_expires_at" ON "passcode"("expires_at");
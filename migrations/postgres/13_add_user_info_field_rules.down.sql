@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "idx_user_info_field_client_type_id_field_name";
+
+ALTER TABLE "user_info_field" DROP COLUMN IF EXISTS "default_value";
+ALTER TABLE "user_info_field" DROP COLUMN IF EXISTS "max_value";
+ALTER TABLE "user_info_field" DROP COLUMN IF EXISTS "min_value";
+ALTER TABLE "user_info_field" DROP COLUMN IF EXISTS "pattern";
+ALTER TABLE "user_info_field" DROP COLUMN IF EXISTS "enum_values";
+ALTER TABLE "user_info_field" DROP COLUMN IF EXISTS "required";
@@ -0,0 +1,9 @@
+ALTER TABLE "user_info_field" ADD COLUMN IF NOT EXISTS "required" BOOLEAN DEFAULT FALSE NOT NULL;
+ALTER TABLE "user_info_field" ADD COLUMN IF NOT EXISTS "enum_values" VARCHAR[] DEFAULT '{}' NOT NULL;
+ALTER TABLE "user_info_field" ADD COLUMN IF NOT EXISTS "pattern" VARCHAR DEFAULT '' NOT NULL;
+ALTER TABLE "user_info_field" ADD COLUMN IF NOT EXISTS "min_value" DOUBLE PRECISION;
+ALTER TABLE "user_info_field" ADD COLUMN IF NOT EXISTS "max_value" DOUBLE PRECISION;
+ALTER TABLE "user_info_field" ADD COLUMN IF NOT EXISTS "default_value" JSONB;
+
+-- user info is keyed by field name, so a client type can't have two fields with the same name
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_info_field_client_type_id_field_name" ON "user_info_field"("client_type_id", "field_name");
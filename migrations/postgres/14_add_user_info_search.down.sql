@@ -0,0 +1,12 @@
+DO $$
+DECLARE
+    idx RECORD;
+BEGIN
+    FOR idx IN SELECT indexname FROM pg_indexes WHERE tablename = 'user_info' AND indexname LIKE 'idx_user_info_search_%' LOOP
+        EXECUTE format('DROP INDEX IF EXISTS %I', idx.indexname);
+    END LOOP;
+END $$;
+
+DROP INDEX IF EXISTS "idx_user_info_data";
+
+ALTER TABLE "user_info_field" DROP COLUMN IF EXISTS "searchable";
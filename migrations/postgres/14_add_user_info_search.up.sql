@@ -0,0 +1,49 @@
+ALTER TABLE "user_info_field" ADD COLUMN IF NOT EXISTS "searchable" BOOLEAN DEFAULT FALSE NOT NULL;
+
+-- rows written before user info was stored with protojson hold the go encoding of structpb.Struct,
+-- {"fields":{"x":{"Kind":{"StringValue":"a"}}}}, rewrite them as plain json so filters and the index see them
+CREATE OR REPLACE FUNCTION "user_info_legacy_value"("value" JSONB) RETURNS JSONB AS $$
+DECLARE
+    kind JSONB := NULLIF("value" -> 'Kind', 'null'::JSONB);
+BEGIN
+    IF kind IS NULL OR kind ? 'NullValue' THEN
+        RETURN 'null'::JSONB;
+    ELSIF kind ? 'StringValue' THEN
+        RETURN kind -> 'StringValue';
+    ELSIF kind ? 'NumberValue' THEN
+        RETURN kind -> 'NumberValue';
+    ELSIF kind ? 'BoolValue' THEN
+        RETURN kind -> 'BoolValue';
+    ELSIF kind ? 'StructValue' THEN
+        RETURN COALESCE((
+            SELECT jsonb_object_agg(f.key, "user_info_legacy_value"(f.value))
+            FROM jsonb_each(COALESCE(NULLIF(kind -> 'StructValue', 'null'::JSONB) -> 'fields', '{}'::JSONB)) AS f
+        ), '{}'::JSONB);
+    ELSIF kind ? 'ListValue' THEN
+        RETURN COALESCE((
+            SELECT jsonb_agg("user_info_legacy_value"(e.value) ORDER BY e.ordinality)
+            FROM jsonb_array_elements(COALESCE(NULLIF(kind -> 'ListValue', 'null'::JSONB) -> 'values', '[]'::JSONB)) WITH ORDINALITY AS e
+        ), '[]'::JSONB);
+    END IF;
+
+    RETURN 'null'::JSONB;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+UPDATE "user_info" SET
+    "data" = COALESCE((
+        SELECT jsonb_object_agg(f.key, "user_info_legacy_value"(f.value))
+        FROM jsonb_each("data" -> 'fields') AS f
+    ), '{}'::JSONB)
+WHERE
+    jsonb_typeof("data") = 'object'
+    AND jsonb_typeof("data" -> 'fields') = 'object'
+    AND (SELECT COUNT(*) FROM jsonb_object_keys("data")) = 1
+    AND NOT EXISTS (
+        SELECT 1 FROM jsonb_each("data" -> 'fields') AS f
+        WHERE jsonb_typeof(f.value) <> 'object' OR NOT f.value ? 'Kind'
+    );
+
+DROP FUNCTION "user_info_legacy_value"(JSONB);
+
+CREATE INDEX IF NOT EXISTS "idx_user_info_data" ON "user_info" USING GIN ("data" jsonb_path_ops);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS "idx_user_deleted_at";
+
+-- fails while a deleted user shares a phone, email or login with another user, they have to be purged first
+DROP INDEX IF EXISTS "idx_user_login";
+DROP INDEX IF EXISTS "idx_user_email";
+DROP INDEX IF EXISTS "idx_user_phone";
+ALTER TABLE "user" ADD CONSTRAINT "user_project_id_client_platform_id_login_key" UNIQUE ("project_id", "client_platform_id", "login");
+ALTER TABLE "user" ADD CONSTRAINT "user_project_id_client_platform_id_email_key" UNIQUE ("project_id", "client_platform_id", "email");
+ALTER TABLE "user" ADD CONSTRAINT "user_project_id_client_platform_id_phone_key" UNIQUE ("project_id", "client_platform_id", "phone");
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "anonymized_at";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "deleted_at";
@@ -0,0 +1,14 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMP;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "anonymized_at" TIMESTAMP;
+
+UPDATE "user" SET "deleted_at" = "updated_at" WHERE "state" = 'DELETED';
+
+-- a deleted user keeps its phone, email and login until it is anonymized, only live users have to be unique
+ALTER TABLE "user" DROP CONSTRAINT IF EXISTS "user_project_id_client_platform_id_phone_key";
+ALTER TABLE "user" DROP CONSTRAINT IF EXISTS "user_project_id_client_platform_id_email_key";
+ALTER TABLE "user" DROP CONSTRAINT IF EXISTS "user_project_id_client_platform_id_login_key";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_phone" ON "user"("project_id", "client_platform_id", "phone") WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_email" ON "user"("project_id", "client_platform_id", "email") WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_login" ON "user"("project_id", "client_platform_id", "login") WHERE "deleted_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_user_deleted_at" ON "user"("deleted_at") WHERE "deleted_at" IS NOT NULL AND "anonymized_at" IS NULL;
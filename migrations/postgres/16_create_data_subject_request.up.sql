@@ -0,0 +1,13 @@
+-- every export and erasure of personal data is kept with its signed receipt, the rows carry no
+-- personal data themselves so they outlive the erasure they record
+CREATE TABLE IF NOT EXISTS "data_subject_request" (
+    "id" UUID PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES "user"("id"),
+    "action" VARCHAR NOT NULL,
+    "digest" VARCHAR NOT NULL,
+    "requested_by" VARCHAR NOT NULL DEFAULT '',
+    "reason" VARCHAR NOT NULL DEFAULT '',
+    "signature" VARCHAR NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX "idx_data_subject_request_user_id" ON "data_subject_request"("user_id");
@@ -0,0 +1,7 @@
+-- the original formatting of contacts isn't kept, only the constraints are dropped
+ALTER TABLE "user" DROP CONSTRAINT IF EXISTS "user_email_normalized";
+ALTER TABLE "user" DROP CONSTRAINT IF EXISTS "user_phone_e164";
+
+-- phones cleared by the migration are put back unless the user has got a new one
+UPDATE "user" u SET "phone" = p."phone" FROM "user_unnormalized_phone" p WHERE u."id" = p."user_id" AND u."phone" IS NULL;
+DROP TABLE IF EXISTS "user_unnormalized_phone";
@@ -0,0 +1,67 @@
+-- phones are brought to E.164 and emails are trimmed and lowercased, numbers written without a calling
+-- code are read as numbers of the default region, its calling code can be set before migrating with
+-- ALTER DATABASE ... SET auth.default_phone_calling_code = '998'.
+-- Phones that can't be normalized are cleared and kept in "user_unnormalized_phone" to be corrected by hand
+-- A missing phone or email is NULL, as the service writes it, so the unique indexes skip it, readers use COALESCE
+CREATE TABLE IF NOT EXISTS "user_unnormalized_phone" (
+    "user_id" UUID PRIMARY KEY REFERENCES "user"("id") ON DELETE CASCADE,
+    "phone" VARCHAR NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+DO $$
+DECLARE
+    calling_code VARCHAR := COALESCE(NULLIF(current_setting('auth.default_phone_calling_code', true), ''), '998');
+    conflicts TEXT;
+BEGIN
+    CREATE TEMP TABLE "user_contact" AS
+    SELECT
+        "id",
+        "project_id",
+        "client_platform_id",
+        "deleted_at",
+        CASE
+            WHEN "p" = '' THEN NULL
+            WHEN "p" ~ '^\+[1-9][0-9]{6,14}$' THEN "p"
+            WHEN "p" ~ '^00[1-9][0-9]{6,14}$' THEN '+' || substr("p", 3)
+            WHEN "p" ~ '^[1-9][0-9]{10,14}$' AND "p" LIKE calling_code || '%' THEN '+' || "p"
+            WHEN "p" ~ '^[0-9]+$' AND length(calling_code || "p") BETWEEN 7 AND 15 THEN '+' || calling_code || "p"
+            ELSE NULL
+        END AS "phone",
+        NULLIF(lower(btrim("email")), '') AS "email"
+    FROM (
+        SELECT *, regexp_replace("phone", '[[:space:]().-]', '', 'g') AS "p" FROM "user"
+    ) u;
+
+    SELECT string_agg("contact", ', ') INTO conflicts FROM (
+        SELECT "phone" AS "contact" FROM "user_contact" WHERE "phone" IS NOT NULL AND "deleted_at" IS NULL
+        GROUP BY "project_id", "client_platform_id", "phone" HAVING count(*) > 1
+        UNION ALL
+        SELECT "email" FROM "user_contact" WHERE "email" IS NOT NULL AND "deleted_at" IS NULL
+        GROUP BY "project_id", "client_platform_id", "email" HAVING count(*) > 1
+        LIMIT 50
+    ) c;
+
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'users share contacts once they are normalized, merge or change them before migrating: %', conflicts;
+    END IF;
+
+    INSERT INTO "user_unnormalized_phone" ("user_id", "phone")
+    SELECT u."id", u."phone"
+    FROM "user" u
+    INNER JOIN "user_contact" c ON c."id" = u."id"
+    WHERE btrim(u."phone") <> '' AND c."phone" IS NULL
+    ON CONFLICT ("user_id") DO NOTHING;
+
+    UPDATE "user" u SET
+        "phone" = c."phone",
+        "email" = c."email"
+    FROM "user_contact" c
+    WHERE u."id" = c."id" AND (u."phone" IS DISTINCT FROM c."phone" OR u."email" IS DISTINCT FROM c."email");
+
+    DROP TABLE "user_contact";
+END $$;
+
+-- the unique indexes on phone and email now hold for the normalized values, these keep writes normalized
+ALTER TABLE "user" ADD CONSTRAINT "user_phone_e164" CHECK ("phone" ~ '^\+[1-9][0-9]{6,14}$');
+ALTER TABLE "user" ADD CONSTRAINT "user_email_normalized" CHECK ("email" = lower(btrim("email")));
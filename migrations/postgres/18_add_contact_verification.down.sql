@@ -0,0 +1,7 @@
+ALTER TABLE "passcode" DROP COLUMN IF EXISTS "target";
+ALTER TABLE "passcode" DROP COLUMN IF EXISTS "purpose";
+
+ALTER TABLE "client_type" DROP COLUMN IF EXISTS "require_verified_contact";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "phone_verified_at";
+ALTER TABLE "user" DROP COLUMN IF EXISTS "email_verified_at";
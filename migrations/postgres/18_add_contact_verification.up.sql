@@ -0,0 +1,9 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "email_verified_at" TIMESTAMP;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "phone_verified_at" TIMESTAMP;
+
+-- confirm_by of the client type tells which contact has to be verified, UNDECIDED accepts either
+ALTER TABLE "client_type" ADD COLUMN IF NOT EXISTS "require_verified_contact" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- a code is good only for what it was sent for, target is the contact it was sent to
+ALTER TABLE "passcode" ADD COLUMN IF NOT EXISTS "purpose" VARCHAR NOT NULL DEFAULT 'REAUTH';
+ALTER TABLE "passcode" ADD COLUMN IF NOT EXISTS "target" VARCHAR NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- a role has the permissions of its parents and of their parents, the service keeps the graph acyclic
+CREATE TABLE IF NOT EXISTS "role_parent" (
+    "role_id" UUID NOT NULL REFERENCES "role"("id") ON DELETE CASCADE,
+    "parent_id" UUID NOT NULL REFERENCES "role"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY ("role_id", "parent_id"),
+    CHECK ("role_id" <> "parent_id")
+);
+CREATE INDEX "idx_role_parent_parent_id" ON "role_parent"("parent_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "session" DROP COLUMN IF EXISTS "active_role_only";
+
+DROP TABLE IF EXISTS "user_role";
@@ -0,0 +1,15 @@
+-- roles a user has besides the primary one in user.role_id, each counts only between its dates
+CREATE TABLE IF NOT EXISTS "user_role" (
+    "user_id" UUID NOT NULL REFERENCES "user"("id") ON DELETE CASCADE,
+    "role_id" UUID NOT NULL REFERENCES "role"("id") ON DELETE CASCADE,
+    "starts_at" TIMESTAMP,
+    "ends_at" TIMESTAMP,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY ("user_id", "role_id"),
+    CHECK ("starts_at" IS NULL OR "ends_at" IS NULL OR "starts_at" < "ends_at")
+);
+CREATE INDEX "idx_user_role_role_id" ON "user_role"("role_id");
+
+-- a session opened with a chosen role acts with that role only, otherwise with every role of the user
+ALTER TABLE "session" ADD COLUMN IF NOT EXISTS "active_role_only" BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,16 @@
+-- permissions granted to a user directly for a limited time, grants that ended are kept as the record of who had what,
+-- permission_name is the path of the permission at the time of the grant, so the record outlives the permission
+CREATE TABLE IF NOT EXISTS "user_permission" (
+    "id" UUID PRIMARY KEY,
+    "user_id" UUID NOT NULL REFERENCES "user"("id") ON DELETE CASCADE,
+    "permission_id" UUID REFERENCES "permission"("id") ON DELETE SET NULL,
+    "permission_name" VARCHAR NOT NULL,
+    "starts_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "ends_at" TIMESTAMP NOT NULL,
+    "reason" VARCHAR NOT NULL,
+    "granted_by" UUID NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CHECK ("starts_at" < "ends_at")
+);
+CREATE INDEX "idx_user_permission_user_id" ON "user_permission"("user_id");
+CREATE INDEX "idx_user_permission_ends_at" ON "user_permission"("ends_at");
@@ -0,0 +1,17 @@
+-- deny rules override every allow, each is given to a role or to a user and for a permission or a scope pattern
+CREATE TABLE IF NOT EXISTS "deny_rule" (
+    "id" UUID PRIMARY KEY,
+    "client_platform_id" UUID NOT NULL REFERENCES "client_platform"("id") ON DELETE CASCADE,
+    "role_id" UUID REFERENCES "role"("id") ON DELETE CASCADE,
+    "user_id" UUID REFERENCES "user"("id") ON DELETE CASCADE,
+    "permission_id" UUID REFERENCES "permission"("id") ON DELETE CASCADE,
+    "method" VARCHAR,
+    "path_pattern" VARCHAR,
+    "reason" VARCHAR DEFAULT '' NOT NULL,
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CHECK (("role_id" IS NULL) <> ("user_id" IS NULL)),
+    CHECK (("permission_id" IS NULL) <> ("path_pattern" IS NULL)),
+    CHECK (("method" IS NULL) = ("path_pattern" IS NULL))
+);
+CREATE INDEX "idx_deny_rule_role_id" ON "deny_rule"("role_id");
+CREATE INDEX "idx_deny_rule_user_id" ON "deny_rule"("user_id");
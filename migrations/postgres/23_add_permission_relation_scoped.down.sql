@@ -0,0 +1 @@
+ALTER TABLE "permission" DROP COLUMN IF EXISTS "relation_scoped";
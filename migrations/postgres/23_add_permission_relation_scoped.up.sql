@@ -0,0 +1,2 @@
+-- a relation scoped permission allows a request only for resources of the relations of the user
+ALTER TABLE "permission" ADD COLUMN IF NOT EXISTS "relation_scoped" BOOLEAN NOT NULL DEFAULT FALSE;
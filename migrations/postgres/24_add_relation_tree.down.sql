@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "idx_relation_path";
+DROP INDEX IF EXISTS "idx_relation_parent_id";
+ALTER TABLE "relation" DROP COLUMN IF EXISTS "path";
+ALTER TABLE "relation" DROP COLUMN IF EXISTS "parent_id";
+
+-- enum values can't be dropped, POINT_OF_SALE stays in relation_types
@@ -0,0 +1,9 @@
+ALTER TYPE relation_types ADD VALUE IF NOT EXISTS 'POINT_OF_SALE';
+
+-- relations form a tree, path is the ids from the root down to the relation itself as /root-id/.../id/
+-- so a subtree is every relation whose path starts with the path of its root
+ALTER TABLE "relation" ADD COLUMN IF NOT EXISTS "parent_id" UUID REFERENCES "relation"("id") ON DELETE RESTRICT;
+ALTER TABLE "relation" ADD COLUMN IF NOT EXISTS "path" VARCHAR NOT NULL DEFAULT '';
+UPDATE "relation" SET "path" = '/' || "id" || '/';
+CREATE INDEX "idx_relation_parent_id" ON "relation"("parent_id");
+CREATE INDEX "idx_relation_path" ON "relation"("path" varchar_pattern_ops);
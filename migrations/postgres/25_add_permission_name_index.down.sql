@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "idx_permission_client_platform_id_parent_id_name";
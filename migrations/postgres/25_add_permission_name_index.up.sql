@@ -0,0 +1,7 @@
+-- a permission is found by its parent and name in the tree, so siblings can't share a name,
+-- duplicates have to be merged by hand before migrating
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_permission_client_platform_id_parent_id_name" ON "permission"(
+    "client_platform_id",
+    COALESCE("parent_id", '00000000-0000-0000-0000-000000000000'::UUID),
+    "name"
+);
@@ -0,0 +1,212 @@
+// Package access decides whether a session may call a scope, a method and a path of a client platform.
+//
+// The evaluation order is:
+//
+//  1. The subject is the roles of the session with all their ancestors, and the user.
+//  2. Deny rules of any of those roles or of the user are checked first, in the order they are given.
+//     A rule for a permission matches every scope of that permission, a rule for a scope pattern
+//     matches by method and path pattern. The first matching rule denies the request, whatever allows it.
+//  3. Otherwise the request is allowed by a permission having the scope that is granted to one of
+//     the roles, the nearest role first, or granted to the user directly.
+//  4. A relation scoped permission allows only when the user has relations and the relation of the
+//     requested resource, if the caller gives one, is among them. Without a resource relation the
+//     caller has to limit the request to the relations of the user. A permission that isn't relation
+//     scoped is preferred to a relation scoped one.
+//  5. Anything not allowed is denied.
+package access
+
+import (
+	"path"
+	"strings"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+)
+
+// Policy is what one request is decided by, storage loads only the part relevant to the request
+type Policy struct {
+	// ScopePermissions are the permissions having the requested scope
+	ScopePermissions []string
+	// RoleParents are the direct parents of the roles and of their ancestors
+	RoleParents map[string][]string
+	// RolePermissions are the permissions granted to the roles and to their ancestors
+	RolePermissions map[string][]string
+	// UserPermissions are the permissions granted to the user in effect now
+	UserPermissions []string
+	// RelationScoped are the relation scoped ones of ScopePermissions
+	RelationScoped map[string]bool
+	// RecentAuthMinutes are the re-authentication windows of ScopePermissions requiring one
+	RecentAuthMinutes map[string]int32
+	// UserRelations are the relations of the user
+	UserRelations []string
+	// Denies are the deny rules of the roles, their ancestors and the user
+	Denies []*pb.DenyRule
+}
+
+type Decision struct {
+	Allowed bool
+	// PermissionID is the permission allowing the request
+	PermissionID string
+	// RoleID is the role the permission is granted to, empty for a grant to the user
+	RoleID string
+	// RelationScoped is set when the permission is relation scoped, the request is allowed for
+	// resources of the relations of the user only
+	RelationScoped bool
+	// DenyRule is the rule the request is denied by, nil if nothing allows it either
+	DenyRule *pb.DenyRule
+}
+
+// Evaluate decides the request of a session acting with roleIDs for a resource of relationID, which may
+// be empty, see the package comment for the order
+func Evaluate(policy *Policy, roleIDs []string, method, path, relationID string) Decision {
+	roles := Ancestors(policy.RoleParents, roleIDs)
+
+	inRoles := toSet(roles)
+	inScope := toSet(policy.ScopePermissions)
+
+	for _, rule := range policy.Denies {
+		if matchDeny(rule, inRoles, inScope, method, path) {
+			return Decision{DenyRule: rule}
+		}
+	}
+
+	inRelations := toSet(policy.UserRelations)
+
+	var scoped *Decision
+	allow := func(permission, role string) bool {
+		if !inScope[permission] {
+			return false
+		}
+
+		if !policy.RelationScoped[permission] {
+			return true
+		}
+
+		if scoped == nil && len(inRelations) > 0 && (len(relationID) == 0 || inRelations[relationID]) {
+			scoped = &Decision{Allowed: true, PermissionID: permission, RoleID: role, RelationScoped: true}
+		}
+
+		return false
+	}
+
+	for _, role := range roles {
+		for _, permission := range policy.RolePermissions[role] {
+			if allow(permission, role) {
+				return Decision{Allowed: true, PermissionID: permission, RoleID: role}
+			}
+		}
+	}
+
+	for _, permission := range policy.UserPermissions {
+		if allow(permission, "") {
+			return Decision{Allowed: true, PermissionID: permission}
+		}
+	}
+
+	if scoped != nil {
+		return *scoped
+	}
+
+	return Decision{}
+}
+
+// matchDeny reports whether the rule applies to the subject and matches the request
+func matchDeny(rule *pb.DenyRule, inRoles, inScope map[string]bool, method, path string) bool {
+	if len(rule.RoleId) > 0 && !inRoles[rule.RoleId] {
+		return false
+	}
+
+	return (len(rule.PermissionId) > 0 && inScope[rule.PermissionId]) ||
+		(len(rule.PathPattern) > 0 && MatchScope(rule.Method, rule.PathPattern, method, path))
+}
+
+// Ancestors returns the roles followed by their ancestors, nearest first and each once
+func Ancestors(parents map[string][]string, roleIDs []string) []string {
+	res := []string{}
+	seen := map[string]bool{}
+
+	queue := append([]string{}, roleIDs...)
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+
+		if seen[role] {
+			continue
+		}
+		seen[role] = true
+
+		res = append(res, role)
+		queue = append(queue, parents[role]...)
+	}
+
+	return res
+}
+
+// MatchScope reports whether a method and a path match a deny pattern. The method * matches any method,
+// in the path pattern * matches one segment or a part of it and a trailing ** matches the rest of the path
+func MatchScope(methodPattern, pathPattern, method, requestPath string) bool {
+	if methodPattern != "*" && !strings.EqualFold(methodPattern, method) {
+		return false
+	}
+
+	patternSegments := strings.Split(strings.Trim(pathPattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(requestPath, "/"), "/")
+
+	for i, segment := range patternSegments {
+		if segment == "**" && i == len(patternSegments)-1 {
+			return true
+		}
+
+		if i >= len(pathSegments) {
+			return false
+		}
+
+		ok, err := path.Match(segment, pathSegments[i])
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	return len(patternSegments) == len(pathSegments)
+}
+
+// ValidatePattern reports whether the path pattern can be matched, ** is only allowed as the last segment
+func ValidatePattern(pathPattern string) bool {
+	if !strings.HasPrefix(pathPattern, "/") {
+		return false
+	}
+
+	segments := strings.Split(strings.Trim(pathPattern, "/"), "/")
+	for i, segment := range segments {
+		if segment == "**" {
+			if i != len(segments)-1 {
+				return false
+			}
+			continue
+		}
+
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterDenied drops the permissions a deny rule is given for, rules for scope patterns don't
+// map to permissions and only apply when a request is decided
+func FilterDenied(permissions []*pb.Permission, denies []*pb.DenyRule) []*pb.Permission {
+	denied := map[string]bool{}
+	for _, rule := range denies {
+		if len(rule.PermissionId) > 0 {
+			denied[rule.PermissionId] = true
+		}
+	}
+
+	res := make([]*pb.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if !denied[permission.Id] {
+			res = append(res, permission)
+		}
+	}
+
+	return res
+}
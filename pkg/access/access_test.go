@@ -0,0 +1,379 @@
+package access_test
+
+import (
+	"testing"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/access"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the request is GET /reports/2022/summary, which the permissions read and audit have as a scope
+func accessPolicy() *access.Policy {
+	return &access.Policy{
+		ScopePermissions: []string{"read", "audit"},
+		RoleParents: map[string][]string{
+			"manager": {"employee"},
+			"intern":  {"employee"},
+		},
+		RolePermissions: map[string][]string{
+			"employee": {"read"},
+			"auditor":  {"audit"},
+		},
+	}
+}
+
+func TestAccessEvaluate(t *testing.T) {
+	cases := []struct {
+		name       string
+		roles      []string
+		relation   string
+		change     func(policy *access.Policy)
+		allowed    bool
+		permission string
+		role       string
+		scoped     bool
+		denyRule   string
+	}{
+		{
+			name:       "allowed by a permission of the role",
+			roles:      []string{"employee"},
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+		},
+		{
+			name:       "allowed by a permission inherited from the parent",
+			roles:      []string{"manager"},
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+		},
+		{
+			name:  "denied when no role has a permission with the scope",
+			roles: []string{"guest"},
+		},
+		{
+			name:       "allowed by one of several roles",
+			roles:      []string{"guest", "auditor"},
+			allowed:    true,
+			permission: "audit",
+			role:       "auditor",
+		},
+		{
+			name:  "allowed by a grant to the user",
+			roles: []string{"guest"},
+			change: func(policy *access.Policy) {
+				policy.UserPermissions = []string{"audit"}
+			},
+			allowed:    true,
+			permission: "audit",
+		},
+		{
+			name:  "denied by a permission rule of the role",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "employee", PermissionId: "read"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "denied by a rule inherited from the parent",
+			roles: []string{"manager"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "employee", PermissionId: "read"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "a rule of a role the session doesn't act with doesn't apply",
+			roles: []string{"manager"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "intern", PermissionId: "read"}}
+			},
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+		},
+		{
+			name:  "a permission rule overrides the allow of another permission with the scope",
+			roles: []string{"employee", "auditor"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "auditor", PermissionId: "audit"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "a permission rule for a permission without the scope doesn't apply",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "employee", PermissionId: "write"}}
+			},
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+		},
+		{
+			name:  "denied by a rule of the user over a grant to the user",
+			roles: []string{"guest"},
+			change: func(policy *access.Policy) {
+				policy.UserPermissions = []string{"audit"}
+				policy.Denies = []*pb.DenyRule{{Id: "deny", UserId: "user", PermissionId: "audit"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "denied by a rule of the user over the role",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", UserId: "user", Method: "GET", PathPattern: "/reports/2022/summary"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "denied by a segment wildcard",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "employee", Method: "GET", PathPattern: "/reports/*/summary"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "denied by a trailing wildcard and any method",
+			roles: []string{"manager"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "employee", Method: "*", PathPattern: "/reports/**"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "denied by a wildcard within a segment",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", UserId: "user", Method: "get", PathPattern: "/reports/20*/summary"}}
+			},
+			denyRule: "deny",
+		},
+		{
+			name:  "a wildcard of another method doesn't apply",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "employee", Method: "DELETE", PathPattern: "/reports/**"}}
+			},
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+		},
+		{
+			name:  "a segment wildcard matches one segment only",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{{Id: "deny", RoleId: "employee", Method: "GET", PathPattern: "/reports/*"}}
+			},
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+		},
+		{
+			name:  "the first matching rule is reported",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.Denies = []*pb.DenyRule{
+					{Id: "other", RoleId: "employee", Method: "POST", PathPattern: "/**"},
+					{Id: "first", UserId: "user", Method: "*", PathPattern: "/**"},
+					{Id: "second", RoleId: "employee", PermissionId: "read"},
+				}
+			},
+			denyRule: "first",
+		},
+		{
+			name:  "a relation scoped permission allows for a resource of a relation of the user",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.RelationScoped = map[string]bool{"read": true}
+				policy.UserRelations = []string{"tashkent", "samarkand"}
+			},
+			relation:   "samarkand",
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+			scoped:     true,
+		},
+		{
+			name:  "a relation scoped permission denies for a resource of another relation",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.RelationScoped = map[string]bool{"read": true}
+				policy.UserRelations = []string{"tashkent"}
+			},
+			relation: "bukhara",
+		},
+		{
+			name:  "a relation scoped permission without a resource relation leaves filtering to the caller",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.RelationScoped = map[string]bool{"read": true}
+				policy.UserRelations = []string{"tashkent"}
+			},
+			allowed:    true,
+			permission: "read",
+			role:       "employee",
+			scoped:     true,
+		},
+		{
+			name:  "a relation scoped permission denies a user without relations",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.RelationScoped = map[string]bool{"read": true}
+			},
+		},
+		{
+			name:  "a permission that isn't relation scoped is preferred",
+			roles: []string{"employee", "auditor"},
+			change: func(policy *access.Policy) {
+				policy.RelationScoped = map[string]bool{"read": true}
+				policy.UserRelations = []string{"tashkent"}
+			},
+			relation:   "bukhara",
+			allowed:    true,
+			permission: "audit",
+			role:       "auditor",
+		},
+		{
+			name:  "a deny rule overrides a relation scoped allow",
+			roles: []string{"employee"},
+			change: func(policy *access.Policy) {
+				policy.RelationScoped = map[string]bool{"read": true}
+				policy.UserRelations = []string{"tashkent"}
+				policy.Denies = []*pb.DenyRule{{Id: "deny", UserId: "user", Method: "GET", PathPattern: "/reports/**"}}
+			},
+			relation: "tashkent",
+			denyRule: "deny",
+		},
+		{
+			name:  "a cycle in the parents doesn't loop",
+			roles: []string{"guest"},
+			change: func(policy *access.Policy) {
+				policy.RoleParents["guest"] = []string{"visitor"}
+				policy.RoleParents["visitor"] = []string{"guest"}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := accessPolicy()
+			if c.change != nil {
+				c.change(policy)
+			}
+
+			decision := access.Evaluate(policy, c.roles, "GET", "/reports/2022/summary", c.relation)
+
+			assert.Equal(t, c.allowed, decision.Allowed)
+			assert.Equal(t, c.permission, decision.PermissionID)
+			assert.Equal(t, c.role, decision.RoleID)
+			assert.Equal(t, c.scoped, decision.RelationScoped)
+
+			if len(c.denyRule) > 0 {
+				if assert.NotNil(t, decision.DenyRule) {
+					assert.Equal(t, c.denyRule, decision.DenyRule.Id)
+				}
+			} else {
+				assert.Nil(t, decision.DenyRule)
+			}
+		})
+	}
+}
+
+func TestAccessMatchScope(t *testing.T) {
+	cases := []struct {
+		method  string
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"GET", "/users", "/users", true},
+		{"GET", "/users", "/users/", true},
+		{"GET", "/users", "/users/1", false},
+		{"GET", "/users/*", "/users/1", true},
+		{"GET", "/users/*", "/users", false},
+		{"GET", "/users/**", "/users", true},
+		{"GET", "/users/**", "/users/1/sessions", true},
+		{"GET", "/**", "/", true},
+		{"*", "/users/*/sessions", "/users/1/sessions", true},
+		{"POST", "/users/*/sessions", "/users/1/sessions", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.match, access.MatchScope(c.method, c.pattern, "GET", c.path), c.method+" "+c.pattern+" on "+c.path)
+	}
+
+	assert.True(t, access.ValidatePattern("/users/*/sessions/**"))
+	assert.False(t, access.ValidatePattern("/users/**/sessions"))
+	assert.False(t, access.ValidatePattern("users"))
+	assert.False(t, access.ValidatePattern("/users/[a"))
+}
+
+func TestAccessExplain(t *testing.T) {
+	policy := accessPolicy()
+	policy.UserPermissions = []string{"audit"}
+	policy.Denies = []*pb.DenyRule{
+		{Id: "other", RoleId: "auditor", PermissionId: "audit"},
+		{Id: "first", RoleId: "employee", Method: "GET", PathPattern: "/reports/**"},
+		{Id: "second", UserId: "user", PermissionId: "read"},
+		{Id: "post", UserId: "user", Method: "POST", PathPattern: "/reports/**"},
+	}
+
+	trace := access.Explain(policy, []string{"manager"}, "GET", "/reports/2022/summary", "")
+
+	assert.False(t, trace.Allowed)
+	if assert.NotNil(t, trace.DenyRule) {
+		assert.Equal(t, "first", trace.DenyRule.Id)
+	}
+	assert.Equal(t, []string{"manager", "employee"}, trace.Roles)
+	assert.Equal(t, map[string][]string{"read": {"employee"}}, trace.Grants)
+	assert.Equal(t, []string{"audit"}, trace.UserGrants)
+
+	denies := []string{}
+	for _, rule := range trace.Denies {
+		denies = append(denies, rule.Id)
+	}
+	assert.Equal(t, []string{"first", "second"}, denies)
+
+	assert.Equal(t, map[string][]string{"audit": {"auditor"}, "read": {"employee"}}, access.Grants(policy, []string{"auditor", "intern"}))
+}
+
+func TestAccessEvaluateRole(t *testing.T) {
+	policy := accessPolicy()
+	policy.Denies = []*pb.DenyRule{
+		{Id: "intern", RoleId: "intern", PermissionId: "read"},
+		{Id: "pattern", RoleId: "auditor", Method: "GET", PathPattern: "/reports/**"},
+		{Id: "user", UserId: "user", PermissionId: "read"},
+	}
+
+	cell := access.EvaluateRole(policy, "manager", []string{"read"}, "", "")
+	assert.True(t, cell.Allowed)
+	assert.True(t, cell.Granted)
+	assert.Equal(t, "employee", cell.RoleID)
+
+	cell = access.EvaluateRole(policy, "intern", []string{"read"}, "", "")
+	assert.False(t, cell.Allowed)
+	assert.True(t, cell.Granted)
+	assert.Equal(t, "read", cell.PermissionID)
+	assert.Equal(t, "employee", cell.RoleID)
+	if assert.NotNil(t, cell.DenyRule) {
+		assert.Equal(t, "intern", cell.DenyRule.Id)
+	}
+
+	// a pattern rule applies to scopes only
+	cell = access.EvaluateRole(policy, "auditor", []string{"audit"}, "", "")
+	assert.True(t, cell.Allowed)
+
+	cell = access.EvaluateRole(policy, "auditor", []string{"read", "audit"}, "GET", "/reports/2022/summary")
+	assert.False(t, cell.Allowed)
+	assert.True(t, cell.Granted)
+	assert.Equal(t, "audit", cell.PermissionID)
+
+	cell = access.EvaluateRole(policy, "guest", []string{"read", "audit"}, "GET", "/reports/2022/summary")
+	assert.False(t, cell.Granted)
+}
@@ -0,0 +1,71 @@
+package access
+
+import (
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+)
+
+// Trace is the decision with everything that took part in it
+type Trace struct {
+	Decision
+	// Roles are the roles the request was evaluated with followed by their ancestors, nearest first
+	Roles []string
+	// Grants are the roles granting each permission of the scope, nearest first
+	Grants map[string][]string
+	// UserGrants are the permissions of the scope granted to the user directly
+	UserGrants []string
+	// Denies are all the deny rules matching the request, Decision.DenyRule is the first of them
+	Denies []*pb.DenyRule
+}
+
+// Explain evaluates the request like Evaluate and collects what every step of it saw,
+// not just what decided it
+func Explain(policy *Policy, roleIDs []string, method, path, relationID string) Trace {
+	trace := Trace{
+		Decision: Evaluate(policy, roleIDs, method, path, relationID),
+		Roles:    Ancestors(policy.RoleParents, roleIDs),
+		Grants:   Grants(policy, roleIDs),
+	}
+
+	inRoles := toSet(trace.Roles)
+	inScope := toSet(policy.ScopePermissions)
+
+	for _, rule := range policy.Denies {
+		if matchDeny(rule, inRoles, inScope, method, path) {
+			trace.Denies = append(trace.Denies, rule)
+		}
+	}
+
+	for _, permission := range policy.UserPermissions {
+		if inScope[permission] {
+			trace.UserGrants = append(trace.UserGrants, permission)
+		}
+	}
+
+	return trace
+}
+
+// Grants returns the roles among roleIDs and their ancestors granting each permission of the scope,
+// nearest first, whatever deny rules and relations say
+func Grants(policy *Policy, roleIDs []string) map[string][]string {
+	res := map[string][]string{}
+	inScope := toSet(policy.ScopePermissions)
+
+	for _, role := range Ancestors(policy.RoleParents, roleIDs) {
+		for _, permission := range policy.RolePermissions[role] {
+			if inScope[permission] {
+				res[permission] = append(res[permission], role)
+			}
+		}
+	}
+
+	return res
+}
+
+func toSet(values []string) map[string]bool {
+	res := make(map[string]bool, len(values))
+	for _, value := range values {
+		res[value] = true
+	}
+
+	return res
+}
@@ -0,0 +1,50 @@
+package access
+
+// MatrixCell is whether a role may use a permission or a scope
+type MatrixCell struct {
+	Decision
+	// Granted is set when the role or one of its ancestors has one of the permissions, a deny rule may
+	// still override it, PermissionID and RoleID of a denied cell are then the grant overridden
+	Granted bool
+}
+
+// EvaluateRole decides for the role alone with its ancestors whether it may use one of the permissions,
+// deny rules of the roles apply the way Evaluate applies them and rules of users and relations don't.
+// policy holds the parents, permissions and rules of all the roles, its other fields are ignored.
+// With an empty method and path the permissions are decided by themselves and only rules for permissions apply.
+func EvaluateRole(policy *Policy, roleID string, permissionIDs []string, method, path string) MatrixCell {
+	rolePolicy := &Policy{
+		ScopePermissions: permissionIDs,
+		RoleParents:      policy.RoleParents,
+		RolePermissions:  policy.RolePermissions,
+	}
+
+	for _, rule := range policy.Denies {
+		if len(rule.RoleId) == 0 || (len(path) == 0 && len(rule.PermissionId) == 0) {
+			continue
+		}
+
+		rolePolicy.Denies = append(rolePolicy.Denies, rule)
+	}
+
+	cell := MatrixCell{
+		Decision: Evaluate(rolePolicy, []string{roleID}, method, path, ""),
+	}
+
+	grants := Grants(rolePolicy, []string{roleID})
+	for _, permissionID := range permissionIDs {
+		roles := grants[permissionID]
+		if len(roles) == 0 {
+			continue
+		}
+
+		cell.Granted = true
+		if !cell.Allowed {
+			cell.PermissionID = permissionID
+			cell.RoleID = roles[0]
+		}
+		break
+	}
+
+	return cell
+}
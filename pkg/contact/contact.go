@@ -0,0 +1,173 @@
+package contact
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// region is what a national number needs to become international
+type region struct {
+	callingCode string
+	trunkPrefix string
+	// nationalLengths are the lengths of the national significant number
+	nationalLengths []int
+}
+
+// regions are the regions a default can be set to, numbers of other regions must be
+// written with their calling code
+var regions = map[string]region{
+	"UZ": {callingCode: "998", trunkPrefix: "8", nationalLengths: []int{9}},
+	"KZ": {callingCode: "7", trunkPrefix: "8", nationalLengths: []int{10}},
+	"RU": {callingCode: "7", trunkPrefix: "8", nationalLengths: []int{10}},
+	"KG": {callingCode: "996", trunkPrefix: "0", nationalLengths: []int{9}},
+	"TJ": {callingCode: "992", trunkPrefix: "8", nationalLengths: []int{9}},
+	"TM": {callingCode: "993", trunkPrefix: "8", nationalLengths: []int{8}},
+	"AZ": {callingCode: "994", trunkPrefix: "0", nationalLengths: []int{9}},
+	"TR": {callingCode: "90", trunkPrefix: "0", nationalLengths: []int{10}},
+	"AE": {callingCode: "971", trunkPrefix: "0", nationalLengths: []int{8, 9}},
+	"IN": {callingCode: "91", trunkPrefix: "0", nationalLengths: []int{10}},
+	"GB": {callingCode: "44", trunkPrefix: "0", nationalLengths: []int{9, 10}},
+	"DE": {callingCode: "49", trunkPrefix: "0", nationalLengths: []int{7, 8, 9, 10, 11}},
+	"US": {callingCode: "1", trunkPrefix: "1", nationalLengths: []int{10}},
+	"CA": {callingCode: "1", trunkPrefix: "1", nationalLengths: []int{10}},
+}
+
+// IsRegion reports whether numbers can be normalized with the region as the default
+func IsRegion(code string) bool {
+	_, ok := regions[strings.ToUpper(code)]
+	return ok
+}
+
+// NormalizePhone returns the number in E.164, numbers without the international prefix are read
+// as national numbers of the default region, formatting characters are ignored
+func NormalizePhone(phone, defaultRegion string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if len(phone) == 0 {
+		return "", fmt.Errorf("phone number is empty")
+	}
+
+	international := false
+	digits := make([]byte, 0, len(phone))
+
+	for i := 0; i < len(phone); i++ {
+		c := phone[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == '+' && len(digits) == 0 && !international:
+			international = true
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')':
+		default:
+			return "", fmt.Errorf("phone number %s contains %q", phone, c)
+		}
+	}
+
+	number := string(digits)
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	if !international {
+		r, ok := regions[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", fmt.Errorf("phone number %s has no calling code and default region %s is unknown", phone, defaultRegion)
+		}
+
+		national, ok := r.national(number)
+		if !ok {
+			return "", fmt.Errorf("phone number %s is not valid for region %s", phone, strings.ToUpper(defaultRegion))
+		}
+		number = r.callingCode + national
+	}
+
+	// E.164 allows up to 15 digits, no calling code starts with 0 and the shortest numbers have 7 digits
+	if len(number) < 7 || len(number) > 15 || number[0] == '0' {
+		return "", fmt.Errorf("phone number %s is not valid", phone)
+	}
+
+	return "+" + number, nil
+}
+
+// national strips the calling code or the trunk prefix a number of the region was written with,
+// the trunk prefix goes first, with it a number of a region with several lengths may have a
+// valid length too, while no national number of such a region starts with its trunk prefix
+func (r region) national(number string) (string, bool) {
+	if strings.HasPrefix(number, r.trunkPrefix) && r.hasNationalLength(number[len(r.trunkPrefix):]) {
+		return number[len(r.trunkPrefix):], true
+	}
+
+	if r.hasNationalLength(number) {
+		return number, true
+	}
+
+	if strings.HasPrefix(number, r.callingCode) && r.hasNationalLength(number[len(r.callingCode):]) {
+		return number[len(r.callingCode):], true
+	}
+
+	return "", false
+}
+
+func (r region) hasNationalLength(number string) bool {
+	for _, l := range r.nationalLengths {
+		if len(number) == l {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizeEmail returns the address trimmed and lowercased, the local part is treated as
+// case-insensitive as every mainstream provider does, dots and plus tags are kept
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(address.Name) > 0 {
+		return "", fmt.Errorf("email %s is not valid", email)
+	}
+
+	at := strings.LastIndex(email, "@")
+	if domain := email[at+1:]; !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", fmt.Errorf("email %s is not valid", email)
+	}
+
+	return strings.ToLower(email), nil
+}
+
+// CheckLogin rejects logins NormalizeUsername would take for an email or a phone number, they
+// couldn't be logged in with and could be mistaken for the contacts of another user
+func CheckLogin(login, defaultRegion string) error {
+	login = strings.TrimSpace(login)
+
+	if strings.Contains(login, "@") || strings.HasPrefix(login, "+") {
+		return fmt.Errorf("login %s looks like an email or a phone number", login)
+	}
+
+	if _, err := NormalizePhone(login, defaultRegion); err == nil {
+		return fmt.Errorf("login %s looks like an email or a phone number", login)
+	}
+
+	return nil
+}
+
+// NormalizeUsername turns a username given at login into the form stored for it, values that
+// are neither an email nor a phone number are logins and are returned as they are
+func NormalizeUsername(username, defaultRegion string) string {
+	username = strings.TrimSpace(username)
+
+	if strings.Contains(username, "@") {
+		if email, err := NormalizeEmail(username); err == nil {
+			return email
+		}
+		return username
+	}
+
+	if phone, err := NormalizePhone(username, defaultRegion); err == nil {
+		return phone
+	}
+
+	return username
+}
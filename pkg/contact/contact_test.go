@@ -0,0 +1,128 @@
+package contact_test
+
+import (
+	"testing"
+	"upm/udevs_go_auth_service/pkg/contact"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	cases := []struct {
+		name          string
+		phone         string
+		defaultRegion string
+		res           string
+		valid         bool
+	}{
+		{name: "international with formatting", phone: " +998 (90) 123-45-67 ", defaultRegion: "UZ", res: "+998901234567", valid: true},
+		{name: "international ignores the default region", phone: "+442079460018", defaultRegion: "UZ", res: "+442079460018", valid: true},
+		{name: "national", phone: "90 123 45 67", defaultRegion: "uz", res: "+998901234567", valid: true},
+		{name: "national with the trunk prefix", phone: "8 90 123 45 67", defaultRegion: "UZ", res: "+998901234567", valid: true},
+		{name: "calling code without a plus", phone: "998901234567", defaultRegion: "UZ", res: "+998901234567", valid: true},
+		{name: "the 00 international prefix", phone: "00 998 90 123 45 67", defaultRegion: "GB", res: "+998901234567", valid: true},
+		{name: "AE mobile with the trunk prefix", phone: "050 123 4567", defaultRegion: "AE", res: "+971501234567", valid: true},
+		{name: "AE landline with the trunk prefix", phone: "04 123 4567", defaultRegion: "AE", res: "+97141234567", valid: true},
+		{name: "AE landline", phone: "4 123 4567", defaultRegion: "AE", res: "+97141234567", valid: true},
+		{name: "GB ten digits with the trunk prefix", phone: "020 7946 0018", defaultRegion: "GB", res: "+442079460018", valid: true},
+		{name: "GB nine digits with the trunk prefix", phone: "016977 3456", defaultRegion: "GB", res: "+44169773456", valid: true},
+		{name: "DE with the trunk prefix", phone: "030 1234567", defaultRegion: "DE", res: "+49301234567", valid: true},
+		{name: "DE with the calling code", phone: "+49 30 1234567", defaultRegion: "DE", res: "+49301234567", valid: true},
+		{name: "US with the trunk prefix", phone: "1 (202) 555-0123", defaultRegion: "US", res: "+12025550123", valid: true},
+		{name: "empty", phone: " ", defaultRegion: "UZ"},
+		{name: "letters", phone: "+998 90 CALL ME", defaultRegion: "UZ"},
+		{name: "a plus after digits", phone: "998+901234567", defaultRegion: "UZ"},
+		{name: "a second plus", phone: "++998901234567", defaultRegion: "UZ"},
+		{name: "a slash", phone: "90/1234567", defaultRegion: "UZ"},
+		{name: "a wrong length for the region", phone: "9012345", defaultRegion: "UZ"},
+		{name: "national without a default region", phone: "901234567"},
+		{name: "national of an unknown region", phone: "901234567", defaultRegion: "XX"},
+		{name: "international too short", phone: "+99812"},
+		{name: "international too long", phone: "+9989012345678901"},
+		{name: "a calling code starting with 0", phone: "+0998901234567"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := contact.NormalizePhone(c.phone, c.defaultRegion)
+			if !c.valid {
+				assert.Error(t, err, res)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, c.res, res)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	cases := []struct {
+		name  string
+		email string
+		res   string
+		valid bool
+	}{
+		{name: "trimmed and lowercased", email: " John.Doe+Tag@Example.COM ", res: "john.doe+tag@example.com", valid: true},
+		{name: "a subdomain", email: "a@mail.example.uz", res: "a@mail.example.uz", valid: true},
+		{name: "without an at", email: "john.example.com"},
+		{name: "with a display name", email: "John <john@example.com>"},
+		{name: "a domain without a dot", email: "john@localhost"},
+		{name: "a domain starting with a dot", email: "john@.example.com"},
+		{name: "a domain ending with a dot", email: "john@example.com."},
+		{name: "two addresses", email: "a@example.com, b@example.com"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := contact.NormalizeEmail(c.email)
+			if !c.valid {
+				assert.Error(t, err, res)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, c.res, res)
+			}
+		})
+	}
+}
+
+func TestCheckLogin(t *testing.T) {
+	cases := []struct {
+		login string
+		valid bool
+	}{
+		{login: "john_doe", valid: true},
+		{login: "john.doe-90", valid: true},
+		// too short for a number of the default region
+		{login: "12345", valid: true},
+		{login: "901234567"},
+		{login: "90 123 45 67"},
+		{login: "998901234567"},
+		{login: "00998901234567"},
+		{login: "+john"},
+		{login: "john@example.com"},
+		{login: "john@"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.login, func(t *testing.T) {
+			err := contact.CheckLogin(c.login, "UZ")
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	assert.Equal(t, "john@example.com", contact.NormalizeUsername(" John@Example.com ", "UZ"))
+	assert.Equal(t, "+998901234567", contact.NormalizeUsername("8 90 123 45 67", "UZ"))
+	assert.Equal(t, "John_Doe", contact.NormalizeUsername(" John_Doe ", "UZ"))
+	// an email that isn't valid is kept for the login lookup to fail
+	assert.Equal(t, "john@localhost", contact.NormalizeUsername("john@localhost", "UZ"))
+}
@@ -42,3 +42,25 @@ func SendEmail(subject, to, link, token string) error {
 
 	return nil
 }
+
+func SendPasscodeEmail(subject, to, code string) error {
+	return SendNotificationEmail(subject, to, "Your confirmation code is "+code)
+}
+
+func SendNotificationEmail(subject, to, text string) error {
+	message := `
+		` + text
+
+	auth := smtp.PlainAuth("", from, password, host)
+
+	msg := "To: \"" + to + "\" <" + to + ">\n" +
+		"From: \"" + from + "\" <" + from + ">\n" +
+		"Subject: " + subject + "\n" +
+		message + "\n"
+
+	if err := smtp.SendMail(host+hostPort, auth, from, []string{to}, []byte(msg)); err != nil {
+		return errors.Wrap(err, "error while sending message to email")
+	}
+
+	return nil
+}
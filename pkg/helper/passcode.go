@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// GeneratePasscode returns a random code of the given length built from the pool characters
+func GeneratePasscode(pool string, length int) (string, error) {
+	if len(pool) == 0 || length <= 0 {
+		return "", errors.New("invalid passcode pool or length")
+	}
+
+	code := make([]byte, length)
+	max := big.NewInt(int64(len(pool)))
+
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", errors.Wrap(err, "error while generating passcode")
+		}
+
+		code[i] = pool[n.Int64()]
+	}
+
+	return string(code), nil
+}
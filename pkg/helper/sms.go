@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var smsClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// SendPasscodeSMS hands the code over to the sms gateway behind hookURL, the gateway gets
+// {"to": "+998...", "text": "..."} as a json POST and is expected to answer with a 2xx status
+func SendPasscodeSMS(hookURL, to, code string) error {
+	return SendNotificationSMS(hookURL, to, "Your confirmation code is "+code)
+}
+
+func SendNotificationSMS(hookURL, to, text string) error {
+	body, err := json.Marshal(map[string]string{
+		"to":   to,
+		"text": text,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := smsClient.Post(hookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error while sending message to phone")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("error while sending message to phone: sms hook answered %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,412 @@
+package rbac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ActionCreate = "CREATE"
+	ActionUpdate = "UPDATE"
+	ActionDelete = "DELETE"
+)
+
+const (
+	KindPermission     = "PERMISSION"
+	KindScope          = "SCOPE"
+	KindRole           = "ROLE"
+	KindRolePermission = "ROLE_PERMISSION"
+	KindRoleParent     = "ROLE_PARENT"
+)
+
+// Config is the permission tree and the roles of a client platform, ids are left out
+// so the same file can be applied to every environment
+type Config struct {
+	Permissions []Permission `yaml:"permissions"`
+	Roles       []Role       `yaml:"roles"`
+}
+
+type Permission struct {
+	Name              string       `yaml:"name"`
+	RecentAuthMinutes int32        `yaml:"recent_auth_minutes,omitempty"`
+	RelationScoped    bool         `yaml:"relation_scoped,omitempty"`
+	Scopes            []Scope      `yaml:"scopes,omitempty"`
+	Children          []Permission `yaml:"children,omitempty"`
+}
+
+type Scope struct {
+	Method string `yaml:"method"`
+	Path   string `yaml:"path"`
+}
+
+// Role is found by the name of its client type and its own name, permissions are paths in the tree
+// and parents are roles of the config the role inherits the permissions of
+type Role struct {
+	ClientType  string    `yaml:"client_type"`
+	Name        string    `yaml:"name"`
+	Permissions []string  `yaml:"permissions,omitempty"`
+	Parents     []RoleRef `yaml:"parents,omitempty"`
+}
+
+type RoleRef struct {
+	ClientType string `yaml:"client_type"`
+	Name       string `yaml:"name"`
+}
+
+func Marshal(config *Config) ([]byte, error) {
+	return yaml.Marshal(config)
+}
+
+// Unmarshal rejects unknown keys, a typo must not be applied as a deletion
+func Unmarshal(data []byte) (*Config, error) {
+	config := &Config{}
+
+	err := yaml.UnmarshalStrict(data, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// PermissionPath is how a permission is referred to, the names from the root joined by a slash
+func PermissionPath(parentPath, name string) string {
+	return parentPath + "/" + name
+}
+
+// NormalizeScope is the form scopes are stored in
+func NormalizeScope(scope Scope) Scope {
+	return Scope{
+		Method: strings.ToUpper(strings.TrimSpace(scope.Method)),
+		Path:   strings.TrimSpace(scope.Path),
+	}
+}
+
+type flatPermission struct {
+	path              string
+	parent            string
+	recentAuthMinutes int32
+	relationScoped    bool
+	scopes            []Scope
+}
+
+type roleKey struct {
+	clientType string
+	name       string
+}
+
+// flatConfig is the config with permissions listed parents first
+type flatConfig struct {
+	permissions     []flatPermission
+	permissionPaths map[string]flatPermission
+	roles           []roleKey
+	rolePermissions map[roleKey]map[string]bool
+	roleParents     map[roleKey]map[roleKey]bool
+}
+
+func flatten(config *Config) (*flatConfig, error) {
+	res := &flatConfig{
+		permissionPaths: map[string]flatPermission{},
+		rolePermissions: map[roleKey]map[string]bool{},
+		roleParents:     map[roleKey]map[roleKey]bool{},
+	}
+
+	err := res.addPermissions(config.Permissions, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range config.Roles {
+		key := roleKey{clientType: strings.TrimSpace(role.ClientType), name: strings.TrimSpace(role.Name)}
+		if len(key.clientType) == 0 || len(key.name) == 0 {
+			return nil, fmt.Errorf("role %q must have client_type and name", role.Name)
+		}
+
+		if _, ok := res.rolePermissions[key]; ok {
+			return nil, fmt.Errorf("role %s of %s is given twice", key.name, key.clientType)
+		}
+
+		permissions := map[string]bool{}
+		for _, path := range role.Permissions {
+			if _, ok := res.permissionPaths[path]; !ok {
+				return nil, fmt.Errorf("role %s of %s has unknown permission %s", key.name, key.clientType, path)
+			}
+			permissions[path] = true
+		}
+
+		res.roles = append(res.roles, key)
+		res.rolePermissions[key] = permissions
+	}
+
+	// parents are checked once every role is known, they may come later in the file
+	for _, role := range config.Roles {
+		key := roleKey{clientType: strings.TrimSpace(role.ClientType), name: strings.TrimSpace(role.Name)}
+
+		parents := map[roleKey]bool{}
+		for _, ref := range role.Parents {
+			parent := roleKey{clientType: strings.TrimSpace(ref.ClientType), name: strings.TrimSpace(ref.Name)}
+			if _, ok := res.rolePermissions[parent]; !ok {
+				return nil, fmt.Errorf("role %s of %s has unknown parent %s of %s", key.name, key.clientType, parent.name, parent.clientType)
+			}
+			parents[parent] = true
+		}
+
+		res.roleParents[key] = parents
+	}
+
+	for _, key := range res.roles {
+		if res.inherits(key, key, map[roleKey]bool{}) {
+			return nil, fmt.Errorf("role %s of %s inherits from itself", key.name, key.clientType)
+		}
+	}
+
+	sortRoleKeys(res.roles)
+
+	return res, nil
+}
+
+// inherits reports whether role has ancestor among its ancestors
+func (c *flatConfig) inherits(role, ancestor roleKey, seen map[roleKey]bool) bool {
+	for parent := range c.roleParents[role] {
+		if parent == ancestor {
+			return true
+		}
+
+		if !seen[parent] {
+			seen[parent] = true
+			if c.inherits(parent, ancestor, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *flatConfig) addPermissions(permissions []Permission, parent string) error {
+	for _, permission := range permissions {
+		name := strings.TrimSpace(permission.Name)
+		if len(name) == 0 {
+			return fmt.Errorf("permission under %s has no name", parent+"/")
+		}
+
+		path := PermissionPath(parent, name)
+		if _, ok := c.permissionPaths[path]; ok {
+			return fmt.Errorf("permission %s is given twice", path)
+		}
+
+		flat := flatPermission{
+			path:              path,
+			parent:            parent,
+			recentAuthMinutes: permission.RecentAuthMinutes,
+			relationScoped:    permission.RelationScoped,
+		}
+
+		seen := map[Scope]bool{}
+		for _, scope := range permission.Scopes {
+			scope = NormalizeScope(scope)
+			if len(scope.Method) == 0 || len(scope.Path) == 0 {
+				return fmt.Errorf("scope of permission %s must have method and path", path)
+			}
+
+			if !seen[scope] {
+				seen[scope] = true
+				flat.scopes = append(flat.scopes, scope)
+			}
+		}
+
+		c.permissions = append(c.permissions, flat)
+		c.permissionPaths[path] = flat
+
+		err := c.addPermissions(permission.Children, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Plan lists the changes that turn current into desired, in the order they can be applied:
+// permissions parents first, scopes, roles with their permissions and parents, then deletions children first
+func Plan(current, desired *Config) ([]*pb.RBACChange, error) {
+	from, err := flatten(current)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := flatten(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*pb.RBACChange{}
+
+	for _, permission := range to.permissions {
+		old, ok := from.permissionPaths[permission.path]
+		switch {
+		case !ok:
+			res = append(res, &pb.RBACChange{
+				Action:            ActionCreate,
+				Kind:              KindPermission,
+				Permission:        permission.path,
+				ParentPermission:  permission.parent,
+				RecentAuthMinutes: permission.recentAuthMinutes,
+				RelationScoped:    permission.relationScoped,
+			})
+		case old.recentAuthMinutes != permission.recentAuthMinutes || old.relationScoped != permission.relationScoped:
+			res = append(res, &pb.RBACChange{
+				Action:            ActionUpdate,
+				Kind:              KindPermission,
+				Permission:        permission.path,
+				ParentPermission:  permission.parent,
+				RecentAuthMinutes: permission.recentAuthMinutes,
+				RelationScoped:    permission.relationScoped,
+			})
+		}
+	}
+
+	for _, permission := range to.permissions {
+		oldScopes := map[Scope]bool{}
+		for _, scope := range from.permissionPaths[permission.path].scopes {
+			oldScopes[scope] = true
+		}
+
+		for _, scope := range permission.scopes {
+			if oldScopes[scope] {
+				delete(oldScopes, scope)
+				continue
+			}
+
+			res = append(res, scopeChange(ActionCreate, permission.path, scope))
+		}
+
+		for _, scope := range from.permissionPaths[permission.path].scopes {
+			if oldScopes[scope] {
+				res = append(res, scopeChange(ActionDelete, permission.path, scope))
+			}
+		}
+	}
+
+	for _, role := range to.roles {
+		if _, ok := from.rolePermissions[role]; !ok {
+			res = append(res, roleChange(ActionCreate, KindRole, role, ""))
+		}
+	}
+
+	for _, role := range to.roles {
+		old := from.rolePermissions[role]
+
+		for _, path := range sortedKeys(to.rolePermissions[role]) {
+			if !old[path] {
+				res = append(res, roleChange(ActionCreate, KindRolePermission, role, path))
+			}
+		}
+
+		for _, path := range sortedKeys(old) {
+			if !to.rolePermissions[role][path] {
+				res = append(res, roleChange(ActionDelete, KindRolePermission, role, path))
+			}
+		}
+	}
+
+	for _, role := range to.roles {
+		old := from.roleParents[role]
+
+		for _, parent := range sortedRoleKeys(old) {
+			if !to.roleParents[role][parent] {
+				res = append(res, roleParentChange(ActionDelete, role, parent))
+			}
+		}
+
+		for _, parent := range sortedRoleKeys(to.roleParents[role]) {
+			if !old[parent] {
+				res = append(res, roleParentChange(ActionCreate, role, parent))
+			}
+		}
+	}
+
+	for _, role := range from.roles {
+		if _, ok := to.rolePermissions[role]; !ok {
+			res = append(res, roleChange(ActionDelete, KindRole, role, ""))
+		}
+	}
+
+	for i := len(from.permissions) - 1; i >= 0; i-- {
+		permission := from.permissions[i]
+		if _, ok := to.permissionPaths[permission.path]; !ok {
+			res = append(res, &pb.RBACChange{
+				Action:           ActionDelete,
+				Kind:             KindPermission,
+				Permission:       permission.path,
+				ParentPermission: permission.parent,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func scopeChange(action, permission string, scope Scope) *pb.RBACChange {
+	return &pb.RBACChange{
+		Action:      action,
+		Kind:        KindScope,
+		Permission:  permission,
+		ScopeMethod: scope.Method,
+		ScopePath:   scope.Path,
+	}
+}
+
+func roleChange(action, kind string, role roleKey, permission string) *pb.RBACChange {
+	return &pb.RBACChange{
+		Action:     action,
+		Kind:       kind,
+		ClientType: role.clientType,
+		Role:       role.name,
+		Permission: permission,
+	}
+}
+
+func roleParentChange(action string, role, parent roleKey) *pb.RBACChange {
+	return &pb.RBACChange{
+		Action:           action,
+		Kind:             KindRoleParent,
+		ClientType:       role.clientType,
+		Role:             role.name,
+		ParentClientType: parent.clientType,
+		ParentRole:       parent.name,
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	res := make([]string, 0, len(m))
+	for key := range m {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+func sortedRoleKeys(m map[roleKey]bool) []roleKey {
+	res := make([]roleKey, 0, len(m))
+	for key := range m {
+		res = append(res, key)
+	}
+	sortRoleKeys(res)
+
+	return res
+}
+
+func sortRoleKeys(keys []roleKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].clientType != keys[j].clientType {
+			return keys[i].clientType < keys[j].clientType
+		}
+		return keys[i].name < keys[j].name
+	})
+}
@@ -0,0 +1,198 @@
+package rbac_test
+
+import (
+	"testing"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/rbac"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rbacConfig is a tree of reports with read under it, the manager inherits from the employee
+func rbacConfig() *rbac.Config {
+	return &rbac.Config{
+		Permissions: []rbac.Permission{
+			{
+				Name:   "reports",
+				Scopes: []rbac.Scope{{Method: "GET", Path: "/reports"}},
+				Children: []rbac.Permission{
+					{
+						Name:   "read",
+						Scopes: []rbac.Scope{{Method: "get", Path: " /reports/* "}, {Method: "GET", Path: "/reports/*"}},
+					},
+				},
+			},
+		},
+		Roles: []rbac.Role{
+			{ClientType: "admin", Name: "manager", Permissions: []string{"/reports/read"}, Parents: []rbac.RoleRef{{ClientType: "admin", Name: "employee"}}},
+			{ClientType: "admin", Name: "employee", Permissions: []string{"/reports"}},
+		},
+	}
+}
+
+func TestRBACPlan(t *testing.T) {
+	cases := []struct {
+		name    string
+		current *rbac.Config
+		desired *rbac.Config
+		plan    []*pb.RBACChange
+	}{
+		{
+			name:    "an empty client platform gets permissions parents first, scopes, roles, their permissions and parents",
+			current: &rbac.Config{},
+			desired: rbacConfig(),
+			plan: []*pb.RBACChange{
+				{Action: rbac.ActionCreate, Kind: rbac.KindPermission, Permission: "/reports"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindPermission, Permission: "/reports/read", ParentPermission: "/reports"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindScope, Permission: "/reports", ScopeMethod: "GET", ScopePath: "/reports"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindScope, Permission: "/reports/read", ScopeMethod: "GET", ScopePath: "/reports/*"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindRole, ClientType: "admin", Role: "employee"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindRole, ClientType: "admin", Role: "manager"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindRolePermission, ClientType: "admin", Role: "employee", Permission: "/reports"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindRolePermission, ClientType: "admin", Role: "manager", Permission: "/reports/read"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindRoleParent, ClientType: "admin", Role: "manager", ParentClientType: "admin", ParentRole: "employee"},
+			},
+		},
+		{
+			name:    "the same config changes nothing",
+			current: rbacConfig(),
+			desired: rbacConfig(),
+			plan:    []*pb.RBACChange{},
+		},
+		{
+			name:    "deletions come last, roles before permissions and children before parents",
+			current: rbacConfig(),
+			desired: &rbac.Config{
+				Permissions: []rbac.Permission{{Name: "reports", RecentAuthMinutes: 5}},
+				Roles:       []rbac.Role{{ClientType: "admin", Name: "employee"}},
+			},
+			plan: []*pb.RBACChange{
+				{Action: rbac.ActionUpdate, Kind: rbac.KindPermission, Permission: "/reports", RecentAuthMinutes: 5},
+				{Action: rbac.ActionDelete, Kind: rbac.KindScope, Permission: "/reports", ScopeMethod: "GET", ScopePath: "/reports"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindRolePermission, ClientType: "admin", Role: "employee", Permission: "/reports"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindRole, ClientType: "admin", Role: "manager"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindPermission, Permission: "/reports/read", ParentPermission: "/reports"},
+			},
+		},
+		{
+			name: "a scope is replaced and a parent is swapped, old links go before new ones",
+			current: &rbac.Config{
+				Permissions: []rbac.Permission{{Name: "reports", Scopes: []rbac.Scope{{Method: "GET", Path: "/reports"}}}},
+				Roles: []rbac.Role{
+					{ClientType: "admin", Name: "a"},
+					{ClientType: "admin", Name: "b"},
+					{ClientType: "admin", Name: "c", Parents: []rbac.RoleRef{{ClientType: "admin", Name: "a"}}},
+				},
+			},
+			desired: &rbac.Config{
+				Permissions: []rbac.Permission{{Name: "reports", Scopes: []rbac.Scope{{Method: "POST", Path: "/reports"}}}},
+				Roles: []rbac.Role{
+					{ClientType: "admin", Name: "a"},
+					{ClientType: "admin", Name: "b"},
+					{ClientType: "admin", Name: "c", Parents: []rbac.RoleRef{{ClientType: "admin", Name: "b"}}},
+				},
+			},
+			plan: []*pb.RBACChange{
+				{Action: rbac.ActionCreate, Kind: rbac.KindScope, Permission: "/reports", ScopeMethod: "POST", ScopePath: "/reports"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindScope, Permission: "/reports", ScopeMethod: "GET", ScopePath: "/reports"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindRoleParent, ClientType: "admin", Role: "c", ParentClientType: "admin", ParentRole: "a"},
+				{Action: rbac.ActionCreate, Kind: rbac.KindRoleParent, ClientType: "admin", Role: "c", ParentClientType: "admin", ParentRole: "b"},
+			},
+		},
+		{
+			name:    "everything is deleted for an empty config",
+			current: rbacConfig(),
+			desired: &rbac.Config{},
+			plan: []*pb.RBACChange{
+				{Action: rbac.ActionDelete, Kind: rbac.KindRole, ClientType: "admin", Role: "employee"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindRole, ClientType: "admin", Role: "manager"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindPermission, Permission: "/reports/read", ParentPermission: "/reports"},
+				{Action: rbac.ActionDelete, Kind: rbac.KindPermission, Permission: "/reports"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			plan, err := rbac.Plan(c.current, c.desired)
+			if assert.NoError(t, err) {
+				assert.Equal(t, c.plan, plan)
+			}
+		})
+	}
+}
+
+func TestRBACPlanInvalid(t *testing.T) {
+	cases := []struct {
+		name    string
+		desired *rbac.Config
+		err     string
+	}{
+		{
+			name:    "a permission without a name",
+			desired: &rbac.Config{Permissions: []rbac.Permission{{Name: " "}}},
+			err:     "permission under / has no name",
+		},
+		{
+			name:    "a permission given twice",
+			desired: &rbac.Config{Permissions: []rbac.Permission{{Name: "reports"}, {Name: "reports"}}},
+			err:     "permission /reports is given twice",
+		},
+		{
+			name:    "a scope without a path",
+			desired: &rbac.Config{Permissions: []rbac.Permission{{Name: "reports", Scopes: []rbac.Scope{{Method: "GET"}}}}},
+			err:     "scope of permission /reports must have method and path",
+		},
+		{
+			name:    "a role given twice",
+			desired: &rbac.Config{Roles: []rbac.Role{{ClientType: "admin", Name: "a"}, {ClientType: "admin", Name: "a"}}},
+			err:     "role a of admin is given twice",
+		},
+		{
+			name:    "a role with an unknown permission",
+			desired: &rbac.Config{Roles: []rbac.Role{{ClientType: "admin", Name: "a", Permissions: []string{"/reports"}}}},
+			err:     "role a of admin has unknown permission /reports",
+		},
+		{
+			name:    "a role with an unknown parent",
+			desired: &rbac.Config{Roles: []rbac.Role{{ClientType: "admin", Name: "a", Parents: []rbac.RoleRef{{ClientType: "admin", Name: "b"}}}}},
+			err:     "role a of admin has unknown parent b of admin",
+		},
+		{
+			name: "roles inheriting from each other",
+			desired: &rbac.Config{Roles: []rbac.Role{
+				{ClientType: "admin", Name: "a", Parents: []rbac.RoleRef{{ClientType: "admin", Name: "b"}}},
+				{ClientType: "admin", Name: "b", Parents: []rbac.RoleRef{{ClientType: "admin", Name: "a"}}},
+			}},
+			err: "role a of admin inherits from itself",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := rbac.Plan(&rbac.Config{}, c.desired)
+			assert.EqualError(t, err, c.err)
+		})
+	}
+}
+
+func TestRBACUnmarshal(t *testing.T) {
+	config, err := rbac.Unmarshal([]byte(`
+permissions:
+  - name: reports
+roles:
+  - client_type: admin
+    name: manager
+    permissions: [/reports]
+    parents:
+      - client_type: admin
+        name: employee
+`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []rbac.RoleRef{{ClientType: "admin", Name: "employee"}}, config.Roles[0].Parents)
+	}
+
+	// a typo must not be read as a missing key, which would be applied as a deletion
+	_, err = rbac.Unmarshal([]byte("roles:\n  - client_type: admin\n    name: manager\n    parent: []\n"))
+	assert.Error(t, err)
+}
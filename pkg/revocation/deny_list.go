@@ -0,0 +1,74 @@
+package revocation
+
+import (
+	"sync"
+	"time"
+)
+
+// DenyList keeps revoked session ids (jti of the issued tokens) in memory
+// until the tokens would have expired anyway
+type DenyList struct {
+	mu      sync.RWMutex
+	items   map[string]time.Time
+	lastSeq int64
+}
+
+// NewDenyList ...
+func NewDenyList() *DenyList {
+	return &DenyList{
+		items: map[string]time.Time{},
+	}
+}
+
+// Add remembers a revoked session, seq is the position of the revocation in the feed
+func (d *DenyList) Add(id string, expiresAt time.Time, seq int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if expiresAt.After(time.Now()) {
+		d.items[id] = expiresAt
+	}
+
+	if seq > d.lastSeq {
+		d.lastSeq = seq
+	}
+}
+
+// IsRevoked ...
+func (d *DenyList) IsRevoked(id string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	expiresAt, ok := d.items[id]
+
+	return ok && expiresAt.After(time.Now())
+}
+
+// LastSeq is the sequence number to resume the feed from
+func (d *DenyList) LastSeq() int64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.lastSeq
+}
+
+// Purge drops the entries whose tokens have expired
+func (d *DenyList) Purge() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	for id, expiresAt := range d.items {
+		if !expiresAt.After(now) {
+			delete(d.items, id)
+		}
+	}
+}
+
+// Len ...
+func (d *DenyList) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return len(d.items)
+}
@@ -0,0 +1,73 @@
+package revocation
+
+import (
+	"context"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+const (
+	reconnectInterval = 5 * time.Second
+	purgeInterval     = 10 * time.Minute
+)
+
+// Watch subscribes to the revocation feed and fills the deny list,
+// the stream is reopened from the last received sequence number whenever it breaks
+func Watch(ctx context.Context, client pb.SessionServiceClient, denyList *DenyList, log logger.LoggerI) {
+	go purge(ctx, denyList)
+
+	for {
+		err := watch(ctx, client, denyList)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Error("!!!revocation.Watch--->", logger.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+func watch(ctx context.Context, client pb.SessionServiceClient, denyList *DenyList) error {
+	stream, err := client.WatchRevokedSessions(ctx, &pb.WatchRevokedSessionsRequest{
+		AfterSeq: denyList.LastSeq(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		revokedSession, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		expiresAt, err := time.Parse(config.DatabaseTimeLayout, revokedSession.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		denyList.Add(revokedSession.Id, expiresAt, revokedSession.Seq)
+	}
+}
+
+func purge(ctx context.Context, denyList *DenyList) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			denyList.Purge()
+		}
+	}
+}
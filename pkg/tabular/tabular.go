@@ -0,0 +1,258 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	FormatCSV  = "CSV"
+	FormatXLSX = "XLSX"
+
+	maxXLSXPartSize = 256 << 20
+	// the limits of a sheet, column XFD and row 1048576, references beyond them would only make
+	// the rows padded to them huge
+	maxXLSXColumns = 16384
+	maxXLSXRows    = 1048576
+)
+
+// Read returns all rows of the first sheet, the first row is expected to be the header
+func Read(format string, data []byte) ([][]string, error) {
+	switch strings.ToUpper(format) {
+	case FormatCSV:
+		return readCSV(data)
+	case FormatXLSX:
+		return readXLSX(data)
+	}
+
+	return nil, fmt.Errorf("unknown file format %s", format)
+}
+
+// FormatByFileName guesses the format by the file extension
+func FormatByFileName(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".xlsx":
+		return FormatXLSX
+	case ".csv":
+		return FormatCSV
+	}
+
+	return ""
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "csv")
+	}
+
+	return rows, nil
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+
+	return b.String()
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R         string   `xml:"r,attr"`
+			T         string   `xml:"t,attr"`
+			V         string   `xml:"v"`
+			InlineStr xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX reads cell values of the first worksheet, formulas are read as their cached values
+// and dates as the serial numbers excel stores them with
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.Wrap(err, "xlsx")
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	workbook := xlsxWorkbook{}
+	if err = decodeXML(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+
+	if len(workbook.Sheets) == 0 {
+		return nil, errors.New("xlsx: workbook has no sheets")
+	}
+
+	relationships := xlsxRelationships{}
+	if err = decodeXML(files, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, err
+	}
+
+	sheetPath := ""
+	for _, r := range relationships.Relationships {
+		if r.ID == workbook.Sheets[0].ID {
+			sheetPath = path.Join("xl", r.Target)
+			if strings.HasPrefix(r.Target, "/") {
+				sheetPath = strings.TrimPrefix(r.Target, "/")
+			}
+		}
+	}
+
+	sharedStrings := xlsxSharedStrings{}
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err = decodeXML(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet := xlsxSheet{}
+	if err = decodeXML(files, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	for _, r := range sheet.Rows {
+		if r.R > maxXLSXRows {
+			return nil, fmt.Errorf("xlsx: row %d is out of the sheet", r.R)
+		}
+
+		// rows without cells are omitted by excel, keep the numbering of the sheet
+		for r.R > len(rows)+1 {
+			rows = append(rows, []string{})
+		}
+
+		row := []string{}
+		for _, c := range r.Cells {
+			column := len(row)
+			if len(c.R) > 0 {
+				column, err = columnIndex(c.R)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			for len(row) < column {
+				row = append(row, "")
+			}
+
+			value := c.V
+			switch c.T {
+			case "s":
+				i, err := strconv.Atoi(c.V)
+				if err != nil || i < 0 || i >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("xlsx: cell %s refers to an unknown shared string", c.R)
+				}
+				value = sharedStrings.Items[i].String()
+			case "inlineStr":
+				value = c.InlineStr.String()
+			case "b":
+				value = strconv.FormatBool(c.V == "1")
+			}
+
+			row = append(row, value)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func decodeXML(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("xlsx: %s is missing", name)
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		return errors.Wrap(err, "xlsx")
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxXLSXPartSize+1))
+	if err != nil {
+		return errors.Wrap(err, "xlsx")
+	}
+
+	if len(data) > maxXLSXPartSize {
+		return fmt.Errorf("xlsx: %s is too large", name)
+	}
+
+	return errors.Wrapf(xml.Unmarshal(data, v), "xlsx: %s", name)
+}
+
+// columnIndex turns the letters of a cell reference like AB12 into a zero based column index
+func columnIndex(ref string) (int, error) {
+	index := 0
+	letters := 0
+
+	for _, c := range strings.ToUpper(ref) {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		index = index*26 + int(c-'A'+1)
+		letters++
+
+		if index > maxXLSXColumns {
+			return 0, fmt.Errorf("xlsx: cell %s is out of the sheet", ref)
+		}
+	}
+
+	if letters == 0 {
+		return 0, fmt.Errorf("xlsx: invalid cell reference %s", ref)
+	}
+
+	return index - 1, nil
+}
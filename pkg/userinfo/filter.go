@@ -0,0 +1,127 @@
+package userinfo
+
+import (
+	"fmt"
+	"time"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Field returns the definition of the field or nil when the schema doesn't declare it
+func (s *Schema) Field(name string) *pb.UserInfoField {
+	if f := s.lookup(name); f != nil {
+		return f.UserInfoField
+	}
+
+	return nil
+}
+
+func (s *Schema) lookup(name string) *field {
+	for _, f := range s.fields {
+		if f.FieldName == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// CheckFilter makes sure the operator can be applied to the field and the value has its type
+func (s *Schema) CheckFilter(filter *pb.UserInfoFilter) error {
+	f := s.Field(filter.FieldName)
+	if f == nil {
+		return fmt.Errorf("user info field %s is not defined", filter.FieldName)
+	}
+
+	if filter.Value == nil || isNull(filter.Value) {
+		return fmt.Errorf("user info filter %s: value is required", f.FieldName)
+	}
+
+	switch filter.Operator {
+	case pb.UserInfoFilterOperators_EQ:
+		if f.FieldType != FieldTypeFlat {
+			return fmt.Errorf("user info filter %s: use CONTAINS for arrays", f.FieldName)
+		}
+
+		return checkFilterValue(f, filter.Value)
+	case pb.UserInfoFilterOperators_GT,
+		pb.UserInfoFilterOperators_GTE,
+		pb.UserInfoFilterOperators_LT,
+		pb.UserInfoFilterOperators_LTE:
+		if f.FieldType != FieldTypeFlat || f.DataType == DataTypeBoolean {
+			return fmt.Errorf("user info filter %s: ranges can be applied to flat numbers, strings and dates only", f.FieldName)
+		}
+
+		return checkFilterValue(f, filter.Value)
+	case pb.UserInfoFilterOperators_CONTAINS:
+		if f.FieldType == FieldTypeFlat && f.DataType != DataTypeString {
+			return fmt.Errorf("user info filter %s: CONTAINS can be applied to strings and arrays only", f.FieldName)
+		}
+
+		return checkFilterValue(f, filter.Value)
+	case pb.UserInfoFilterOperators_IN:
+		if f.FieldType != FieldTypeFlat {
+			return fmt.Errorf("user info filter %s: IN can be applied to flat fields only", f.FieldName)
+		}
+
+		list, ok := filter.Value.Kind.(*structpb.Value_ListValue)
+		if !ok || len(list.ListValue.Values) == 0 {
+			return fmt.Errorf("user info filter %s: IN requires a non-empty list", f.FieldName)
+		}
+
+		for _, item := range list.ListValue.Values {
+			if err := checkFilterValue(f, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("user info filter %s: unknown operator %s", f.FieldName, filter.Operator)
+}
+
+// CheckOrderBy makes sure users can be sorted by the field
+func (s *Schema) CheckOrderBy(name string) error {
+	f := s.Field(name)
+	if f == nil {
+		return fmt.Errorf("user info field %s is not defined", name)
+	}
+
+	if f.FieldType != FieldTypeFlat {
+		return fmt.Errorf("user info field %s: arrays can't be sorted", name)
+	}
+
+	return nil
+}
+
+// checkFilterValue checks only the type of the value, the rules of the field such as
+// ranges or patterns don't restrict what can be searched for
+func checkFilterValue(f *pb.UserInfoField, value *structpb.Value) error {
+	ok := false
+
+	switch f.DataType {
+	case DataTypeString:
+		_, ok = value.Kind.(*structpb.Value_StringValue)
+	case DataTypeNumber, DataTypeInteger:
+		_, ok = value.Kind.(*structpb.Value_NumberValue)
+	case DataTypeBoolean:
+		_, ok = value.Kind.(*structpb.Value_BoolValue)
+	case DataTypeDate:
+		var v *structpb.Value_StringValue
+		v, ok = value.Kind.(*structpb.Value_StringValue)
+		if ok {
+			if _, err := time.Parse(dateLayout, v.StringValue); err != nil {
+				_, err = time.Parse(time.RFC3339, v.StringValue)
+				ok = err == nil
+			}
+		}
+	}
+
+	if !ok {
+		return fmt.Errorf("user info filter %s: value must be of type %s", f.FieldName, f.DataType)
+	}
+
+	return nil
+}
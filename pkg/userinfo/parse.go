@@ -0,0 +1,105 @@
+package userinfo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ArraySeparator separates the items of array fields written as text
+const ArraySeparator = ";"
+
+// ParseValue converts the text of a table cell into the value of the field,
+// empty text means the value is absent and nil is returned
+func (s *Schema) ParseValue(name, text string) (*structpb.Value, error) {
+	f := s.Field(name)
+	if f == nil {
+		return nil, fmt.Errorf("user info field %s is not defined", name)
+	}
+
+	text = strings.TrimSpace(text)
+	if len(text) == 0 {
+		return nil, nil
+	}
+
+	if f.FieldType == FieldTypeFlat {
+		return parseItem(f.DataType, text)
+	}
+
+	items := []*structpb.Value{}
+	for _, part := range strings.Split(text, ArraySeparator) {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+
+		item, err := parseItem(f.DataType, part)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return structpb.NewListValue(&structpb.ListValue{Values: items}), nil
+}
+
+func parseItem(dataType, text string) (*structpb.Value, error) {
+	switch dataType {
+	case DataTypeNumber, DataTypeInteger:
+		n, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number", text)
+		}
+		return structpb.NewNumberValue(n), nil
+	case DataTypeBoolean:
+		b, err := strconv.ParseBool(strings.ToLower(text))
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a boolean", text)
+		}
+		return structpb.NewBoolValue(b), nil
+	}
+
+	return structpb.NewStringValue(text), nil
+}
+
+// ValidatePartial checks only the fields present in data, it is used for updates
+// where absent fields keep their stored values
+func (s *Schema) ValidatePartial(data *structpb.Struct) []*pb.UserInfoFieldError {
+	fieldErrors := []*pb.UserInfoFieldError{}
+
+	names := []string{}
+	for name := range data.GetFields() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := s.lookup(name)
+		if f == nil {
+			fieldErrors = append(fieldErrors, fieldError(name, "unknown field"))
+			continue
+		}
+
+		if msg := f.validate(data.Fields[name]); len(msg) > 0 {
+			fieldErrors = append(fieldErrors, fieldError(name, msg))
+		}
+	}
+
+	return fieldErrors
+}
+
+// Required returns the names of required fields without a default value
+func (s *Schema) Required() []string {
+	names := []string{}
+	for _, f := range s.fields {
+		if f.Required && f.DefaultValue == nil {
+			names = append(names, f.FieldName)
+		}
+	}
+
+	return names
+}
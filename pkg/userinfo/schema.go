@@ -0,0 +1,363 @@
+package userinfo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	FieldTypeFlat  = "FLAT"
+	FieldTypeArray = "ARRAY"
+
+	DataTypeString  = "STRING"
+	DataTypeNumber  = "NUMBER"
+	DataTypeInteger = "INTEGER"
+	DataTypeBoolean = "BOOLEAN"
+	DataTypeDate    = "DATE"
+
+	dateLayout = "2006-01-02"
+)
+
+// Schema is the compiled set of user_info_field rows of a client type
+type Schema struct {
+	fields []*field
+}
+
+type field struct {
+	*pb.UserInfoField
+	pattern *regexp.Regexp
+}
+
+// Compile checks the field definitions and prepares them for validation
+func Compile(fields []*pb.UserInfoField) (*Schema, error) {
+	schema := &Schema{}
+	names := map[string]bool{}
+
+	for _, f := range fields {
+		compiled, err := compileField(f)
+		if err != nil {
+			return nil, err
+		}
+
+		if names[f.FieldName] {
+			return nil, fmt.Errorf("field %s is defined more than once", f.FieldName)
+		}
+		names[f.FieldName] = true
+
+		schema.fields = append(schema.fields, compiled)
+	}
+
+	sort.Slice(schema.fields, func(i, j int) bool {
+		return schema.fields[i].FieldName < schema.fields[j].FieldName
+	})
+
+	return schema, nil
+}
+
+// CheckField validates a single field definition before it is stored
+func CheckField(f *pb.UserInfoField) error {
+	_, err := compileField(f)
+	return err
+}
+
+func compileField(f *pb.UserInfoField) (*field, error) {
+	if len(f.FieldName) == 0 {
+		return nil, errors.New("field name is required")
+	}
+
+	if f.FieldType != FieldTypeFlat && f.FieldType != FieldTypeArray {
+		return nil, fmt.Errorf("field %s has unknown field type %s", f.FieldName, f.FieldType)
+	}
+
+	switch f.DataType {
+	case DataTypeString, DataTypeNumber, DataTypeInteger, DataTypeBoolean, DataTypeDate:
+	default:
+		return nil, fmt.Errorf("field %s has unknown data type %s", f.FieldName, f.DataType)
+	}
+
+	compiled := &field{UserInfoField: f}
+
+	if len(f.Pattern) > 0 {
+		if f.DataType != DataTypeString {
+			return nil, fmt.Errorf("field %s: pattern can be set for strings only", f.FieldName)
+		}
+
+		pattern, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s has invalid pattern", f.FieldName)
+		}
+		compiled.pattern = pattern
+	}
+
+	if f.MinValue != nil && f.MaxValue != nil && f.MinValue.Value > f.MaxValue.Value {
+		return nil, fmt.Errorf("field %s: min value is greater than max value", f.FieldName)
+	}
+
+	if f.DefaultValue != nil {
+		if msg := compiled.validate(f.DefaultValue); len(msg) > 0 {
+			return nil, fmt.Errorf("field %s has invalid default value: %s", f.FieldName, msg)
+		}
+	}
+
+	return compiled, nil
+}
+
+// Validate checks data against the schema and returns it with defaults filled in,
+// every violation is reported separately so forms can highlight the fields
+func (s *Schema) Validate(data *structpb.Struct) (*structpb.Struct, []*pb.UserInfoFieldError) {
+	res := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	fieldErrors := []*pb.UserInfoFieldError{}
+
+	values := map[string]*structpb.Value{}
+	if data != nil && data.Fields != nil {
+		values = data.Fields
+	}
+
+	known := map[string]bool{}
+	for _, f := range s.fields {
+		known[f.FieldName] = true
+
+		value, ok := values[f.FieldName]
+		if !ok || isNull(value) {
+			switch {
+			case f.DefaultValue != nil:
+				res.Fields[f.FieldName] = f.DefaultValue
+			case f.Required:
+				fieldErrors = append(fieldErrors, fieldError(f.FieldName, "is required"))
+			}
+			continue
+		}
+
+		if msg := f.validate(value); len(msg) > 0 {
+			fieldErrors = append(fieldErrors, fieldError(f.FieldName, msg))
+			continue
+		}
+
+		res.Fields[f.FieldName] = value
+	}
+
+	unknown := []string{}
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	for _, name := range unknown {
+		fieldErrors = append(fieldErrors, fieldError(name, "unknown field"))
+	}
+
+	return res, fieldErrors
+}
+
+func (f *field) validate(value *structpb.Value) string {
+	if f.FieldType == FieldTypeFlat {
+		return f.validateItem(value)
+	}
+
+	list, ok := value.Kind.(*structpb.Value_ListValue)
+	if !ok {
+		return "must be an array"
+	}
+
+	if f.Required && len(list.ListValue.Values) == 0 {
+		return "must have at least one item"
+	}
+
+	for i, item := range list.ListValue.Values {
+		if msg := f.validateItem(item); len(msg) > 0 {
+			return fmt.Sprintf("item %d %s", i, msg)
+		}
+	}
+
+	return ""
+}
+
+func (f *field) validateItem(value *structpb.Value) string {
+	switch f.DataType {
+	case DataTypeString:
+		v, ok := value.Kind.(*structpb.Value_StringValue)
+		if !ok {
+			return "must be a string"
+		}
+
+		length := float64(utf8.RuneCountInString(v.StringValue))
+		if f.MinValue != nil && length < f.MinValue.Value {
+			return fmt.Sprintf("must be at least %s characters long", formatNumber(f.MinValue.Value))
+		}
+
+		if f.MaxValue != nil && length > f.MaxValue.Value {
+			return fmt.Sprintf("must be at most %s characters long", formatNumber(f.MaxValue.Value))
+		}
+
+		if f.pattern != nil && !f.pattern.MatchString(v.StringValue) {
+			return "doesn't match the pattern " + f.Pattern
+		}
+
+		return f.validateEnum(v.StringValue)
+	case DataTypeNumber, DataTypeInteger:
+		v, ok := value.Kind.(*structpb.Value_NumberValue)
+		if !ok {
+			return "must be a number"
+		}
+
+		if f.DataType == DataTypeInteger && v.NumberValue != math.Trunc(v.NumberValue) {
+			return "must be an integer"
+		}
+
+		if f.MinValue != nil && v.NumberValue < f.MinValue.Value {
+			return "must be greater than or equal to " + formatNumber(f.MinValue.Value)
+		}
+
+		if f.MaxValue != nil && v.NumberValue > f.MaxValue.Value {
+			return "must be less than or equal to " + formatNumber(f.MaxValue.Value)
+		}
+
+		return f.validateEnum(formatNumber(v.NumberValue))
+	case DataTypeBoolean:
+		if _, ok := value.Kind.(*structpb.Value_BoolValue); !ok {
+			return "must be a boolean"
+		}
+	case DataTypeDate:
+		v, ok := value.Kind.(*structpb.Value_StringValue)
+		if !ok {
+			return "must be a date string"
+		}
+
+		if _, err := time.Parse(dateLayout, v.StringValue); err != nil {
+			if _, err := time.Parse(time.RFC3339, v.StringValue); err != nil {
+				return "must be a date in YYYY-MM-DD or RFC3339 format"
+			}
+		}
+
+		return f.validateEnum(v.StringValue)
+	}
+
+	return ""
+}
+
+func (f *field) validateEnum(value string) string {
+	if len(f.EnumValues) == 0 {
+		return ""
+	}
+
+	for _, v := range f.EnumValues {
+		if v == value {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("must be one of %v", f.EnumValues)
+}
+
+// JSONSchema describes the data as a draft-07 JSON Schema object
+func (s *Schema) JSONSchema() (*structpb.Struct, error) {
+	properties := map[string]interface{}{}
+	required := []interface{}{}
+
+	for _, f := range s.fields {
+		item := map[string]interface{}{}
+
+		switch f.DataType {
+		case DataTypeString:
+			item["type"] = "string"
+			setRange(item, f.UserInfoField, "minLength", "maxLength")
+			if len(f.Pattern) > 0 {
+				item["pattern"] = f.Pattern
+			}
+		case DataTypeNumber:
+			item["type"] = "number"
+			setRange(item, f.UserInfoField, "minimum", "maximum")
+		case DataTypeInteger:
+			item["type"] = "integer"
+			setRange(item, f.UserInfoField, "minimum", "maximum")
+		case DataTypeBoolean:
+			item["type"] = "boolean"
+		case DataTypeDate:
+			item["type"] = "string"
+			item["format"] = "date"
+		}
+
+		if len(f.EnumValues) > 0 {
+			enum := []interface{}{}
+			for _, v := range f.EnumValues {
+				if f.DataType == DataTypeNumber || f.DataType == DataTypeInteger {
+					n, err := strconv.ParseFloat(v, 64)
+					if err != nil {
+						return nil, errors.Wrapf(err, "field %s has invalid enum value", f.FieldName)
+					}
+					enum = append(enum, n)
+					continue
+				}
+				enum = append(enum, v)
+			}
+			item["enum"] = enum
+		}
+
+		property := item
+		if f.FieldType == FieldTypeArray {
+			property = map[string]interface{}{
+				"type":  "array",
+				"items": item,
+			}
+
+			if f.Required {
+				property["minItems"] = 1
+			}
+		}
+
+		if f.DefaultValue != nil {
+			property["default"] = f.DefaultValue.AsInterface()
+		}
+
+		if f.Required {
+			required = append(required, f.FieldName)
+		}
+
+		properties[f.FieldName] = property
+	}
+
+	return structpb.NewStruct(map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	})
+}
+
+func setRange(item map[string]interface{}, f *pb.UserInfoField, minKey, maxKey string) {
+	if f.MinValue != nil {
+		item[minKey] = f.MinValue.Value
+	}
+
+	if f.MaxValue != nil {
+		item[maxKey] = f.MaxValue.Value
+	}
+}
+
+func isNull(value *structpb.Value) bool {
+	_, ok := value.Kind.(*structpb.Value_NullValue)
+	return value.Kind == nil || ok
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func fieldError(name, message string) *pb.UserInfoFieldError {
+	return &pb.UserInfoFieldError{
+		FieldName: name,
+		Message:   message,
+	}
+}
@@ -0,0 +1,256 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CeremonyCreate is the client data type of a registration ceremony
+	CeremonyCreate = "webauthn.create"
+	// CeremonyGet is the client data type of an authentication ceremony
+	CeremonyGet = "webauthn.get"
+
+	flagUserPresent        byte = 0x01
+	flagUserVerified       byte = 0x04
+	flagAttestedCredential byte = 0x40
+
+	challengeLength = 32
+)
+
+var (
+	ErrInvalidClientData        = errors.New("invalid client data")
+	ErrInvalidAuthenticatorData = errors.New("invalid authenticator data")
+	ErrInvalidSignature         = errors.New("invalid signature")
+	ErrSignCountRollback        = errors.New("sign count has not increased, the authenticator may have been cloned")
+	ErrUserNotVerified          = errors.New("user is not verified by the authenticator")
+	ErrUnsupportedKey           = errors.New("unsupported public key")
+)
+
+// ClientData ...
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// AuthenticatorData ...
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+}
+
+// UserPresent ...
+func (a *AuthenticatorData) UserPresent() bool {
+	return a.Flags&flagUserPresent != 0
+}
+
+// UserVerified ...
+func (a *AuthenticatorData) UserVerified() bool {
+	return a.Flags&flagUserVerified != 0
+}
+
+// AAGUIDString formats the authenticator model id as uuid, empty if it wasn't attested
+func (a *AuthenticatorData) AAGUIDString() string {
+	if len(a.AAGUID) != 16 {
+		return ""
+	}
+
+	h := hex.EncodeToString(a.AAGUID)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// NewChallenge returns a random base64url encoded challenge
+func NewChallenge() (string, error) {
+	b := make([]byte, challengeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return EncodeToString(b), nil
+}
+
+// EncodeToString encodes bytes the way browsers do in WebAuthn payloads (base64url without padding)
+func EncodeToString(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeString accepts both padded and unpadded base64url
+func DecodeString(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// RPIDFromSubdomain derives the relying party id from the client platform subdomain,
+// which may be stored either as a bare host or as a full url
+func RPIDFromSubdomain(subdomain string) (string, error) {
+	subdomain = strings.TrimSpace(subdomain)
+	if subdomain == "" {
+		return "", errors.New("client platform has no subdomain")
+	}
+
+	if !strings.Contains(subdomain, "://") {
+		subdomain = "https://" + subdomain
+	}
+
+	u, err := url.Parse(subdomain)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid client platform subdomain")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return "", errors.New("invalid client platform subdomain")
+	}
+
+	return host, nil
+}
+
+// ParseClientData decodes clientDataJSON and checks it against the expected ceremony
+func ParseClientData(clientDataJSON []byte, ceremony, challenge, rpID string) (*ClientData, error) {
+	clientData := &ClientData{}
+
+	if err := json.Unmarshal(clientDataJSON, clientData); err != nil {
+		return nil, errors.Wrap(ErrInvalidClientData, err.Error())
+	}
+
+	if clientData.Type != ceremony {
+		return nil, errors.Wrap(ErrInvalidClientData, "unexpected ceremony type")
+	}
+
+	if strings.TrimRight(clientData.Challenge, "=") != strings.TrimRight(challenge, "=") {
+		return nil, errors.Wrap(ErrInvalidClientData, "challenge mismatch")
+	}
+
+	origin, err := url.Parse(clientData.Origin)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidClientData, "invalid origin")
+	}
+
+	host := strings.ToLower(origin.Hostname())
+	if host != rpID && !strings.HasSuffix(host, "."+rpID) {
+		return nil, errors.Wrap(ErrInvalidClientData, "origin doesn't belong to the relying party")
+	}
+
+	if origin.Scheme != "https" && host != "localhost" {
+		return nil, errors.Wrap(ErrInvalidClientData, "origin must be served over https")
+	}
+
+	return clientData, nil
+}
+
+// ParseAuthenticatorData decodes authenticator data and checks the relying party id hash
+func ParseAuthenticatorData(raw []byte, rpID string) (*AuthenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, errors.Wrap(ErrInvalidAuthenticatorData, "too short")
+	}
+
+	authData := &AuthenticatorData{
+		RPIDHash:  raw[0:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return nil, errors.Wrap(ErrInvalidAuthenticatorData, "relying party id mismatch")
+	}
+
+	if !authData.UserPresent() {
+		return nil, errors.Wrap(ErrInvalidAuthenticatorData, "user is not present")
+	}
+
+	if authData.Flags&flagAttestedCredential != 0 {
+		if len(raw) < 55 {
+			return nil, errors.Wrap(ErrInvalidAuthenticatorData, "attested credential data is too short")
+		}
+
+		authData.AAGUID = raw[37:53]
+		credentialIDLength := int(binary.BigEndian.Uint16(raw[53:55]))
+
+		if len(raw) < 55+credentialIDLength {
+			return nil, errors.Wrap(ErrInvalidAuthenticatorData, "credential id is too short")
+		}
+
+		authData.CredentialID = raw[55 : 55+credentialIDLength]
+	}
+
+	return authData, nil
+}
+
+// VerifyPublicKey makes sure a registered key is a DER encoded SubjectPublicKeyInfo we can verify with
+func VerifyPublicKey(publicKey []byte) error {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return errors.Wrap(ErrUnsupportedKey, err.Error())
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return nil
+	default:
+		return ErrUnsupportedKey
+	}
+}
+
+// VerifySignature checks an assertion signature over authenticatorData || sha256(clientDataJSON)
+func VerifySignature(publicKey, authenticatorData, clientDataJSON, signature []byte) error {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return errors.Wrap(ErrUnsupportedKey, err.Error())
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+	digest := sha256.Sum256(signed)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest[:], signature) {
+			return ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, signed, signature) {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedKey
+	}
+
+	return nil
+}
+
+// VerifySignCount rejects assertions whose counter didn't move forward,
+// authenticators that don't implement counters always report zero
+func VerifySignCount(stored int64, received uint32) error {
+	if stored == 0 && received == 0 {
+		return nil
+	}
+
+	if int64(received) <= stored {
+		return ErrSignCountRollback
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/helper"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+func (s *Scheduler) purgeSessions(ctx context.Context) error {
+	rowsAffected, err := s.strg.Session().DeleteExpired(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->purge_sessions", logger.Any("sessions", rowsAffected))
+
+	rowsAffected, err = s.strg.RevokedSession().DeleteExpired(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->purge_sessions", logger.Any("revoked_sessions", rowsAffected))
+
+	return nil
+}
+
+func (s *Scheduler) purgePasscodes(ctx context.Context) error {
+	rowsAffected, err := s.strg.Passcode().DeleteExpired(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->purge_passcodes", logger.Any("passcodes", rowsAffected))
+
+	rowsAffected, err = s.strg.PasskeyChallenge().DeleteExpired(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->purge_passcodes", logger.Any("passkey_challenges", rowsAffected))
+
+	return nil
+}
+
+func (s *Scheduler) expireUsers(ctx context.Context) error {
+	rowsAffected, err := s.strg.User().ExpirePassed(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->expire_users", logger.Any("expired", rowsAffected))
+
+	rowsAffected, err = s.strg.User().EndSuspensions(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->expire_users", logger.Any("reinstated", rowsAffected))
+
+	return nil
+}
+
+func (s *Scheduler) expireIntegrations(ctx context.Context) error {
+	rowsAffected, err := s.strg.Integration().ExpirePassed(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->expire_integrations", logger.Any("expired", rowsAffected))
+
+	return nil
+}
+
+// purgeDeletedUsers anonymizes users whose restore window has passed
+func (s *Scheduler) purgeDeletedUsers(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -s.cfg.UserDeleteRetentionDays).Format(config.DatabaseTimeLayout)
+
+	rowsAffected, err := s.strg.User().PurgeDeleted(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("---Scheduler--->purge_deleted_users", logger.Any("anonymized", rowsAffected))
+
+	return nil
+}
+
+// notifyUserExpiry emails users once their account is about to expire,
+// the flag is reset when expires_at is changed
+func (s *Scheduler) notifyUserExpiry(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, s.cfg.UserExpiryNotifyDays).Format(config.DatabaseTimeLayout)
+
+	users, err := s.strg.User().GetListToNotifyExpiry(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if len(user.Email) == 0 {
+			continue
+		}
+
+		err = helper.SendNotificationEmail("Account Expiry", user.Email, "Your account expires at "+user.ExpiresAt)
+		if err != nil {
+			s.log.Error("!!!Scheduler--->notify_user_expiry", logger.Error(err), logger.String("user_id", user.Id))
+			continue
+		}
+
+		_, err = s.strg.User().SetExpiryNotifiedAt(ctx, &pb.UserPrimaryKey{Id: user.Id})
+		if err != nil {
+			return err
+		}
+	}
+
+	s.log.Info("---Scheduler--->notify_user_expiry", logger.Any("users", len(users)))
+
+	return nil
+}
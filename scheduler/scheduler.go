@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+	"upm/udevs_go_auth_service/config"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/saidamir98/udevs_pkg/logger"
+)
+
+// Job is run every Interval by at most one replica at a time
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	cfg  config.Config
+	log  logger.LoggerI
+	strg storage.StorageI
+	jobs []Job
+}
+
+func New(cfg config.Config, log logger.LoggerI, strg storage.StorageI) *Scheduler {
+	s := &Scheduler{
+		cfg:  cfg,
+		log:  log,
+		strg: strg,
+	}
+
+	s.jobs = []Job{
+		{Name: "purge_sessions", Interval: config.CleanupJobInterval, Run: s.purgeSessions},
+		{Name: "purge_passcodes", Interval: config.CleanupJobInterval, Run: s.purgePasscodes},
+		{Name: "expire_users", Interval: config.ExpiryJobInterval, Run: s.expireUsers},
+		{Name: "expire_integrations", Interval: config.ExpiryJobInterval, Run: s.expireIntegrations},
+		{Name: "purge_deleted_users", Interval: config.PurgeDeletedUsersJobInterval, Run: s.purgeDeletedUsers},
+	}
+
+	if cfg.UserExpiryNotifyDays > 0 {
+		s.jobs = append(s.jobs, Job{Name: "notify_user_expiry", Interval: config.ExpiryNotificationJobInterval, Run: s.notifyUserExpiry})
+	}
+
+	return s
+}
+
+// Start runs every job right away and then on its interval until ctx is done
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	startedAt := time.Now()
+
+	acquired, err := s.strg.AdvisoryLock().Do(ctx, "scheduler:"+job.Name, job.Run)
+	if err != nil {
+		s.log.Error("!!!Scheduler--->"+job.Name, logger.Error(err))
+		return
+	}
+
+	if !acquired {
+		s.log.Info("---Scheduler--->"+job.Name, logger.String("skipped", "locked by another replica"))
+		return
+	}
+
+	s.log.Info("---Scheduler--->"+job.Name, logger.Any("duration", time.Since(startedAt).String()))
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type advisoryLockRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAdvisoryLockRepo(db *pgxpool.Pool) storage.AdvisoryLockRepoI {
+	return &advisoryLockRepo{
+		db: db,
+	}
+}
+
+// Do holds a session level advisory lock on a dedicated connection while fn runs,
+// the lock is released with the connection even if unlock fails
+func (r *advisoryLockRepo) Do(ctx context.Context, key string, fn func(ctx context.Context) error) (acquired bool, err error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, key).Scan(&acquired)
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	defer func() {
+		_, unlockErr := conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, key)
+		if unlockErr != nil {
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	return true, fn(ctx)
+}
@@ -227,7 +227,8 @@ func (r *clientRepo) GetMatrix(ctx context.Context, req *pb.GetClientMatrixReque
 		name,
 		confirm_by,
 		self_register,
-		self_recover
+		self_recover,
+		require_verified_contact
 	FROM
 		"client_type"
 	WHERE
@@ -249,6 +250,7 @@ func (r *clientRepo) GetMatrix(ctx context.Context, req *pb.GetClientMatrixReque
 			&confirmBy,
 			&obj.SelfRegister,
 			&obj.SelfRecover,
+			&obj.RequireVerifiedContact,
 		)
 		if err != nil {
 			return res, err
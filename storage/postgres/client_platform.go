@@ -108,7 +108,8 @@ func (r *clientPlatformRepo) GetByPKDetailed(ctx context.Context, pKey *pb.Clien
 	  id,
 	  client_platform_id,
 	  parent_id,
-	  name
+	  name,
+	  recent_auth_minutes
 	FROM
 	  "permission"
 	WHERE
@@ -130,6 +131,7 @@ func (r *clientPlatformRepo) GetByPKDetailed(ctx context.Context, pKey *pb.Clien
 			&tempPersmission.ClientPlatformId,
 			&parentId,
 			&name,
+			&tempPersmission.RecentAuthMinutes,
 		)
 		if err != nil {
 			return res, err
@@ -29,14 +29,16 @@ func (r *clientTypeRepo) Create(ctx context.Context, entity *pb.CreateClientType
 		name,
 		confirm_by,
 		self_register,
-		self_recover
+		self_recover,
+		require_verified_contact
 	) VALUES (
 		$1,
 		$2,
 		$3,
 		$4,
 		$5,
-		$6
+		$6,
+		$7
 	)`
 
 	uuid, err := uuid.NewRandom()
@@ -51,6 +53,7 @@ func (r *clientTypeRepo) Create(ctx context.Context, entity *pb.CreateClientType
 		entity.ConfirmBy.String(),
 		entity.SelfRegister,
 		entity.SelfRecover,
+		entity.RequireVerifiedContact,
 	)
 
 	pKey = &pb.ClientTypePrimaryKey{
@@ -69,7 +72,8 @@ func (r *clientTypeRepo) GetByPK(ctx context.Context, pKey *pb.ClientTypePrimary
 		name,
 		confirm_by,
 		self_register,
-		self_recover
+		self_recover,
+		require_verified_contact
 	FROM
 		"client_type"
 	WHERE
@@ -82,6 +86,7 @@ func (r *clientTypeRepo) GetByPK(ctx context.Context, pKey *pb.ClientTypePrimary
 		&confirmBy,
 		&res.SelfRegister,
 		&res.SelfRecover,
+		&res.RequireVerifiedContact,
 	)
 
 	res.ConfirmBy = pb.ConfirmStrategies(pb.ConfirmStrategies_value[confirmBy])
@@ -102,7 +107,8 @@ func (r *clientTypeRepo) GetList(ctx context.Context, queryParam *pb.GetClientTy
 		name,
 		confirm_by,
 		self_register,
-		self_recover
+		self_recover,
+		require_verified_contact
 	FROM
 		"client_type"`
 	filter := " WHERE 1=1"
@@ -162,6 +168,7 @@ func (r *clientTypeRepo) GetList(ctx context.Context, queryParam *pb.GetClientTy
 			&confirmBy,
 			&obj.SelfRegister,
 			&obj.SelfRecover,
+			&obj.RequireVerifiedContact,
 		)
 		if err != nil {
 			return res, err
@@ -179,16 +186,18 @@ func (r *clientTypeRepo) Update(ctx context.Context, entity *pb.UpdateClientType
 		confirm_by = :confirm_by,
 		self_register = :self_register,
 		self_recover = :self_recover,
+		require_verified_contact = :require_verified_contact,
 		updated_at = now()
 	WHERE
 		id = :id`
 
 	params := map[string]interface{}{
-		"id":            entity.Id,
-		"name":          entity.Name,
-		"confirm_by":    entity.ConfirmBy.String(),
-		"self_register": entity.SelfRegister,
-		"self_recover":  entity.SelfRecover,
+		"id":                       entity.Id,
+		"name":                     entity.Name,
+		"confirm_by":               entity.ConfirmBy.String(),
+		"self_register":            entity.SelfRegister,
+		"self_recover":             entity.SelfRecover,
+		"require_verified_contact": entity.RequireVerifiedContact,
 	}
 
 	q, arr := helper.ReplaceQueryParams(query, params)
@@ -229,7 +238,8 @@ func (r *clientTypeRepo) GetCompleteByPK(ctx context.Context, pKey *pb.ClientTyp
 		name,
 		confirm_by,
 		self_register,
-		self_recover
+		self_recover,
+		require_verified_contact
 	FROM
 		"client_type"
 	WHERE
@@ -244,6 +254,7 @@ func (r *clientTypeRepo) GetCompleteByPK(ctx context.Context, pKey *pb.ClientTyp
 		&confirmBy,
 		&res.ClientType.SelfRegister,
 		&res.ClientType.SelfRecover,
+		&res.ClientType.RequireVerifiedContact,
 	)
 
 	res.ClientType.ConfirmBy = pb.ConfirmStrategies(pb.ConfirmStrategies_value[confirmBy])
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type dataSubjectRequestRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDataSubjectRequestRepo(db *pgxpool.Pool) storage.DataSubjectRequestRepoI {
+	return &dataSubjectRequestRepo{
+		db: db,
+	}
+}
+
+// Create stores the receipt as it was signed, created_at is kept verbatim so the signature can be checked later
+func (r *dataSubjectRequestRepo) Create(ctx context.Context, entity *pb.DataSubjectReceipt) (err error) {
+	query := `INSERT INTO "data_subject_request" (
+		id,
+		user_id,
+		action,
+		digest,
+		requested_by,
+		reason,
+		signature,
+		created_at
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7,
+		$8
+	)`
+
+	_, err = r.db.Exec(ctx, query,
+		entity.Id,
+		entity.UserId,
+		entity.Action,
+		entity.Digest,
+		entity.RequestedBy,
+		entity.Reason,
+		entity.Signature,
+		entity.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByPK returns the stored receipt, created_at isn't selected back since the signed value is the one the receipt holds
+func (r *dataSubjectRequestRepo) GetByPK(ctx context.Context, id string) (res *pb.DataSubjectReceipt, err error) {
+	res = &pb.DataSubjectReceipt{}
+
+	query := `SELECT
+		id,
+		user_id,
+		action,
+		digest,
+		requested_by,
+		reason,
+		signature
+	FROM
+		"data_subject_request"
+	WHERE
+		id = $1`
+
+	err = r.db.QueryRow(ctx, query, id).Scan(
+		&res.Id,
+		&res.UserId,
+		&res.Action,
+		&res.Digest,
+		&res.RequestedBy,
+		&res.Reason,
+		&res.Signature,
+	)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
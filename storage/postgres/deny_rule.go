@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type denyRuleRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDenyRuleRepo(db *pgxpool.Pool) storage.DenyRuleRepoI {
+	return &denyRuleRepo{
+		db: db,
+	}
+}
+
+func (r *denyRuleRepo) Create(ctx context.Context, entity *pb.CreateDenyRuleRequest) (pKey *pb.DenyRulePrimaryKey, err error) {
+	query := `INSERT INTO "deny_rule" (
+		id,
+		client_platform_id,
+		role_id,
+		user_id,
+		permission_id,
+		method,
+		path_pattern,
+		reason
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7,
+		$8
+	)`
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return pKey, err
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		id.String(),
+		entity.ClientPlatformId,
+		nullString(entity.RoleId),
+		nullString(entity.UserId),
+		nullString(entity.PermissionId),
+		nullString(entity.Method),
+		nullString(entity.PathPattern),
+		entity.Reason,
+	)
+
+	pKey = &pb.DenyRulePrimaryKey{
+		Id: id.String(),
+	}
+
+	return pKey, err
+}
+
+func nullString(s string) *string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return &s
+}
+
+const denyRuleSelect = `SELECT
+		dr.id,
+		dr.client_platform_id,
+		COALESCE(dr.role_id::VARCHAR, ''),
+		COALESCE(dr.user_id::VARCHAR, ''),
+		COALESCE(dr.permission_id::VARCHAR, ''),
+		COALESCE(dr.method, ''),
+		COALESCE(dr.path_pattern, ''),
+		dr.reason,
+		TO_CHAR(dr.created_at, ` + config.DatabaseQueryTimeLayout + `)
+	FROM
+		"deny_rule" AS dr`
+
+func scanDenyRule(row pgx.Row) (*pb.DenyRule, error) {
+	res := &pb.DenyRule{}
+
+	err := row.Scan(
+		&res.Id,
+		&res.ClientPlatformId,
+		&res.RoleId,
+		&res.UserId,
+		&res.PermissionId,
+		&res.Method,
+		&res.PathPattern,
+		&res.Reason,
+		&res.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func scanDenyRules(rows pgx.Rows) (res []*pb.DenyRule, err error) {
+	defer rows.Close()
+
+	res = []*pb.DenyRule{}
+	for rows.Next() {
+		obj, err := scanDenyRule(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *denyRuleRepo) GetByPK(ctx context.Context, pKey *pb.DenyRulePrimaryKey) (res *pb.DenyRule, err error) {
+	query := denyRuleSelect + `
+	WHERE
+		dr.id = $1`
+
+	return scanDenyRule(r.db.QueryRow(ctx, query, pKey.Id))
+}
+
+func (r *denyRuleRepo) GetList(ctx context.Context, queryParam *pb.GetDenyRuleListRequest) (res []*pb.DenyRule, err error) {
+	query := denyRuleSelect + `
+	WHERE
+		dr.client_platform_id = $1
+		AND ($2 = '' OR dr.role_id::VARCHAR = $2)
+		AND ($3 = '' OR dr.user_id::VARCHAR = $3)
+	ORDER BY dr.created_at`
+
+	rows, err := r.db.Query(ctx, query, queryParam.ClientPlatformId, queryParam.RoleId, queryParam.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDenyRules(rows)
+}
+
+// GetListForSubjects returns the deny rules of the user and of the roles with their ancestors,
+// the rules of the user first, an empty userID gives the rules of the roles only
+func (r *denyRuleRepo) GetListForSubjects(ctx context.Context, clientPlatformID, userID string, roleIDs []string) (res []*pb.DenyRule, err error) {
+	return getDenyRulesForSubjects(ctx, r.db, clientPlatformID, userID, roleIDs)
+}
+
+func getDenyRulesForSubjects(ctx context.Context, db *pgxpool.Pool, clientPlatformID, userID string, roleIDs []string) (res []*pb.DenyRule, err error) {
+	query := roleTreeCTE + `
+	` + denyRuleSelect + `
+	WHERE
+		dr.client_platform_id = $2
+		AND (dr.user_id = NULLIF($3, '')::UUID OR dr.role_id IN (SELECT role_id FROM role_tree))
+	ORDER BY dr.user_id IS NULL, dr.created_at`
+
+	rows, err := db.Query(ctx, query, roleIDs, clientPlatformID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDenyRules(rows)
+}
+
+func (r *denyRuleRepo) Delete(ctx context.Context, pKey *pb.DenyRulePrimaryKey) (rowsAffected int64, err error) {
+	query := `DELETE FROM "deny_rule" WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
@@ -568,3 +568,49 @@ func (r *IntegrationRepo) GetIntegrationSession(ctx context.Context, req *pb.Get
 
 	return res, nil
 }
+
+// ExpirePassed deactivates integrations whose expires_at has passed and drops their sessions
+func (r *IntegrationRepo) ExpirePassed(ctx context.Context) (rowsAffected int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE "integration" SET
+		active = -1,
+		updated_at = now()
+	WHERE
+		active > 0 AND expires_at < now()
+	RETURNING id`
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM "session" WHERE integration_id = ANY($1)`, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(ids)), tx.Commit(ctx)
+}
@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type passcodeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPasscodeRepo(db *pgxpool.Pool) storage.PasscodeRepoI {
+	return &passcodeRepo{
+		db: db,
+	}
+}
+
+func (r *passcodeRepo) Create(ctx context.Context, entity *pb.CreatePasscodeRequest) (pKey *pb.PasscodePrimaryKey, err error) {
+	query := `INSERT INTO "passcode" (
+		id,
+		project_id,
+		client_platform_id,
+		client_type_id,
+		user_id,
+		confirm_by,
+		hashed_code,
+		state,
+		expires_at,
+		purpose,
+		target
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7,
+		$8,
+		$9,
+		$10,
+		$11
+	)`
+
+	uuid, err := uuid.NewRandom()
+	if err != nil {
+		return pKey, err
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		uuid.String(),
+		entity.ProjectId,
+		entity.ClientPlatformId,
+		entity.ClientTypeId,
+		entity.UserId,
+		entity.ConfirmBy.String(),
+		entity.HashedCode,
+		config.PasscodeStatePending,
+		entity.ExpiresAt,
+		entity.Purpose,
+		entity.Target,
+	)
+
+	pKey = &pb.PasscodePrimaryKey{
+		Id: uuid.String(),
+	}
+
+	return pKey, err
+}
+
+func (r *passcodeRepo) GetByPK(ctx context.Context, pKey *pb.PasscodePrimaryKey) (res *pb.Passcode, err error) {
+	res = &pb.Passcode{}
+	var confirmBy string
+
+	query := `SELECT
+		id,
+		project_id,
+		client_platform_id,
+		client_type_id,
+		user_id,
+		confirm_by,
+		hashed_code,
+		state,
+		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
+		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at,
+		purpose,
+		target
+	FROM
+		"passcode"
+	WHERE
+		id = $1`
+
+	err = r.db.QueryRow(ctx, query, pKey.Id).Scan(
+		&res.Id,
+		&res.ProjectId,
+		&res.ClientPlatformId,
+		&res.ClientTypeId,
+		&res.UserId,
+		&confirmBy,
+		&res.HashedCode,
+		&res.State,
+		&res.ExpiresAt,
+		&res.CreatedAt,
+		&res.UpdatedAt,
+		&res.Purpose,
+		&res.Target,
+	)
+	if err != nil {
+		return res, err
+	}
+
+	res.ConfirmBy = pb.ConfirmStrategies(pb.ConfirmStrategies_value[confirmBy])
+
+	return res, nil
+}
+
+// GetListByUserID returns passcodes sent to the user that haven't been purged yet, codes are left out
+func (r *passcodeRepo) GetListByUserID(ctx context.Context, userID string) (res []*pb.Passcode, err error) {
+	res = []*pb.Passcode{}
+
+	query := `SELECT
+		id,
+		project_id,
+		client_platform_id,
+		client_type_id,
+		user_id,
+		confirm_by,
+		state,
+		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
+		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at,
+		purpose,
+		target
+	FROM
+		"passcode"
+	WHERE
+		user_id = $1
+	ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &pb.Passcode{}
+		var confirmBy string
+
+		err = rows.Scan(
+			&obj.Id,
+			&obj.ProjectId,
+			&obj.ClientPlatformId,
+			&obj.ClientTypeId,
+			&obj.UserId,
+			&confirmBy,
+			&obj.State,
+			&obj.ExpiresAt,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+			&obj.Purpose,
+			&obj.Target,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		obj.ConfirmBy = pb.ConfirmStrategies(pb.ConfirmStrategies_value[confirmBy])
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+func (r *passcodeRepo) UpdateState(ctx context.Context, pKey *pb.PasscodePrimaryKey, state int32) (rowsAffected int64, err error) {
+	query := `UPDATE "passcode" SET
+		state = $2,
+		updated_at = now()
+	WHERE
+		id = $1 AND state <> $2`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id, state)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+// UseAttempt counts a check of a pending passcode, no rows are affected once maxAttempts have been used
+func (r *passcodeRepo) UseAttempt(ctx context.Context, pKey *pb.PasscodePrimaryKey, maxAttempts int32) (rowsAffected int64, err error) {
+	query := `UPDATE "passcode" SET
+		attempts = attempts + 1,
+		updated_at = now()
+	WHERE
+		id = $1 AND state = $2 AND attempts < $3`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id, config.PasscodeStatePending, maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+func (r *passcodeRepo) DeleteExpired(ctx context.Context) (rowsAffected int64, err error) {
+	query := `DELETE FROM "passcode" WHERE expires_at < now()`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type passkeyChallengeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPasskeyChallengeRepo(db *pgxpool.Pool) storage.PasskeyChallengeRepoI {
+	return &passkeyChallengeRepo{
+		db: db,
+	}
+}
+
+func (r *passkeyChallengeRepo) Create(ctx context.Context, entity *pb.CreatePasskeyChallengeRequest) (pKey *pb.PasskeyChallengePrimaryKey, err error) {
+	query := `INSERT INTO "passkey_challenge" (
+		id,
+		client_platform_id,
+		user_id,
+		ceremony,
+		challenge,
+		rp_id,
+		expires_at
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7
+	)`
+
+	uuid, err := uuid.NewRandom()
+	if err != nil {
+		return pKey, err
+	}
+
+	var userID *string
+	if len(entity.UserId) > 0 {
+		userID = &entity.UserId
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		uuid.String(),
+		entity.ClientPlatformId,
+		userID,
+		entity.Ceremony,
+		entity.Challenge,
+		entity.RpId,
+		entity.ExpiresAt,
+	)
+
+	pKey = &pb.PasskeyChallengePrimaryKey{
+		Id: uuid.String(),
+	}
+
+	return pKey, err
+}
+
+func (r *passkeyChallengeRepo) GetByPK(ctx context.Context, pKey *pb.PasskeyChallengePrimaryKey) (res *pb.PasskeyChallenge, err error) {
+	res = &pb.PasskeyChallenge{}
+	query := `SELECT
+		id,
+		client_platform_id,
+		user_id,
+		ceremony,
+		challenge,
+		rp_id,
+		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at
+	FROM
+		"passkey_challenge"
+	WHERE
+		id = $1`
+
+	var userID *string
+	err = r.db.QueryRow(ctx, query, pKey.Id).Scan(
+		&res.Id,
+		&res.ClientPlatformId,
+		&userID,
+		&res.Ceremony,
+		&res.Challenge,
+		&res.RpId,
+		&res.ExpiresAt,
+		&res.CreatedAt,
+	)
+	if err != nil {
+		return res, err
+	}
+
+	if userID != nil {
+		res.UserId = *userID
+	}
+
+	return res, nil
+}
+
+func (r *passkeyChallengeRepo) Delete(ctx context.Context, pKey *pb.PasskeyChallengePrimaryKey) (rowsAffected int64, err error) {
+	query := `DELETE FROM "passkey_challenge" WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+func (r *passkeyChallengeRepo) DeleteExpired(ctx context.Context) (rowsAffected int64, err error) {
+	query := `DELETE FROM "passkey_challenge" WHERE expires_at < now()`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type passkeyCredentialRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPasskeyCredentialRepo(db *pgxpool.Pool) storage.PasskeyCredentialRepoI {
+	return &passkeyCredentialRepo{
+		db: db,
+	}
+}
+
+func (r *passkeyCredentialRepo) Create(ctx context.Context, entity *pb.CreatePasskeyCredentialRequest) (pKey *pb.PasskeyCredentialPrimaryKey, err error) {
+	query := `INSERT INTO "passkey_credential" (
+		id,
+		user_id,
+		credential_id,
+		name,
+		public_key,
+		public_key_algorithm,
+		sign_count,
+		transports,
+		aaguid
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7,
+		$8,
+		$9
+	)`
+
+	uuid, err := uuid.NewRandom()
+	if err != nil {
+		return pKey, err
+	}
+
+	var aaguid *string
+	if len(entity.Aaguid) > 0 {
+		aaguid = &entity.Aaguid
+	}
+
+	transports := entity.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		uuid.String(),
+		entity.UserId,
+		entity.CredentialId,
+		entity.Name,
+		entity.PublicKey,
+		entity.PublicKeyAlgorithm,
+		entity.SignCount,
+		transports,
+		aaguid,
+	)
+
+	pKey = &pb.PasskeyCredentialPrimaryKey{
+		Id: uuid.String(),
+	}
+
+	return pKey, err
+}
+
+func (r *passkeyCredentialRepo) GetByPK(ctx context.Context, pKey *pb.PasskeyCredentialPrimaryKey) (res *pb.PasskeyCredential, err error) {
+	query := `SELECT
+		id,
+		user_id,
+		credential_id,
+		name,
+		public_key,
+		public_key_algorithm,
+		sign_count,
+		transports,
+		aaguid,
+		TO_CHAR(last_used_at, ` + config.DatabaseQueryTimeLayout + `) AS last_used_at,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
+		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at
+	FROM
+		"passkey_credential"
+	WHERE
+		id = $1`
+
+	return r.getOne(ctx, query, pKey.Id)
+}
+
+func (r *passkeyCredentialRepo) GetByCredentialID(ctx context.Context, credentialID string) (res *pb.PasskeyCredential, err error) {
+	query := `SELECT
+		id,
+		user_id,
+		credential_id,
+		name,
+		public_key,
+		public_key_algorithm,
+		sign_count,
+		transports,
+		aaguid,
+		TO_CHAR(last_used_at, ` + config.DatabaseQueryTimeLayout + `) AS last_used_at,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
+		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at
+	FROM
+		"passkey_credential"
+	WHERE
+		credential_id = $1`
+
+	return r.getOne(ctx, query, credentialID)
+}
+
+func (r *passkeyCredentialRepo) getOne(ctx context.Context, query string, arg string) (res *pb.PasskeyCredential, err error) {
+	res = &pb.PasskeyCredential{}
+
+	var (
+		aaguid     *string
+		lastUsedAt sql.NullString
+	)
+
+	err = r.db.QueryRow(ctx, query, arg).Scan(
+		&res.Id,
+		&res.UserId,
+		&res.CredentialId,
+		&res.Name,
+		&res.PublicKey,
+		&res.PublicKeyAlgorithm,
+		&res.SignCount,
+		&res.Transports,
+		&aaguid,
+		&lastUsedAt,
+		&res.CreatedAt,
+		&res.UpdatedAt,
+	)
+	if err != nil {
+		return res, err
+	}
+
+	if aaguid != nil {
+		res.Aaguid = *aaguid
+	}
+
+	if lastUsedAt.Valid {
+		res.LastUsedAt = lastUsedAt.String
+	}
+
+	return res, nil
+}
+
+// GetListByUserID doesn't select public keys, the list is shown to the user
+func (r *passkeyCredentialRepo) GetListByUserID(ctx context.Context, userID string) (res []*pb.PasskeyCredential, err error) {
+	res = []*pb.PasskeyCredential{}
+
+	query := `SELECT
+		id,
+		user_id,
+		credential_id,
+		name,
+		public_key_algorithm,
+		sign_count,
+		transports,
+		aaguid,
+		TO_CHAR(last_used_at, ` + config.DatabaseQueryTimeLayout + `) AS last_used_at,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
+		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at
+	FROM
+		"passkey_credential"
+	WHERE
+		user_id = $1
+	ORDER BY created_at`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &pb.PasskeyCredential{}
+		var (
+			aaguid     *string
+			lastUsedAt sql.NullString
+		)
+
+		err = rows.Scan(
+			&obj.Id,
+			&obj.UserId,
+			&obj.CredentialId,
+			&obj.Name,
+			&obj.PublicKeyAlgorithm,
+			&obj.SignCount,
+			&obj.Transports,
+			&aaguid,
+			&lastUsedAt,
+			&obj.CreatedAt,
+			&obj.UpdatedAt,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		if aaguid != nil {
+			obj.Aaguid = *aaguid
+		}
+
+		if lastUsedAt.Valid {
+			obj.LastUsedAt = lastUsedAt.String
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+// UpdateSignCount only moves the counter forward, no rows are affected when the stored counter
+// is already at or above signCount. Authenticators without counters keep reporting zero
+func (r *passkeyCredentialRepo) UpdateSignCount(ctx context.Context, pKey *pb.PasskeyCredentialPrimaryKey, signCount int64) (rowsAffected int64, err error) {
+	query := `UPDATE "passkey_credential" SET
+		sign_count = $2,
+		last_used_at = now(),
+		updated_at = now()
+	WHERE
+		id = $1 AND
+		(sign_count < $2 OR (sign_count = 0 AND $2 = 0))`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id, signCount)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+func (r *passkeyCredentialRepo) Delete(ctx context.Context, pKey *pb.PasskeyCredentialPrimaryKey) (rowsAffected int64, err error) {
+	query := `DELETE FROM "passkey_credential" WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
@@ -2,14 +2,14 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
 	"strings"
-	"upm/udevs_go_auth_service/genproto/auth_service"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/pkg/helper"
 	"upm/udevs_go_auth_service/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -28,12 +28,16 @@ func (r *permissionRepo) Create(ctx context.Context, entity *pb.CreatePermission
 		id,
 		client_platform_id,
 		parent_id,
-		name
+		name,
+		recent_auth_minutes,
+		relation_scoped
 	) VALUES (
 		$1,
 		$2,
 		$3,
-		$4
+		$4,
+		$5,
+		$6
 	)`
 
 	uuid, err := uuid.NewRandom()
@@ -56,6 +60,8 @@ func (r *permissionRepo) Create(ctx context.Context, entity *pb.CreatePermission
 		entity.ClientPlatformId,
 		nullStr,
 		entity.Name,
+		entity.RecentAuthMinutes,
+		entity.RelationScoped,
 	)
 
 	pKey = &pb.PermissionPrimaryKey{
@@ -72,7 +78,9 @@ func (r *permissionRepo) GetByPK(ctx context.Context, pKey *pb.PermissionPrimary
 		id,
 		client_platform_id,
 		parent_id,
-		name
+		name,
+		recent_auth_minutes,
+		relation_scoped
 	FROM
 		"permission"
 	WHERE
@@ -83,6 +91,8 @@ func (r *permissionRepo) GetByPK(ctx context.Context, pKey *pb.PermissionPrimary
 		&res.ClientPlatformId,
 		&nullableStr,
 		&res.Name,
+		&res.RecentAuthMinutes,
+		&res.RelationScoped,
 	)
 	if err != nil {
 		return res, err
@@ -132,7 +142,9 @@ func (r *permissionRepo) GetList(ctx context.Context, queryParam *pb.GetPermissi
 		id,
 		client_platform_id,
 		parent_id,
-		name
+		name,
+		recent_auth_minutes,
+		relation_scoped
 	FROM
 		"permission"`
 	filter := " WHERE 1=1"
@@ -182,6 +194,8 @@ func (r *permissionRepo) GetList(ctx context.Context, queryParam *pb.GetPermissi
 			&obj.ClientPlatformId,
 			&nullableStr,
 			&obj.Name,
+			&obj.RecentAuthMinutes,
+			&obj.RelationScoped,
 		)
 		if nullableStr != nil {
 			obj.ParentId = *nullableStr
@@ -196,48 +210,77 @@ func (r *permissionRepo) GetList(ctx context.Context, queryParam *pb.GetPermissi
 	return res, nil
 }
 
-func (r *permissionRepo) GetListByRoleId(ctx context.Context, roleID string) (res []*pb.Permission, err error) {
-	var (
-		permissionMap = make(map[string]*pb.Permission)
+// effectivePermissionsQuery lists the permissions of the roles $1 and of their ancestors, each once,
+// with the role it's granted by if it isn't granted to one of the roles themselves
+const effectivePermissionsQuery = roleTreeCTE + `, granted AS (
+		SELECT DISTINCT ON (rp.permission_id)
+			rp.permission_id,
+			rt.role_id,
+			rt.depth
+		FROM role_tree AS rt
+		INNER JOIN "role_permission" AS rp ON rp.role_id = rt.role_id
+		ORDER BY rp.permission_id, rt.depth
 	)
-	res = []*pb.Permission{}
-
-	query := `SELECT
+	SELECT
 		p.id,
 		p.client_platform_id,
 		p.parent_id,
-		p.name
-	FROM 
-		"role_permission"
-	AS
-		rp
-	INNER JOIN
-			"permission" 
-		AS 
-			p
-	ON
-		p.id = rp.permission_id
-	WHERE
-		rp.role_id = $1
+		p.name,
+		p.recent_auth_minutes,
+		p.relation_scoped,
+		g.depth > 0,
+		CASE WHEN g.depth > 0 THEN g.role_id::VARCHAR ELSE '' END
+	FROM granted AS g
+	INNER JOIN "permission" AS p ON p.id = g.permission_id
 	ORDER BY p.created_at`
 
-	rows, err := r.db.Query(ctx, query, roleID)
+func scanEffectivePermission(rows pgx.Rows) (*pb.Permission, error) {
+	permission := &pb.Permission{}
+	var parentID *string
+
+	err := rows.Scan(
+		&permission.Id,
+		&permission.ClientPlatformId,
+		&parentID,
+		&permission.Name,
+		&permission.RecentAuthMinutes,
+		&permission.RelationScoped,
+		&permission.Inherited,
+		&permission.InheritedFromRoleId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		permission.ParentId = *parentID
+	}
+
+	return permission, nil
+}
+
+// GetListByRoleId returns the effective permissions of the role, inherited ones included
+func (r *permissionRepo) GetListByRoleId(ctx context.Context, roleID string) (res []*pb.Permission, err error) {
+	return r.GetListByRoleIds(ctx, []string{roleID})
+}
+
+// GetListByRoleIds returns the union of the effective permissions of the roles
+func (r *permissionRepo) GetListByRoleIds(ctx context.Context, roleIDs []string) (res []*pb.Permission, err error) {
+	var (
+		permissionMap = make(map[string]*pb.Permission)
+	)
+	res = []*pb.Permission{}
+
+	rows, err := r.db.Query(ctx, effectivePermissionsQuery, roleIDs)
 	if err != nil {
 		return res, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		permission := &pb.Permission{}
-		var parentID *string
-		err = rows.Scan(
-			&permission.Id,
-			&permission.ClientPlatformId,
-			&parentID,
-			&permission.Name,
-		)
-		if parentID != nil {
-			permission.ParentId = *parentID
+		permission, err := scanEffectivePermission(rows)
+		if err != nil {
+			return res, err
 		}
 
 		if permission.ParentId != "" {
@@ -246,9 +289,6 @@ func (r *permissionRepo) GetListByRoleId(ctx context.Context, roleID string) (re
 
 		permissionMap[permission.Id] = permission
 
-		if err != nil {
-			return res, err
-		}
 		res = append(res, permission)
 	}
 	return res, nil
@@ -264,7 +304,9 @@ func (r *permissionRepo) GetListByClientPlatformId(ctx context.Context, clientPl
 		id,
 		client_platform_id,
 		parent_id,
-		name
+		name,
+		recent_auth_minutes,
+		relation_scoped
 	FROM
 		"permission"
 	WHERE
@@ -285,6 +327,8 @@ func (r *permissionRepo) GetListByClientPlatformId(ctx context.Context, clientPl
 			&permission.ClientPlatformId,
 			&parentID,
 			&permission.Name,
+			&permission.RecentAuthMinutes,
+			&permission.RelationScoped,
 		)
 		if parentID != nil {
 			permission.ParentId = *parentID
@@ -314,6 +358,8 @@ func (r *permissionRepo) Update(ctx context.Context, entity *pb.UpdatePermission
 		client_platform_id = :client_platform_id,
 		parent_id = :parent_id,
 		name = :name,
+		recent_auth_minutes = :recent_auth_minutes,
+		relation_scoped = :relation_scoped,
 		updated_at = now()
 	WHERE
 		id = :id`
@@ -324,10 +370,12 @@ func (r *permissionRepo) Update(ctx context.Context, entity *pb.UpdatePermission
 	}
 
 	params := map[string]interface{}{
-		"id":                 entity.Id,
-		"client_platform_id": entity.ClientPlatformId,
-		"parent_id":          nullStr,
-		"name":               entity.Name,
+		"id":                  entity.Id,
+		"client_platform_id":  entity.ClientPlatformId,
+		"parent_id":           nullStr,
+		"name":                entity.Name,
+		"recent_auth_minutes": entity.RecentAuthMinutes,
+		"relation_scoped":     entity.RelationScoped,
 	}
 
 	q, arr := helper.ReplaceQueryParams(query, params)
@@ -354,54 +402,237 @@ func (r *permissionRepo) Delete(ctx context.Context, pKey *pb.PermissionPrimaryK
 	return rowsAffected, err
 }
 
-func (r *permissionRepo) GeneratePermission(ctx context.Context, req auth_service.PermissionGenerated) (res *auth_service.Permission, err error) {
+// GeneratePermission syncs the permissions of the client platform with the tree, permissions are matched
+// by parent and name, so running it again with the same tree changes nothing, concurrent syncs and
+// applies of the rbac config for the client platform wait for each other
+func (r *permissionRepo) GeneratePermission(ctx context.Context, req *pb.PermissionGenerated) (res *pb.PermissionGeneratedResponse, err error) {
+	res = &pb.PermissionGeneratedResponse{}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, rbacLock, req.ClientPlatformId)
+	if err != nil {
+		return res, err
+	}
+
+	synced := map[string]bool{}
+
+	err = syncPermissions(ctx, tx, req.ClientPlatformId, nil, "", req.Permissions, synced, res)
+	if err != nil {
+		return res, err
+	}
+
+	if req.Prune {
+		err = prunePermissions(ctx, tx, req.ClientPlatformId, synced, res)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return res, err
+	}
 
-	return nil, nil
+	return res, nil
 }
 
-func MakePermissions(permissions *auth_service.PermissionGenerated_Permission) []*auth_service.Permission {
-	var (
-		res []*auth_service.Permission
-	)
-	if permissions.Children == nil {
-		return res
+// syncPermissions creates the missing permissions of one level of the tree and syncs their scopes,
+// then goes down to their children, other fields of existing permissions aren't in the tree and stay
+func syncPermissions(ctx context.Context, tx pgx.Tx, clientPlatformID string, parentID *string, parentPath string, permissions []*pb.PermissionGenerated_Permission, synced map[string]bool, res *pb.PermissionGeneratedResponse) error {
+	names := map[string]bool{}
+
+	for _, permission := range permissions {
+		name := strings.TrimSpace(permission.GetPermission())
+		if len(name) == 0 {
+			return fmt.Errorf("permission under %s has no name", parentPath+"/")
+		}
+
+		path := parentPath + "/" + name
+		if names[name] {
+			return fmt.Errorf("permission %s is given twice", path)
+		}
+		names[name] = true
+
+		var id string
+		err := tx.QueryRow(ctx, `SELECT id FROM "permission"
+		WHERE
+			client_platform_id = $1 AND
+			parent_id IS NOT DISTINCT FROM $2 AND
+			name = $3
+		FOR UPDATE`, clientPlatformID, parentID, name).Scan(&id)
+
+		created := false
+		switch err {
+		case nil:
+		case pgx.ErrNoRows:
+			newID, err := uuid.NewRandom()
+			if err != nil {
+				return err
+			}
+			id = newID.String()
+
+			_, err = tx.Exec(ctx, `INSERT INTO "permission" (
+				id,
+				client_platform_id,
+				parent_id,
+				name
+			) VALUES (
+				$1,
+				$2,
+				$3,
+				$4
+			)`, id, clientPlatformID, parentID, name)
+			if err != nil {
+				return err
+			}
+
+			created = true
+			res.Created = append(res.Created, path)
+		default:
+			return err
+		}
+		synced[id] = true
+
+		added, removed, err := syncPermissionScopes(ctx, tx, clientPlatformID, id, permission.GetScopes())
+		if err != nil {
+			return err
+		}
+		res.ScopesAdded += added
+		res.ScopesRemoved += removed
+
+		switch {
+		case created:
+		case added > 0 || removed > 0:
+			res.Updated = append(res.Updated, path)
+		default:
+			res.Unchanged++
+		}
+
+		err = syncPermissions(ctx, tx, clientPlatformID, &id, path, permission.GetChildren(), synced, res)
+		if err != nil {
+			return err
+		}
 	}
 
-	return res
+	return nil
 }
 
-func InsertScopes(scopes []*auth_service.PermissionGenerated_Permission_Scope, permissionID, clientPlatformID string, tx *sql.Tx) error {
-	values := []interface{}{}
-	query := `
-		INSERT INTO "permission_scope" (
+// syncPermissionScopes makes the scopes of the permission exactly the given ones, scopes are
+// upserted without counting a request so HasAccess statistics stay as they are
+func syncPermissionScopes(ctx context.Context, tx pgx.Tx, clientPlatformID, permissionID string, scopes []*pb.PermissionGenerated_Permission_Scope) (added, removed int32, err error) {
+	paths := []string{}
+	methods := []string{}
+	given := map[string]bool{}
+
+	for _, scope := range scopes {
+		path := strings.TrimSpace(scope.GetUrl())
+		method := strings.ToUpper(strings.TrimSpace(scope.GetMethod()))
+		if len(path) == 0 || len(method) == 0 {
+			return 0, 0, fmt.Errorf("scope of permission %s must have url and method", permissionID)
+		}
+
+		if given[method+" "+path] {
+			continue
+		}
+		given[method+" "+path] = true
+
+		paths = append(paths, path)
+		methods = append(methods, method)
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO "scope" (
+		client_platform_id,
+		path,
+		method,
+		requests
+	) SELECT $1::UUID, s.path, s.method, 0 FROM UNNEST($2::VARCHAR[], $3::VARCHAR[]) AS s(path, method)
+	ON CONFLICT (client_platform_id, path, method) DO NOTHING`, clientPlatformID, paths, methods)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM "permission_scope" AS ps
+	WHERE
+		ps.permission_id = $1 AND
+		NOT EXISTS (
+			SELECT 1 FROM UNNEST($3::VARCHAR[], $4::VARCHAR[]) AS s(path, method)
+			WHERE ps.client_platform_id = $2 AND ps.path = s.path AND ps.method = s.method
+		)`, permissionID, clientPlatformID, paths, methods)
+	if err != nil {
+		return 0, 0, err
+	}
+	removed = int32(result.RowsAffected())
+
+	result, err = tx.Exec(ctx, `INSERT INTO "permission_scope" (
 		permission_id,
 		client_platform_id,
 		path,
 		method
-		) VALUES `
-
-	for _, v := range scopes {
-		query += "(?, ?, ?, ?),"
-		values = append(values,
-			permissionID,
-			clientPlatformID,
-			v.GetUrl(),
-			v.GetMethod(),
-		)
+	) SELECT $1::UUID, $2::UUID, s.path, s.method FROM UNNEST($3::VARCHAR[], $4::VARCHAR[]) AS s(path, method)
+	ON CONFLICT (permission_id, client_platform_id, path, method) DO NOTHING`, permissionID, clientPlatformID, paths, methods)
+	if err != nil {
+		return 0, 0, err
 	}
+	added = int32(result.RowsAffected())
 
-	query = strings.TrimSuffix(query, ",")
-	query = helper.ReplaceSQL(query, "?")
+	return added, removed, nil
+}
 
-	stmt, err := tx.Prepare(query)
+// prunePermissions deletes the permissions of the client platform the sync hasn't reached, children of
+// a synced permission are synced too, so a pruned permission never has a synced child
+func prunePermissions(ctx context.Context, tx pgx.Tx, clientPlatformID string, synced map[string]bool, res *pb.PermissionGeneratedResponse) error {
+	ids := make([]string, 0, len(synced))
+	for id := range synced {
+		ids = append(ids, id)
+	}
+
+	rows, err := tx.Query(ctx, `WITH RECURSIVE tree AS (
+		SELECT id, name::VARCHAR AS path FROM "permission" WHERE client_platform_id = $1 AND parent_id IS NULL
+		UNION ALL
+		SELECT p.id, tree.path || '/' || p.name FROM "permission" AS p INNER JOIN tree ON p.parent_id = tree.id
+	)
+	SELECT id, '/' || path FROM tree WHERE NOT (id = ANY($2::UUID[])) ORDER BY path`, clientPlatformID, ids)
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
 
-	_, err = stmt.Exec(values...)
-	if err != nil {
+	pruned := []string{}
+	for rows.Next() {
+		var id, path string
+		err = rows.Scan(&id, &path)
+		if err != nil {
+			return err
+		}
+
+		pruned = append(pruned, id)
+		res.Deleted = append(res.Deleted, path)
+	}
+	if err = rows.Err(); err != nil {
 		return err
 	}
+	rows.Close()
+
+	if len(pruned) == 0 {
+		return nil
+	}
+
+	for _, query := range []string{
+		`DELETE FROM "role_permission" WHERE permission_id = ANY($1::UUID[])`,
+		`DELETE FROM "permission_scope" WHERE permission_id = ANY($1::UUID[])`,
+		// parents and children go in one statement, so the parent_id constraint holds at its end
+		`DELETE FROM "permission" WHERE id = ANY($1::UUID[])`,
+	} {
+		_, err = tx.Exec(ctx, query, pruned)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
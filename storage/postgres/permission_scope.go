@@ -2,10 +2,11 @@ package postgres
 
 import (
 	"context"
-	"fmt"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/access"
 	"upm/udevs_go_auth_service/storage"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -91,30 +92,289 @@ func (r *permissionScopeRepo) Remove(ctx context.Context, pKey *pb.PermissionSco
 	return rowsAffected, err
 }
 
-func (r *permissionScopeRepo) HasAccess(ctx context.Context, roleID, clientPlatformID, path, method string) (hasAccess bool, err error) {
-	var count int32
+// GetAccessPolicy loads what a request for the scope is decided by: the permissions having the scope,
+// the roles with their ancestors, the grants and deny rules of the roles and the user and the relations of the
+// user with their descendants, userID may be empty
+func (r *permissionScopeRepo) GetAccessPolicy(ctx context.Context, userID string, roleIDs []string, clientPlatformID, path, method string) (policy *access.Policy, err error) {
+	policy = &access.Policy{
+		ScopePermissions:  []string{},
+		RoleParents:       map[string][]string{},
+		RolePermissions:   map[string][]string{},
+		UserPermissions:   []string{},
+		RelationScoped:    map[string]bool{},
+		RecentAuthMinutes: map[string]int32{},
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT
+		ps.permission_id,
+		p.relation_scoped,
+		p.recent_auth_minutes
+	FROM
+		"permission_scope" AS ps
+	INNER JOIN
+		"permission" AS p
+	ON
+		p.id = ps.permission_id
+	WHERE
+		ps.client_platform_id = $1 AND ps.path = $2 AND ps.method = $3`,
+		clientPlatformID,
+		path,
+		method,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var (
+			permissionID      string
+			relationScoped    bool
+			recentAuthMinutes int32
+		)
+
+		err = rows.Scan(&permissionID, &relationScoped, &recentAuthMinutes)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		policy.ScopePermissions = append(policy.ScopePermissions, permissionID)
+		policy.RelationScoped[permissionID] = relationScoped
+		if recentAuthMinutes > 0 {
+			policy.RecentAuthMinutes[permissionID] = recentAuthMinutes
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, roleTreeCTE+`
+	SELECT rp.role_id, rp.parent_id
+	FROM "role_parent" AS rp
+	WHERE rp.role_id IN (SELECT role_id FROM role_tree)`,
+		roleIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanStringPairs(rows, policy.RoleParents)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, roleTreeCTE+`
+	SELECT rp.role_id, rp.permission_id
+	FROM "role_permission" AS rp
+	WHERE rp.role_id IN (SELECT role_id FROM role_tree) AND rp.permission_id = ANY($2::UUID[])`,
+		roleIDs,
+		policy.ScopePermissions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanStringPairs(rows, policy.RolePermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.Denies, err = getDenyRulesForSubjects(ctx, r.db, clientPlatformID, userID, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// without a user, as when a role is explained, only the roles count
+	if len(userID) == 0 {
+		return policy, nil
+	}
+
+	rows, err = r.db.Query(ctx, `SELECT DISTINCT
+		up.permission_id
+	FROM
+		"user_permission" AS up
+	WHERE
+		up.user_id = $1 AND up.starts_at <= now() AND up.ends_at > now() AND up.permission_id = ANY($2::UUID[])`,
+		userID,
+		policy.ScopePermissions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.UserPermissions, err = scanStrings(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// a relation of the user covers its descendants too
+	rows, err = r.db.Query(ctx, `SELECT DISTINCT
+		d.id
+	FROM
+		"user_relation" AS ur
+	INNER JOIN
+		"relation" AS r
+	ON
+		r.id = ur.relation_id
+	INNER JOIN
+		"relation" AS d
+	ON
+		d.path LIKE r.path || '%'
+	WHERE
+		ur.user_id = $1`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.UserRelations, err = scanStrings(rows)
+	if err != nil {
+		return nil, err
+	}
 
-	query := `SELECT COUNT(*) FROM
-	(SELECT * FROM "role_permission" 
-	WHERE role_id = $1
-	) AS rp
+	return policy, nil
+}
+
+// GetPlatformPolicy loads the parents and permissions of every role of the client platform with the deny rules
+// of the roles, for deciding role by role with access.EvaluateRole
+func (r *permissionScopeRepo) GetPlatformPolicy(ctx context.Context, clientPlatformID string) (policy *access.Policy, err error) {
+	policy = &access.Policy{
+		RoleParents:     map[string][]string{},
+		RolePermissions: map[string][]string{},
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT
+		rp.role_id,
+		rp.parent_id
+	FROM
+		"role_parent" AS rp
 	INNER JOIN
-	(SELECT * FROM "permission_scope"
-	WHERE client_platform_id = $2 AND path = $3 AND method = $4) AS ps
-	ON rp.permission_id = ps.permission_id`
+		"role" AS r
+	ON
+		r.id = rp.role_id
+	WHERE
+		r.client_platform_id = $1`,
+		clientPlatformID,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	err = r.db.QueryRow(ctx, query, roleID, clientPlatformID, path, method).Scan(
-		&count,
+	err = scanStringPairs(rows, policy.RoleParents)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, `SELECT
+		rp.role_id,
+		rp.permission_id
+	FROM
+		"role_permission" AS rp
+	INNER JOIN
+		"role" AS r
+	ON
+		r.id = rp.role_id
+	WHERE
+		r.client_platform_id = $1`,
+		clientPlatformID,
 	)
 	if err != nil {
-		return hasAccess, err
+		return nil, err
+	}
+
+	err = scanStringPairs(rows, policy.RolePermissions)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Println(count)
+	rows, err = r.db.Query(ctx, denyRuleSelect+`
+	WHERE
+		dr.client_platform_id = $1 AND dr.role_id IS NOT NULL
+	ORDER BY dr.created_at`,
+		clientPlatformID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	policy.Denies, err = scanDenyRules(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// GetListByClientPlatformID returns the scopes of the permissions of the client platform
+func (r *permissionScopeRepo) GetListByClientPlatformID(ctx context.Context, clientPlatformID string) (res []*pb.PermissionScope, err error) {
+	res = []*pb.PermissionScope{}
+	query := `SELECT
+		permission_id,
+		client_platform_id,
+		path,
+		method
+	FROM
+		"permission_scope"
+	WHERE
+		client_platform_id = $1
+	ORDER BY path, method`
+
+	rows, err := r.db.Query(ctx, query, clientPlatformID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		scope := &pb.PermissionScope{}
+		err = rows.Scan(
+			&scope.PermissionId,
+			&scope.ClientPlatformId,
+			&scope.Path,
+			&scope.Method,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, scope)
+	}
+
+	return res, rows.Err()
+}
+
+func scanStrings(rows pgx.Rows) ([]string, error) {
+	defer rows.Close()
+
+	res := []string{}
+	for rows.Next() {
+		var value string
+		err := rows.Scan(&value)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, value)
+	}
+
+	return res, rows.Err()
+}
+
+// scanStringPairs collects rows of a key and a value into m
+func scanStringPairs(rows pgx.Rows, m map[string][]string) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		err := rows.Scan(&key, &value)
+		if err != nil {
+			return err
+		}
 
-	if count > 0 {
-		return true, nil
+		m[key] = append(m[key], value)
 	}
 
-	return false, nil
+	return rows.Err()
 }
@@ -10,22 +10,32 @@ import (
 )
 
 type Store struct {
-	db              *pgxpool.Pool
-	clientPlatform  storage.ClientPlatformRepoI
-	clientType      storage.ClientTypeRepoI
-	client          storage.ClientRepoI
-	relation        storage.RelationRepoI
-	userInfoField   storage.UserInfoFieldRepoI
-	role            storage.RoleRepoI
-	permission      storage.PermissionRepoI
-	scope           storage.ScopeRepoI
-	permissionScope storage.PermissionScopeRepoI
-	rolePermission  storage.RolePermissionRepoI
-	user            storage.UserRepoI
-	integration     storage.IntegrationRepoI
-	userRelation    storage.UserRelationRepoI
-	userInfo        storage.UserInfoRepoI
-	session         storage.SessionRepoI
+	db                 *pgxpool.Pool
+	clientPlatform     storage.ClientPlatformRepoI
+	clientType         storage.ClientTypeRepoI
+	client             storage.ClientRepoI
+	relation           storage.RelationRepoI
+	userInfoField      storage.UserInfoFieldRepoI
+	role               storage.RoleRepoI
+	permission         storage.PermissionRepoI
+	scope              storage.ScopeRepoI
+	permissionScope    storage.PermissionScopeRepoI
+	rolePermission     storage.RolePermissionRepoI
+	user               storage.UserRepoI
+	integration        storage.IntegrationRepoI
+	userRelation       storage.UserRelationRepoI
+	userInfo           storage.UserInfoRepoI
+	session            storage.SessionRepoI
+	passcode           storage.PasscodeRepoI
+	passkeyCredential  storage.PasskeyCredentialRepoI
+	passkeyChallenge   storage.PasskeyChallengeRepoI
+	revokedSession     storage.RevokedSessionRepoI
+	advisoryLock       storage.AdvisoryLockRepoI
+	dataSubjectRequest storage.DataSubjectRequestRepoI
+	rbac               storage.RBACRepoI
+	userRole           storage.UserRoleRepoI
+	userPermission     storage.UserPermissionRepoI
+	denyRule           storage.DenyRuleRepoI
 }
 
 func NewPostgres(ctx context.Context, cfg config.Config) (storage.StorageI, error) {
@@ -168,9 +178,90 @@ func (s *Store) Session() storage.SessionRepoI {
 
 	return s.session
 }
+
+func (s *Store) Passcode() storage.PasscodeRepoI {
+	if s.passcode == nil {
+		s.passcode = NewPasscodeRepo(s.db)
+	}
+
+	return s.passcode
+}
+
 func (s *Store) Integration() storage.IntegrationRepoI {
 	if s.integration == nil {
 		s.integration = NewIntegrationRepo(s.db)
 	}
 	return s.integration
 }
+
+func (s *Store) PasskeyCredential() storage.PasskeyCredentialRepoI {
+	if s.passkeyCredential == nil {
+		s.passkeyCredential = NewPasskeyCredentialRepo(s.db)
+	}
+
+	return s.passkeyCredential
+}
+
+func (s *Store) PasskeyChallenge() storage.PasskeyChallengeRepoI {
+	if s.passkeyChallenge == nil {
+		s.passkeyChallenge = NewPasskeyChallengeRepo(s.db)
+	}
+
+	return s.passkeyChallenge
+}
+
+func (s *Store) RevokedSession() storage.RevokedSessionRepoI {
+	if s.revokedSession == nil {
+		s.revokedSession = NewRevokedSessionRepo(s.db)
+	}
+
+	return s.revokedSession
+}
+
+func (s *Store) AdvisoryLock() storage.AdvisoryLockRepoI {
+	if s.advisoryLock == nil {
+		s.advisoryLock = NewAdvisoryLockRepo(s.db)
+	}
+
+	return s.advisoryLock
+}
+
+func (s *Store) DataSubjectRequest() storage.DataSubjectRequestRepoI {
+	if s.dataSubjectRequest == nil {
+		s.dataSubjectRequest = NewDataSubjectRequestRepo(s.db)
+	}
+
+	return s.dataSubjectRequest
+}
+
+func (s *Store) RBAC() storage.RBACRepoI {
+	if s.rbac == nil {
+		s.rbac = NewRBACRepo(s.db)
+	}
+
+	return s.rbac
+}
+
+func (s *Store) UserRole() storage.UserRoleRepoI {
+	if s.userRole == nil {
+		s.userRole = NewUserRoleRepo(s.db)
+	}
+
+	return s.userRole
+}
+
+func (s *Store) UserPermission() storage.UserPermissionRepoI {
+	if s.userPermission == nil {
+		s.userPermission = NewUserPermissionRepo(s.db)
+	}
+
+	return s.userPermission
+}
+
+func (s *Store) DenyRule() storage.DenyRuleRepoI {
+	if s.denyRule == nil {
+		s.denyRule = NewDenyRuleRepo(s.db)
+	}
+
+	return s.denyRule
+}
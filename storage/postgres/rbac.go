@@ -0,0 +1,526 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/rbac"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type rbacRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRBACRepo(db *pgxpool.Pool) storage.RBACRepoI {
+	return &rbacRepo{
+		db: db,
+	}
+}
+
+// rbacState is the config of a client platform with the ids of what it names
+type rbacState struct {
+	config        *rbac.Config
+	permissionIDs map[string]string
+	roleIDs       map[string]string
+}
+
+// rbacLock serializes the changes of the permission tree and the roles of a client platform,
+// applying a config and syncing a generated tree both plan against what they read
+const rbacLock = `SELECT pg_advisory_xact_lock(hashtext('rbac:' || $1))`
+
+func rbacRoleKey(clientType, name string) string {
+	return clientType + "\x00" + name
+}
+
+func (r *rbacRepo) GetConfig(ctx context.Context, clientPlatformID string) (res *rbac.Config, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	state, err := loadRBACState(ctx, tx, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.config, nil
+}
+
+// GetPermissionPaths returns the paths in the tree of the permissions of the client platform by their ids,
+// they are the names the config refers to the permissions by
+func (r *rbacRepo) GetPermissionPaths(ctx context.Context, clientPlatformID string) (paths map[string]string, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	state, err := loadRBACState(ctx, tx, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	paths = make(map[string]string, len(state.permissionIDs))
+	for path, id := range state.permissionIDs {
+		paths[id] = path
+	}
+
+	return paths, nil
+}
+
+// Apply plans the changes against the config stored at the moment and applies them in the same
+// transaction, concurrent applies for the client platform wait for each other
+func (r *rbacRepo) Apply(ctx context.Context, clientPlatformID string, config *rbac.Config, dryRun bool) (plan []*pb.RBACChange, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, rbacLock, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := loadRBACState(ctx, tx, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err = rbac.Plan(state.config, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return plan, nil
+	}
+
+	var projectID string
+	err = tx.QueryRow(ctx, `SELECT project_id FROM "client_platform" WHERE id = $1`, clientPlatformID).Scan(&projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range plan {
+		err = applyRBACChange(ctx, tx, clientPlatformID, projectID, state, change)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s %s: %w", change.Action, change.Kind, describeRBACChange(change), err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func describeRBACChange(change *pb.RBACChange) string {
+	switch change.Kind {
+	case rbac.KindScope:
+		return change.Permission + " " + change.ScopeMethod + " " + change.ScopePath
+	case rbac.KindRole:
+		return change.ClientType + "/" + change.Role
+	case rbac.KindRolePermission:
+		return change.ClientType + "/" + change.Role + " " + change.Permission
+	case rbac.KindRoleParent:
+		return change.ClientType + "/" + change.Role + " " + change.ParentClientType + "/" + change.ParentRole
+	default:
+		return change.Permission
+	}
+}
+
+func applyRBACChange(ctx context.Context, tx pgx.Tx, clientPlatformID, projectID string, state *rbacState, change *pb.RBACChange) (err error) {
+	switch change.Kind + " " + change.Action {
+	case rbac.KindPermission + " " + rbac.ActionCreate:
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return err
+		}
+
+		var parentID *string
+		if len(change.ParentPermission) > 0 {
+			parent := state.permissionIDs[change.ParentPermission]
+			parentID = &parent
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO "permission" (
+			id,
+			client_platform_id,
+			parent_id,
+			name,
+			recent_auth_minutes,
+			relation_scoped
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6
+		)`,
+			id.String(),
+			clientPlatformID,
+			parentID,
+			strings.TrimPrefix(change.Permission, change.ParentPermission+"/"),
+			change.RecentAuthMinutes,
+			change.RelationScoped,
+		)
+		if err != nil {
+			return err
+		}
+
+		state.permissionIDs[change.Permission] = id.String()
+	case rbac.KindPermission + " " + rbac.ActionUpdate:
+		_, err = tx.Exec(ctx, `UPDATE "permission" SET recent_auth_minutes = $2, relation_scoped = $3, updated_at = now() WHERE id = $1`,
+			state.permissionIDs[change.Permission],
+			change.RecentAuthMinutes,
+			change.RelationScoped,
+		)
+	case rbac.KindPermission + " " + rbac.ActionDelete:
+		id := state.permissionIDs[change.Permission]
+
+		for _, query := range []string{
+			`DELETE FROM "role_permission" WHERE permission_id = $1`,
+			`DELETE FROM "permission_scope" WHERE permission_id = $1`,
+			`DELETE FROM "permission" WHERE id = $1`,
+		} {
+			_, err = tx.Exec(ctx, query, id)
+			if err != nil {
+				return err
+			}
+		}
+	case rbac.KindScope + " " + rbac.ActionCreate:
+		_, err = tx.Exec(ctx, `INSERT INTO "scope" (
+			client_platform_id,
+			path,
+			method,
+			requests
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			0
+		) ON CONFLICT (client_platform_id, path, method) DO NOTHING`, clientPlatformID, change.ScopePath, change.ScopeMethod)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO "permission_scope" (
+			permission_id,
+			client_platform_id,
+			path,
+			method
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4
+		)`, state.permissionIDs[change.Permission], clientPlatformID, change.ScopePath, change.ScopeMethod)
+	case rbac.KindScope + " " + rbac.ActionDelete:
+		_, err = tx.Exec(ctx, `DELETE FROM "permission_scope" WHERE permission_id = $1 AND path = $2 AND method = $3`,
+			state.permissionIDs[change.Permission],
+			change.ScopePath,
+			change.ScopeMethod,
+		)
+	case rbac.KindRole + " " + rbac.ActionCreate:
+		clientTypeID, err := getRBACClientTypeID(ctx, tx, clientPlatformID, change.ClientType)
+		if err != nil {
+			return err
+		}
+
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO "role" (
+			id,
+			client_type_id,
+			name,
+			client_platform_id,
+			project_id
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5
+		)`, id.String(), clientTypeID, change.Role, clientPlatformID, projectID)
+		if err != nil {
+			return err
+		}
+
+		state.roleIDs[rbacRoleKey(change.ClientType, change.Role)] = id.String()
+	case rbac.KindRole + " " + rbac.ActionDelete:
+		id := state.roleIDs[rbacRoleKey(change.ClientType, change.Role)]
+
+		_, err = tx.Exec(ctx, `DELETE FROM "role_permission" WHERE role_id = $1`, id)
+		if err != nil {
+			return err
+		}
+
+		// users and integrations of the role keep it from being deleted
+		_, err = tx.Exec(ctx, `DELETE FROM "role" WHERE id = $1`, id)
+	case rbac.KindRolePermission + " " + rbac.ActionCreate:
+		_, err = tx.Exec(ctx, `INSERT INTO "role_permission" (role_id, permission_id) VALUES ($1, $2)`,
+			state.roleIDs[rbacRoleKey(change.ClientType, change.Role)],
+			state.permissionIDs[change.Permission],
+		)
+	case rbac.KindRolePermission + " " + rbac.ActionDelete:
+		_, err = tx.Exec(ctx, `DELETE FROM "role_permission" WHERE role_id = $1 AND permission_id = $2`,
+			state.roleIDs[rbacRoleKey(change.ClientType, change.Role)],
+			state.permissionIDs[change.Permission],
+		)
+	case rbac.KindRoleParent + " " + rbac.ActionCreate:
+		_, err = tx.Exec(ctx, `INSERT INTO "role_parent" (role_id, parent_id) VALUES ($1, $2)`,
+			state.roleIDs[rbacRoleKey(change.ClientType, change.Role)],
+			state.roleIDs[rbacRoleKey(change.ParentClientType, change.ParentRole)],
+		)
+	case rbac.KindRoleParent + " " + rbac.ActionDelete:
+		_, err = tx.Exec(ctx, `DELETE FROM "role_parent" WHERE role_id = $1 AND parent_id = $2`,
+			state.roleIDs[rbacRoleKey(change.ClientType, change.Role)],
+			state.roleIDs[rbacRoleKey(change.ParentClientType, change.ParentRole)],
+		)
+	default:
+		err = fmt.Errorf("unknown change")
+	}
+
+	return err
+}
+
+// getRBACClientTypeID finds the client type by name among the client types of the client platform,
+// client type names aren't unique, so a name shared within the client platform is rejected
+func getRBACClientTypeID(ctx context.Context, tx pgx.Tx, clientPlatformID, name string) (string, error) {
+	rows, err := tx.Query(ctx, `SELECT ct.id FROM "client_type" AS ct
+	WHERE
+		ct.name = $2 AND (
+			EXISTS (SELECT 1 FROM "client" AS c WHERE c.client_platform_id = $1 AND c.client_type_id = ct.id) OR
+			EXISTS (SELECT 1 FROM "role" AS r WHERE r.client_platform_id = $1 AND r.client_type_id = ct.id)
+		)`, clientPlatformID, name)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("client type %s is not a client of the client platform", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("client type name %s is shared by %d client types of the client platform", name, len(ids))
+	}
+}
+
+func loadRBACState(ctx context.Context, tx pgx.Tx, clientPlatformID string) (*rbacState, error) {
+	state := &rbacState{
+		config:        &rbac.Config{},
+		permissionIDs: map[string]string{},
+		roleIDs:       map[string]string{},
+	}
+
+	type permissionRow struct {
+		id                string
+		name              string
+		recentAuthMinutes int32
+		relationScoped    bool
+	}
+
+	rows, err := tx.Query(ctx, `SELECT
+		id,
+		COALESCE(parent_id::VARCHAR, ''),
+		name,
+		recent_auth_minutes,
+		relation_scoped
+	FROM "permission"
+	WHERE client_platform_id = $1
+	ORDER BY name`, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[string][]permissionRow{}
+	for rows.Next() {
+		var (
+			row      permissionRow
+			parentID string
+		)
+
+		err = rows.Scan(&row.id, &parentID, &row.name, &row.recentAuthMinutes, &row.relationScoped)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		children[parentID] = append(children[parentID], row)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx, `SELECT
+		ps.permission_id,
+		ps.method,
+		ps.path
+	FROM "permission_scope" AS ps
+	INNER JOIN "permission" AS p ON p.id = ps.permission_id
+	WHERE p.client_platform_id = $1
+	ORDER BY ps.path, ps.method`, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := map[string][]rbac.Scope{}
+	for rows.Next() {
+		var (
+			permissionID string
+			scope        rbac.Scope
+		)
+
+		err = rows.Scan(&permissionID, &scope.Method, &scope.Path)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		scopes[permissionID] = append(scopes[permissionID], scope)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	permissionPaths := map[string]string{}
+
+	var build func(parentID, parentPath string) []rbac.Permission
+	build = func(parentID, parentPath string) []rbac.Permission {
+		res := []rbac.Permission{}
+		for _, row := range children[parentID] {
+			path := rbac.PermissionPath(parentPath, row.name)
+			state.permissionIDs[path] = row.id
+			permissionPaths[row.id] = path
+
+			res = append(res, rbac.Permission{
+				Name:              row.name,
+				RecentAuthMinutes: row.recentAuthMinutes,
+				RelationScoped:    row.relationScoped,
+				Scopes:            scopes[row.id],
+				Children:          build(row.id, path),
+			})
+		}
+
+		return res
+	}
+	state.config.Permissions = build("", "")
+
+	rows, err = tx.Query(ctx, `SELECT
+		r.id,
+		COALESCE(ct.name, ''),
+		COALESCE(r.name, ''),
+		COALESCE(rp.permission_id::VARCHAR, '')
+	FROM "role" AS r
+	INNER JOIN "client_type" AS ct ON ct.id = r.client_type_id
+	LEFT JOIN "role_permission" AS rp ON rp.role_id = r.id
+	WHERE r.client_platform_id = $1
+	ORDER BY ct.name, r.name`, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := map[string]int{}
+	for rows.Next() {
+		var id, clientType, name, permissionID string
+
+		err = rows.Scan(&id, &clientType, &name, &permissionID)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		i, ok := roles[id]
+		if !ok {
+			i = len(state.config.Roles)
+			roles[id] = i
+			state.config.Roles = append(state.config.Roles, rbac.Role{ClientType: clientType, Name: name})
+			state.roleIDs[rbacRoleKey(clientType, name)] = id
+		}
+
+		// permissions of other client platforms can't be named in the config
+		if path, ok := permissionPaths[permissionID]; ok {
+			state.config.Roles[i].Permissions = append(state.config.Roles[i].Permissions, path)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// parents are roles of the client platform as well, AddRoleParent keeps it so
+	rows, err = tx.Query(ctx, `SELECT
+		rp.role_id,
+		rp.parent_id
+	FROM "role_parent" AS rp
+	INNER JOIN "role" AS r ON r.id = rp.role_id
+	WHERE r.client_platform_id = $1`, clientPlatformID)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := map[string][]string{}
+	err = scanStringPairs(rows, parents)
+	if err != nil {
+		return nil, err
+	}
+
+	for roleID, parentIDs := range parents {
+		for _, parentID := range parentIDs {
+			j, ok := roles[parentID]
+			if !ok {
+				continue
+			}
+
+			parent := state.config.Roles[j]
+			i := roles[roleID]
+			state.config.Roles[i].Parents = append(state.config.Roles[i].Parents, rbac.RoleRef{
+				ClientType: parent.ClientType,
+				Name:       parent.Name,
+			})
+		}
+	}
+
+	for i := range state.config.Roles {
+		sort.Strings(state.config.Roles[i].Permissions)
+		sort.Slice(state.config.Roles[i].Parents, func(a, b int) bool {
+			parents := state.config.Roles[i].Parents
+			if parents[a].ClientType != parents[b].ClientType {
+				return parents[a].ClientType < parents[b].ClientType
+			}
+			return parents[a].Name < parents[b].Name
+		})
+	}
+
+	return state, nil
+}
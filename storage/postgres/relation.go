@@ -2,11 +2,13 @@ package postgres
 
 import (
 	"context"
+	"strings"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/pkg/helper"
 	"upm/udevs_go_auth_service/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -20,19 +22,29 @@ func NewRelationRepo(db *pgxpool.Pool) storage.RelationRepoI {
 	}
 }
 
+// relationTreeLock serializes the changes of paths, a relation added under a node being moved
+// would get the old path otherwise
+const relationTreeLock = `SELECT pg_advisory_xact_lock(hashtext('relation_tree'))`
+
+// Add puts the relation under the parent, or makes it a root if the parent id is empty,
+// the parent must be of the same client type
 func (r *relationRepo) Add(ctx context.Context, entity *pb.AddRelationRequest) (pKey *pb.RelationPrimaryKey, err error) {
 	query := `INSERT INTO "relation" (
 		id,
 		client_type_id,
 		type,
 		name,
-		description
+		description,
+		parent_id,
+		path
 	) VALUES (
 		$1,
 		$2,
 		$3,
 		$4,
-		$5
+		$5,
+		$6,
+		$7
 	)`
 
 	uuid, err := uuid.NewRandom()
@@ -40,49 +52,181 @@ func (r *relationRepo) Add(ctx context.Context, entity *pb.AddRelationRequest) (
 		return pKey, err
 	}
 
-	_, err = r.db.Exec(ctx, query,
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return pKey, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, relationTreeLock)
+	if err != nil {
+		return pKey, err
+	}
+
+	var parentID *string
+	path := "/" + uuid.String() + "/"
+	if len(entity.ParentId) > 0 {
+		var parentPath, parentClientTypeID string
+		err = tx.QueryRow(ctx, `SELECT path, client_type_id FROM "relation" WHERE id = $1`, entity.ParentId).Scan(&parentPath, &parentClientTypeID)
+		if err == pgx.ErrNoRows {
+			return pKey, storage.ErrorRelationParentNotFound
+		}
+		if err != nil {
+			return pKey, err
+		}
+
+		if parentClientTypeID != entity.ClientTypeId {
+			return pKey, storage.ErrorRelationParentClientType
+		}
+
+		parentID = &entity.ParentId
+		path = parentPath + uuid.String() + "/"
+	}
+
+	_, err = tx.Exec(ctx, query,
 		uuid.String(),
 		entity.ClientTypeId,
 		entity.Type.String(),
 		entity.Name,
 		entity.Description,
+		parentID,
+		path,
 	)
+	if err != nil {
+		return pKey, err
+	}
 
 	pKey = &pb.RelationPrimaryKey{
 		Id: uuid.String(),
 	}
 
-	return pKey, err
+	return pKey, tx.Commit(ctx)
 }
 
-func (r *relationRepo) GetByPK(ctx context.Context, pKey *pb.RelationPrimaryKey) (res *pb.Relation, err error) {
-	var relationType string
-	res = &pb.Relation{}
-	query := `SELECT
+const relationSelect = `SELECT
 		id,
 		client_type_id,
 		type,
 		name,
-		description
+		COALESCE(description, ''),
+		COALESCE(parent_id::VARCHAR, ''),
+		path
 	FROM
-		"relation"
-	WHERE
-		id = $1`
+		"relation"`
 
-	err = r.db.QueryRow(ctx, query, pKey.Id).Scan(
+func scanRelation(row pgx.Row) (*pb.Relation, error) {
+	var relationType string
+	res := &pb.Relation{}
+
+	err := row.Scan(
 		&res.Id,
 		&res.ClientTypeId,
 		&relationType,
 		&res.Name,
 		&res.Description,
+		&res.ParentId,
+		&res.Path,
 	)
+	if err != nil {
+		return res, err
+	}
 
 	res.Type = pb.RelationTypes(pb.RelationTypes_value[relationType])
+
+	return res, nil
+}
+
+func (r *relationRepo) GetByPK(ctx context.Context, pKey *pb.RelationPrimaryKey) (res *pb.Relation, err error) {
+	query := relationSelect + `
+	WHERE
+		id = $1`
+
+	return scanRelation(r.db.QueryRow(ctx, query, pKey.Id))
+}
+
+// GetSubtree returns the relation and all its descendants, parents before their children
+func (r *relationRepo) GetSubtree(ctx context.Context, pKey *pb.RelationPrimaryKey) (res []*pb.Relation, err error) {
+	res = []*pb.Relation{}
+	query := relationSelect + `
+	WHERE
+		path LIKE (SELECT path FROM "relation" WHERE id = $1) || '%'
+	ORDER BY path`
+
+	rows, err := r.db.Query(ctx, query, pKey.Id)
 	if err != nil {
 		return res, err
 	}
+	defer rows.Close()
 
-	return res, nil
+	for rows.Next() {
+		relation, err := scanRelation(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, relation)
+	}
+
+	return res, rows.Err()
+}
+
+// Move puts the relation with its subtree under the parent, or makes it a root if parentID is empty,
+// a parent in the subtree would make a cycle and is rejected
+func (r *relationRepo) Move(ctx context.Context, entity *pb.MoveRelationRequest) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, relationTreeLock)
+	if err != nil {
+		return err
+	}
+
+	var oldPath, clientTypeID string
+	err = tx.QueryRow(ctx, `SELECT path, client_type_id FROM "relation" WHERE id = $1`, entity.Id).Scan(&oldPath, &clientTypeID)
+	if err != nil {
+		return err
+	}
+
+	var parentID *string
+	newPath := "/" + entity.Id + "/"
+	if len(entity.ParentId) > 0 {
+		var parentPath, parentClientTypeID string
+		err = tx.QueryRow(ctx, `SELECT path, client_type_id FROM "relation" WHERE id = $1`, entity.ParentId).Scan(&parentPath, &parentClientTypeID)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(parentPath, oldPath) {
+			return storage.ErrorRelationCycle
+		}
+
+		if parentClientTypeID != clientTypeID {
+			return storage.ErrorRelationParentClientType
+		}
+
+		parentID = &entity.ParentId
+		newPath = parentPath + entity.Id + "/"
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE "relation" SET
+		parent_id = CASE WHEN id = $1 THEN $2 ELSE parent_id END,
+		path = $4::VARCHAR || SUBSTRING(path FROM LENGTH($3::VARCHAR) + 1),
+		updated_at = now()
+	WHERE
+		path LIKE $3::VARCHAR || '%'`,
+		entity.Id,
+		parentID,
+		oldPath,
+		newPath,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *relationRepo) Update(ctx context.Context, entity *pb.UpdateRelationRequest) (rowsAffected int64, err error) {
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type revokedSessionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRevokedSessionRepo(db *pgxpool.Pool) storage.RevokedSessionRepoI {
+	return &revokedSessionRepo{
+		db: db,
+	}
+}
+
+// GetList returns revocations after the given sequence number whose tokens haven't expired yet
+func (r *revokedSessionRepo) GetList(ctx context.Context, queryParam *pb.GetRevokedSessionListRequest) (res *pb.GetRevokedSessionListResponse, err error) {
+	res = &pb.GetRevokedSessionListResponse{
+		RevokedSessions: []*pb.RevokedSession{},
+		LastSeq:         queryParam.AfterSeq,
+	}
+
+	limit := queryParam.Limit
+	if limit <= 0 || limit > config.RevokedSessionListLimit {
+		limit = config.RevokedSessionListLimit
+	}
+
+	query := `SELECT
+		id,
+		seq,
+		user_id,
+		integration_id,
+		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
+		TO_CHAR(revoked_at, ` + config.DatabaseQueryTimeLayout + `) AS revoked_at
+	FROM
+		"revoked_session"
+	WHERE
+		seq > $1 AND expires_at > now()
+	ORDER BY seq
+	LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, queryParam.AfterSeq, limit)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &pb.RevokedSession{}
+		var (
+			userID        sql.NullString
+			integrationID sql.NullString
+		)
+
+		err = rows.Scan(
+			&obj.Id,
+			&obj.Seq,
+			&userID,
+			&integrationID,
+			&obj.ExpiresAt,
+			&obj.RevokedAt,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		if userID.Valid {
+			obj.UserId = userID.String
+		}
+
+		if integrationID.Valid {
+			obj.IntegrationId = integrationID.String
+		}
+
+		res.RevokedSessions = append(res.RevokedSessions, obj)
+		res.LastSeq = obj.Seq
+	}
+
+	return res, nil
+}
+
+func (r *revokedSessionRepo) DeleteExpired(ctx context.Context) (rowsAffected int64, err error) {
+	query := `DELETE FROM "revoked_session" WHERE expires_at < now()`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
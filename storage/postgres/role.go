@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/pkg/helper"
 	"upm/udevs_go_auth_service/storage"
@@ -97,6 +96,7 @@ func (r *roleRepo) GetRoleByIdDetailed(ctx context.Context, entity *pb.RolePrima
 		ct.project_id,
 		ct.self_register,
 		ct.self_recover,
+		ct.require_verified_contact,
 		ct.name AS client_type_name
 	FROM 
 		"role" 
@@ -121,6 +121,7 @@ func (r *roleRepo) GetRoleByIdDetailed(ctx context.Context, entity *pb.RolePrima
 		&res.ClientType.ProjectId,
 		&res.ClientType.SelfRegister,
 		&res.ClientType.SelfRecover,
+		&res.ClientType.RequireVerifiedContact,
 		&res.ClientType.Name,
 	)
 	res.ClientType.ConfirmBy = pb.ConfirmStrategies(pb.ConfirmStrategies_value[confirmBy])
@@ -129,44 +130,25 @@ func (r *roleRepo) GetRoleByIdDetailed(ctx context.Context, entity *pb.RolePrima
 		return res, err
 	}
 
-	getPermissionQuery := `SELECT
-			p.id,
-			p.name,
-			p.parent_id,
-			p.client_platform_id
-		FROM 
-			"role_permission"
-		AS
-			rp
-		INNER JOIN
-				"permission" 
-			AS 
-				p
-		ON
-			p.id = rp.permission_id
-		WHERE
-			rp.role_id = $1`
-
-	rows, err := r.db.Query(ctx, getPermissionQuery, entity.GetId())
+	rows, err := r.db.Query(ctx, effectivePermissionsQuery, []string{entity.GetId()})
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		permission := &pb.Permission{}
-		var parentId sql.NullString
-		rows.Scan(
-			&permission.Id,
-			&permission.Name,
-			&parentId,
-			&permission.ClientPlatformId,
-		)
-		permission.ParentId = parentId.String
-		// if err != nil {
-		// 	return nil, err
-		// }
+		permission, err := scanEffectivePermission(rows)
+		if err != nil {
+			return nil, err
+		}
 		res.Permissions = append(res.Permissions, permission)
 	}
+	rows.Close()
+
+	res.Parents, err = r.GetParents(ctx, entity.GetId())
+	if err != nil {
+		return nil, err
+	}
 
 	return res, nil
 }
@@ -271,3 +253,114 @@ func (r *roleRepo) Remove(ctx context.Context, pKey *pb.RolePrimaryKey) (rowsAff
 
 	return rowsAffected, err
 }
+
+// roleTreeCTE lists the roles of the array $1 with depth 0 and their ancestors with their distance to them,
+// the depth bound only guards against a cycle slipping past AddParent
+const roleTreeCTE = `WITH RECURSIVE role_tree AS (
+		SELECT UNNEST($1::UUID[]) AS role_id, 0 AS depth
+	UNION
+		SELECT rp.parent_id, rt.depth + 1
+		FROM "role_parent" AS rp
+		INNER JOIN role_tree AS rt ON rt.role_id = rp.role_id
+		WHERE rt.depth < 32
+	)`
+
+// AddParent lets the role inherit the permissions of the parent, a parent that is the role itself
+// or one of its descendants would make a cycle and is rejected
+func (r *roleRepo) AddParent(ctx context.Context, entity *pb.RoleParent) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// two inserts checked at the same time could close a cycle together
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('role_parent'))`)
+	if err != nil {
+		return err
+	}
+
+	var samePlatform, cycle bool
+	err = tx.QueryRow(ctx, roleTreeCTE+`
+	SELECT
+		COALESCE((SELECT r.client_platform_id = p.client_platform_id FROM "role" AS r, "role" AS p WHERE r.id = $2 AND p.id = $3), FALSE),
+		EXISTS (SELECT 1 FROM role_tree WHERE role_id = $2)`,
+		[]string{entity.ParentId},
+		entity.RoleId,
+		entity.ParentId,
+	).Scan(&samePlatform, &cycle)
+	if err != nil {
+		return err
+	}
+
+	if cycle {
+		return storage.ErrorRoleCycle
+	}
+
+	if !samePlatform {
+		return storage.ErrorRoleParentPlatform
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO "role_parent" (role_id, parent_id) VALUES ($1, $2)`, entity.RoleId, entity.ParentId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *roleRepo) RemoveParent(ctx context.Context, entity *pb.RoleParent) (rowsAffected int64, err error) {
+	query := `DELETE FROM "role_parent" WHERE role_id = $1 AND parent_id = $2`
+
+	result, err := r.db.Exec(ctx, query, entity.RoleId, entity.ParentId)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+// GetParents returns the direct parents of the role
+func (r *roleRepo) GetParents(ctx context.Context, roleID string) (res []*pb.Role, err error) {
+	res = []*pb.Role{}
+	query := `SELECT
+		r.id,
+		r.client_type_id,
+		r.name,
+		r.client_platform_id,
+		r.project_id
+	FROM
+		"role_parent" AS rp
+	INNER JOIN
+		"role" AS r
+	ON
+		r.id = rp.parent_id
+	WHERE
+		rp.role_id = $1
+	ORDER BY r.name`
+
+	rows, err := r.db.Query(ctx, query, roleID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		role := &pb.Role{}
+		err = rows.Scan(
+			&role.Id,
+			&role.ClientTypeId,
+			&role.Name,
+			&role.ClientPlatformId,
+			&role.ProjectId,
+		)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, role)
+	}
+
+	return res, rows.Err()
+}
@@ -33,7 +33,8 @@ func (r *sessionRepo) Create(ctx context.Context, entity *pb.CreateSessionReques
 		role_id,
 		ip,
 		data,
-		expires_at
+		expires_at,
+		active_role_only
 	) VALUES (
 		$1,
 		$2,
@@ -43,7 +44,8 @@ func (r *sessionRepo) Create(ctx context.Context, entity *pb.CreateSessionReques
 		$6,
 		$7,
 		$8,
-		$9
+		$9,
+		$10
 	)`
 
 	uuid, err := uuid.NewRandom()
@@ -61,6 +63,7 @@ func (r *sessionRepo) Create(ctx context.Context, entity *pb.CreateSessionReques
 		entity.Ip,
 		entity.Data,
 		entity.ExpiresAt,
+		entity.ActiveRoleOnly,
 	)
 
 	pKey = &pb.SessionPrimaryKey{
@@ -83,7 +86,9 @@ func (r *sessionRepo) GetByPK(ctx context.Context, pKey *pb.SessionPrimaryKey) (
 		data,
 		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
 		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
-		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at
+		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at,
+		TO_CHAR(last_auth_at, ` + config.DatabaseQueryTimeLayout + `) AS last_auth_at,
+		active_role_only
 	FROM
 		"session"
 	WHERE
@@ -111,6 +116,8 @@ func (r *sessionRepo) GetByPK(ctx context.Context, pKey *pb.SessionPrimaryKey) (
 		&createdAt,
 		// &res.UpdatedAt,
 		&updatedAt,
+		&res.LastAuthAt,
+		&res.ActiveRoleOnly,
 	)
 	if err != nil {
 		return res, err
@@ -267,6 +274,49 @@ func (r *sessionRepo) Delete(ctx context.Context, pKey *pb.SessionPrimaryKey) (r
 	return rowsAffected, err
 }
 
+func (r *sessionRepo) UpdateLastAuthAt(ctx context.Context, pKey *pb.SessionPrimaryKey) (rowsAffected int64, err error) {
+	query := `UPDATE "session" SET
+		last_auth_at = now(),
+		updated_at = now()
+	WHERE
+		id = $1`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+func (r *sessionRepo) DeleteByUserID(ctx context.Context, userID string) (rowsAffected int64, err error) {
+	query := `DELETE FROM "session" WHERE user_id = $1`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+func (r *sessionRepo) DeleteExpired(ctx context.Context) (rowsAffected int64, err error) {
+	query := `DELETE FROM "session" WHERE expires_at < now()`
+
+	result, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
 func (r *sessionRepo) DeleteExpiredUserSessions(ctx context.Context, userID string) (rowsAffected int64, err error) {
 	query := `DELETE FROM "session" WHERE user_id = $1 AND expires_at < $2`
 
@@ -3,16 +3,22 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"upm/udevs_go_auth_service/config"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/pkg/helper"
+	"upm/udevs_go_auth_service/pkg/userinfo"
 	"upm/udevs_go_auth_service/storage"
 
-	"github.com/saidamir98/udevs_pkg/util"
-
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/lib/pq"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type userRepo struct {
@@ -25,6 +31,7 @@ func NewUserRepo(db *pgxpool.Pool) storage.UserRepoI {
 	}
 }
 
+// Create stores empty contacts as NULL, the unique indexes of phone, email and login skip them then
 func (r *userRepo) Create(ctx context.Context, entity *pb.CreateUserRequest) (pKey *pb.UserPrimaryKey, err error) {
 	query := `INSERT INTO "user" (
 		id,
@@ -38,7 +45,7 @@ func (r *userRepo) Create(ctx context.Context, entity *pb.CreateUserRequest) (pK
 		email,
 		login,
 		password,
-		active,
+		state,
 		expires_at
 	) VALUES (
 		$1,
@@ -48,9 +55,9 @@ func (r *userRepo) Create(ctx context.Context, entity *pb.CreateUserRequest) (pK
 		$5,
 		$6,
 		$7,
-		$8,
-		$9,
-		$10,
+		NULLIF($8, ''),
+		NULLIF($9, ''),
+		NULLIF($10, ''),
 		$11,
 		$12,
 		$13
@@ -73,7 +80,7 @@ func (r *userRepo) Create(ctx context.Context, entity *pb.CreateUserRequest) (pK
 		entity.Email,
 		entity.Login,
 		entity.Password,
-		entity.Active,
+		entity.State.String(),
 		entity.ExpiresAt,
 	)
 
@@ -86,6 +93,14 @@ func (r *userRepo) Create(ctx context.Context, entity *pb.CreateUserRequest) (pK
 
 func (r *userRepo) GetByPK(ctx context.Context, pKey *pb.UserPrimaryKey) (res *pb.User, err error) {
 	res = &pb.User{}
+	var (
+		state           string
+		suspendedUntil  sql.NullString
+		deletedAt       sql.NullString
+		emailVerifiedAt sql.NullString
+		phoneVerifiedAt sql.NullString
+	)
+
 	query := `SELECT
 		id,
 		project_id,
@@ -94,11 +109,15 @@ func (r *userRepo) GetByPK(ctx context.Context, pKey *pb.UserPrimaryKey) (res *p
 		role_id,
 		name,
 		photo_url,
-		phone,
-		email,
-		login,
-		password,
-		active,
+		COALESCE(phone, ''),
+		COALESCE(email, ''),
+		COALESCE(login, ''),
+		state,
+		state_reason,
+		TO_CHAR(suspended_until, ` + config.DatabaseQueryTimeLayout + `) AS suspended_until,
+		TO_CHAR(deleted_at, ` + config.DatabaseQueryTimeLayout + `) AS deleted_at,
+		TO_CHAR(email_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS email_verified_at,
+		TO_CHAR(phone_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS phone_verified_at,
 		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
 		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
 		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at
@@ -118,8 +137,12 @@ func (r *userRepo) GetByPK(ctx context.Context, pKey *pb.UserPrimaryKey) (res *p
 		&res.Phone,
 		&res.Email,
 		&res.Login,
-		&res.Password,
-		&res.Active,
+		&state,
+		&res.StateReason,
+		&suspendedUntil,
+		&deletedAt,
+		&emailVerifiedAt,
+		&phoneVerifiedAt,
 		&res.ExpiresAt,
 		&res.CreatedAt,
 		&res.UpdatedAt,
@@ -128,6 +151,9 @@ func (r *userRepo) GetByPK(ctx context.Context, pKey *pb.UserPrimaryKey) (res *p
 		return res, err
 	}
 
+	setUserState(res, state, suspendedUntil, deletedAt)
+	setContactVerification(res, emailVerifiedAt, phoneVerifiedAt)
+
 	return res, nil
 }
 
@@ -141,18 +167,22 @@ func (r *userRepo) GetListByPKs(ctx context.Context, pKeys *pb.UserPrimaryKeyLis
 		role_id,
 		name,
 		photo_url,
-		phone,
-		email,
-		login,
-		password,
-		active,
+		COALESCE(phone, ''),
+		COALESCE(email, ''),
+		COALESCE(login, ''),
+		state,
+		state_reason,
+		TO_CHAR(suspended_until, ` + config.DatabaseQueryTimeLayout + `) AS suspended_until,
+		TO_CHAR(deleted_at, ` + config.DatabaseQueryTimeLayout + `) AS deleted_at,
+		TO_CHAR(email_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS email_verified_at,
+		TO_CHAR(phone_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS phone_verified_at,
 		expires_at,
 		created_at,
 		updated_at
 	FROM
 		"user"
 	WHERE
-		id = ANY($1)`
+		id = ANY($1) AND deleted_at IS NULL`
 
 	rows, err := r.db.Query(ctx, query, pq.Array(pKeys.Ids))
 	if err != nil {
@@ -162,10 +192,14 @@ func (r *userRepo) GetListByPKs(ctx context.Context, pKeys *pb.UserPrimaryKeyLis
 
 	for rows.Next() {
 		var (
-			active    sql.NullInt32
-			expiresAt sql.NullString
-			createdAt sql.NullString
-			updatedAt sql.NullString
+			state           string
+			suspendedUntil  sql.NullString
+			deletedAt       sql.NullString
+			emailVerifiedAt sql.NullString
+			phoneVerifiedAt sql.NullString
+			expiresAt       sql.NullString
+			createdAt       sql.NullString
+			updatedAt       sql.NullString
 		)
 
 		user := &pb.User{}
@@ -180,8 +214,12 @@ func (r *userRepo) GetListByPKs(ctx context.Context, pKeys *pb.UserPrimaryKeyLis
 			&user.Phone,
 			&user.Email,
 			&user.Login,
-			&user.Password,
-			&active,
+			&state,
+			&user.StateReason,
+			&suspendedUntil,
+			&deletedAt,
+			&emailVerifiedAt,
+			&phoneVerifiedAt,
 			&expiresAt,
 			&createdAt,
 			&updatedAt,
@@ -191,9 +229,8 @@ func (r *userRepo) GetListByPKs(ctx context.Context, pKeys *pb.UserPrimaryKeyLis
 			return res, err
 		}
 
-		if active.Valid {
-			user.Active = active.Int32
-		}
+		setUserState(user, state, suspendedUntil, deletedAt)
+		setContactVerification(user, emailVerifiedAt, phoneVerifiedAt)
 
 		if expiresAt.Valid {
 			user.ExpiresAt = expiresAt.String
@@ -225,39 +262,26 @@ func (r *userRepo) GetList(ctx context.Context, queryParam *pb.GetUserListReques
 		role_id,
 		name,
 		photo_url,
-		phone,
-		email,
-		login,
-		password,
-		active,
+		COALESCE(phone, ''),
+		COALESCE(email, ''),
+		COALESCE(login, ''),
+		state,
+		state_reason,
+		TO_CHAR(suspended_until, ` + config.DatabaseQueryTimeLayout + `) AS suspended_until,
+		TO_CHAR(deleted_at, ` + config.DatabaseQueryTimeLayout + `) AS deleted_at,
+		TO_CHAR(email_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS email_verified_at,
+		TO_CHAR(phone_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS phone_verified_at,
 		expires_at,
 		created_at,
 		updated_at
 	FROM
 		"user"`
-	filter := " WHERE 1=1"
-	order := " ORDER BY created_at"
-	arrangement := " DESC"
 	offset := " OFFSET 0"
 	limit := " LIMIT 10"
 
-	if len(queryParam.Search) > 0 {
-		params["search"] = queryParam.Search
-		filter += " AND ((name || phone || email || login) ILIKE ('%' || :search || '%'))"
-	}
-
-	if len(queryParam.ClientPlatformId) > 0 {
-		params["client_platform_id"] = queryParam.ClientPlatformId
-		filter += " AND client_platform_id = :client_platform_id"
-	}
-	if len(queryParam.ProjectId) > 0 {
-		params["project_id"] = queryParam.ProjectId
-		filter += " AND project_id = :project_id"
-	}
-
-	if len(queryParam.ClientTypeId) > 0 {
-		params["client_type_id"] = queryParam.ClientTypeId
-		filter += " AND client_type_id = :client_type_id"
+	filter, order, err := r.listFilter(ctx, queryParam, params)
+	if err != nil {
+		return res, err
 	}
 
 	if queryParam.Offset > 0 {
@@ -279,7 +303,7 @@ func (r *userRepo) GetList(ctx context.Context, queryParam *pb.GetUserListReques
 		return res, err
 	}
 
-	q := query + filter + order + arrangement + offset + limit
+	q := query + filter + order + offset + limit
 
 	q, arr = helper.ReplaceQueryParams(q, params)
 	rows, err := r.db.Query(ctx, q, arr...)
@@ -291,10 +315,14 @@ func (r *userRepo) GetList(ctx context.Context, queryParam *pb.GetUserListReques
 	for rows.Next() {
 		obj := &pb.User{}
 		var (
-			active    sql.NullInt32
-			expiresAt sql.NullString
-			createdAt sql.NullString
-			updatedAt sql.NullString
+			state           string
+			suspendedUntil  sql.NullString
+			deletedAt       sql.NullString
+			emailVerifiedAt sql.NullString
+			phoneVerifiedAt sql.NullString
+			expiresAt       sql.NullString
+			createdAt       sql.NullString
+			updatedAt       sql.NullString
 		)
 
 		err = rows.Scan(
@@ -308,8 +336,12 @@ func (r *userRepo) GetList(ctx context.Context, queryParam *pb.GetUserListReques
 			&obj.Phone,
 			&obj.Email,
 			&obj.Login,
-			&obj.Password,
-			&active,
+			&state,
+			&obj.StateReason,
+			&suspendedUntil,
+			&deletedAt,
+			&emailVerifiedAt,
+			&phoneVerifiedAt,
 			&expiresAt,
 			&createdAt,
 			&updatedAt,
@@ -319,9 +351,8 @@ func (r *userRepo) GetList(ctx context.Context, queryParam *pb.GetUserListReques
 			return res, err
 		}
 
-		if active.Valid {
-			obj.Active = active.Int32
-		}
+		setUserState(obj, state, suspendedUntil, deletedAt)
+		setContactVerification(obj, emailVerifiedAt, phoneVerifiedAt)
 
 		if expiresAt.Valid {
 			obj.ExpiresAt = expiresAt.String
@@ -341,6 +372,81 @@ func (r *userRepo) GetList(ctx context.Context, queryParam *pb.GetUserListReques
 	return res, nil
 }
 
+// listFilter builds the conditions and the ordering shared by GetList and GetExportList
+func (r *userRepo) listFilter(ctx context.Context, queryParam *pb.GetUserListRequest, params map[string]interface{}) (filter, order string, err error) {
+	filter = " WHERE deleted_at IS NULL"
+	order = " ORDER BY created_at"
+	arrangement := " DESC"
+
+	if len(queryParam.Search) > 0 {
+		params["search"] = queryParam.Search
+		filter += " AND ((name || phone || email || login) ILIKE ('%' || :search || '%'))"
+	}
+
+	if len(queryParam.ClientPlatformId) > 0 {
+		params["client_platform_id"] = queryParam.ClientPlatformId
+		filter += " AND client_platform_id = :client_platform_id"
+	}
+	if len(queryParam.ProjectId) > 0 {
+		params["project_id"] = queryParam.ProjectId
+		filter += " AND project_id = :project_id"
+	}
+
+	if len(queryParam.ClientTypeId) > 0 {
+		params["client_type_id"] = queryParam.ClientTypeId
+		filter += " AND client_type_id = :client_type_id"
+	}
+
+	if len(queryParam.UserInfoFilters) > 0 || len(queryParam.UserInfoOrderBy) > 0 {
+		fields, err := NewUserInfoFieldRepo(r.db).GetListByClientTypeID(ctx, queryParam.ClientTypeId)
+		if err != nil {
+			return "", "", err
+		}
+
+		fieldMap := make(map[string]*pb.UserInfoField, len(fields))
+		for _, f := range fields {
+			fieldMap[f.FieldName] = f
+		}
+
+		conditions := []string{}
+		for i, userInfoFilter := range queryParam.UserInfoFilters {
+			f, ok := fieldMap[userInfoFilter.FieldName]
+			if !ok {
+				return "", "", fmt.Errorf("user info field %s is not defined", userInfoFilter.FieldName)
+			}
+
+			condition, err := userInfoCondition(f, userInfoFilter, fmt.Sprintf("user_info_%d", i), params)
+			if err != nil {
+				return "", "", err
+			}
+
+			conditions = append(conditions, condition)
+		}
+
+		if len(conditions) > 0 {
+			filter += ` AND id IN (SELECT user_id FROM "user_info" WHERE ` + strings.Join(conditions, " AND ") + `)`
+		}
+
+		if len(queryParam.UserInfoOrderBy) > 0 {
+			if _, ok := fieldMap[queryParam.UserInfoOrderBy]; !ok {
+				return "", "", fmt.Errorf("user info field %s is not defined", queryParam.UserInfoOrderBy)
+			}
+
+			order = ` ORDER BY (SELECT ` + userInfoValue("data", queryParam.UserInfoOrderBy) + ` FROM "user_info" WHERE user_id = "user".id)`
+		}
+	}
+
+	if strings.ToUpper(queryParam.Arrangement) == "ASC" {
+		arrangement = " ASC"
+	}
+
+	if len(queryParam.UserInfoOrderBy) > 0 {
+		arrangement += " NULLS LAST, created_at DESC"
+	}
+
+	return filter, order + arrangement + ", id", nil
+}
+
 func (r *userRepo) Update(ctx context.Context, entity *pb.UpdateUserRequest) (rowsAffected int64, err error) {
 	query := `UPDATE "user" SET
 		project_id = :project_id,
@@ -349,14 +455,16 @@ func (r *userRepo) Update(ctx context.Context, entity *pb.UpdateUserRequest) (ro
 		role_id = :role_id,
 		name = :name,
 		photo_url = :photo_url,
-		phone = :phone,
-		email = :email,
-		login = :login,
-		active = :active,
+		phone = NULLIF(:phone, ''),
+		email = NULLIF(:email, ''),
+		login = NULLIF(:login, ''),
+		phone_verified_at = CASE WHEN phone = :phone THEN phone_verified_at ELSE NULL END,
+		email_verified_at = CASE WHEN email = :email THEN email_verified_at ELSE NULL END,
+		expiry_notified_at = CASE WHEN expires_at = :expires_at THEN expiry_notified_at ELSE NULL END,
 		expires_at = :expires_at,
 		updated_at = now()
 	WHERE
-		id = :id`
+		id = :id AND deleted_at IS NULL`
 
 	params := map[string]interface{}{
 		"id":                 entity.Id,
@@ -369,7 +477,6 @@ func (r *userRepo) Update(ctx context.Context, entity *pb.UpdateUserRequest) (ro
 		"phone":              entity.Phone,
 		"email":              entity.Email,
 		"login":              entity.Login,
-		"active":             entity.Active,
 		"expires_at":         entity.ExpiresAt,
 	}
 
@@ -384,21 +491,269 @@ func (r *userRepo) Update(ctx context.Context, entity *pb.UpdateUserRequest) (ro
 	return rowsAffected, err
 }
 
+// Delete marks the user deleted and revokes its sessions, the row is kept to be restored until
+// the retention passes and PurgeDeleted anonymizes it
 func (r *userRepo) Delete(ctx context.Context, pKey *pb.UserPrimaryKey) (rowsAffected int64, err error) {
-	query := `DELETE FROM "user" WHERE id = $1`
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
 
-	result, err := r.db.Exec(ctx, query, pKey.Id)
+	var state string
+	err = tx.QueryRow(ctx, `SELECT state FROM "user" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, pKey.Id).Scan(&state)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	query := `UPDATE "user" SET
+		state = $2,
+		state_reason = $3,
+		suspended_until = NULL,
+		deleted_at = now(),
+		updated_at = now()
+	WHERE
+		id = $1`
+
+	result, err := tx.Exec(ctx, query, pKey.Id, pb.UserStates_DELETED.String(), "user is deleted")
 	if err != nil {
 		return 0, err
 	}
 
 	rowsAffected = result.RowsAffected()
 
-	return rowsAffected, err
+	err = insertStateHistory(ctx, tx, pKey.Id, state, pb.UserStates_DELETED.String(), "user is deleted")
+	if err != nil {
+		return 0, err
+	}
+
+	// deleted sessions are moved to revoked_session by the trigger, so tokens stop working at once
+	_, err = tx.Exec(ctx, `DELETE FROM "session" WHERE user_id = $1`, pKey.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, tx.Commit(ctx)
+}
+
+// Restore brings back a user deleted after the given time in the state it had before the deletion
+func (r *userRepo) Restore(ctx context.Context, entity *pb.ChangeUserStateRequest, deletedAfter string) (rowsAffected int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var state string
+	query := `SELECT
+		COALESCE((
+			SELECT from_state FROM "user_state_history"
+			WHERE user_id = "user".id AND to_state = $2
+			ORDER BY created_at DESC
+			LIMIT 1
+		), $3)
+	FROM
+		"user"
+	WHERE
+		id = $1 AND deleted_at > $4 AND anonymized_at IS NULL
+	FOR UPDATE`
+
+	err = tx.QueryRow(ctx, query,
+		entity.UserId,
+		pb.UserStates_DELETED.String(),
+		pb.UserStates_LOCKED.String(),
+		deletedAfter,
+	).Scan(&state)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// uniqueness is kept among live users only, someone may have taken the contacts meanwhile
+	var taken bool
+	query = `SELECT EXISTS (
+		SELECT 1 FROM "user" AS o, "user" AS u
+		WHERE
+			u.id = $1 AND o.id <> u.id AND o.deleted_at IS NULL AND
+			o.project_id = u.project_id AND o.client_platform_id = u.client_platform_id AND
+			(o.phone = u.phone OR o.email = u.email OR o.login = u.login)
+	)`
+
+	err = tx.QueryRow(ctx, query, entity.UserId).Scan(&taken)
+	if err != nil {
+		return 0, err
+	}
+
+	if taken {
+		return 0, storage.ErrorUserContactTaken
+	}
+
+	query = `UPDATE "user" SET
+		state = $2,
+		state_reason = $3,
+		deleted_at = NULL,
+		updated_at = now()
+	WHERE
+		id = $1`
+
+	result, err := tx.Exec(ctx, query, entity.UserId, state, entity.Reason)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	err = insertStateHistory(ctx, tx, entity.UserId, pb.UserStates_DELETED.String(), state, entity.Reason)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, tx.Commit(ctx)
+}
+
+// PurgeDeleted anonymizes users deleted before the given time
+func (r *userRepo) PurgeDeleted(ctx context.Context, before string) (rowsAffected int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	erased, err := anonymizeUsers(ctx, tx, "deleted_at < $1 AND anonymized_at IS NULL", before)
+	if err != nil {
+		return 0, err
+	}
+
+	return erased["user"], tx.Commit(ctx)
+}
+
+// Erase deletes the user if it isn't yet and anonymizes it at once without waiting for the retention,
+// erased holds the number of rows touched in every table
+func (r *userRepo) Erase(ctx context.Context, entity *pb.DataSubjectRequest) (erased map[string]int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		state   string
+		deleted bool
+	)
+
+	query := `SELECT state, deleted_at IS NOT NULL FROM "user" WHERE id = $1 FOR UPDATE`
+
+	err = tx.QueryRow(ctx, query, entity.UserId).Scan(&state, &deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	if !deleted {
+		query = `UPDATE "user" SET
+			state = $2,
+			state_reason = '',
+			suspended_until = NULL,
+			deleted_at = now()
+		WHERE
+			id = $1`
+
+		_, err = tx.Exec(ctx, query, entity.UserId, pb.UserStates_DELETED.String())
+		if err != nil {
+			return nil, err
+		}
+
+		err = insertStateHistory(ctx, tx, entity.UserId, state, pb.UserStates_DELETED.String(), "personal data is erased")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	erased, err = anonymizeUsers(ctx, tx, "id = $1", entity.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return erased, tx.Commit(ctx)
+}
+
+// anonymizeUsers keeps the user rows for the references and the state history but removes personal
+// data, credentials and everything stored for the users in other tables, deleted sessions end up
+// in the revocation feed
+func anonymizeUsers(ctx context.Context, tx pgx.Tx, condition string, args ...interface{}) (erased map[string]int64, err error) {
+	erased = map[string]int64{}
+
+	query := `UPDATE "user" SET
+		name = '',
+		photo_url = '',
+		phone = NULL,
+		email = NULL,
+		login = NULL,
+		password = NULL,
+		email_verified_at = NULL,
+		phone_verified_at = NULL,
+		state_reason = '',
+		anonymized_at = COALESCE(anonymized_at, now()),
+		updated_at = now()
+	WHERE
+		` + condition + `
+	RETURNING id`
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	erased["user"] = int64(len(ids))
+	if len(ids) == 0 {
+		return erased, nil
+	}
+
+	for _, table := range []string{
+		"user_info",
+		"user_relation",
+		"passkey_credential",
+		"passcode",
+		"session",
+	} {
+		result, err := tx.Exec(ctx, `DELETE FROM "`+table+`" WHERE user_id = ANY($1)`, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		erased[table] = result.RowsAffected()
+	}
+
+	return erased, nil
 }
 
 func (r *userRepo) GetByUsername(ctx context.Context, username string) (res *pb.User, err error) {
 	res = &pb.User{}
+	var (
+		state           string
+		suspendedUntil  sql.NullString
+		deletedAt       sql.NullString
+		emailVerifiedAt sql.NullString
+		phoneVerifiedAt sql.NullString
+	)
 
 	query := `SELECT
 		id,
@@ -408,21 +763,28 @@ func (r *userRepo) GetByUsername(ctx context.Context, username string) (res *pb.
 		role_id,
 		name,
 		photo_url,
-		phone,
-		email,
-		login,
-		password,
-		active,
+		COALESCE(phone, ''),
+		COALESCE(email, ''),
+		COALESCE(login, ''),
+		state,
+		state_reason,
+		TO_CHAR(suspended_until, ` + config.DatabaseQueryTimeLayout + `) AS suspended_until,
+		TO_CHAR(deleted_at, ` + config.DatabaseQueryTimeLayout + `) AS deleted_at,
+		TO_CHAR(email_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS email_verified_at,
+		TO_CHAR(phone_verified_at, ` + config.DatabaseQueryTimeLayout + `) AS phone_verified_at,
 		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
 		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
 		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at
 	FROM
 		"user"
-	WHERE`
+	WHERE
+		deleted_at IS NULL AND`
 
-	if util.IsValidEmail(username) {
+	// the username is expected to be normalized, emails are lowercased and phones are in E.164,
+	// logins can't look like either of them, so only the column of the kind of the username is matched
+	if strings.Contains(username, "@") {
 		query = query + ` email = $1`
-	} else if util.IsValidPhone(username) {
+	} else if strings.HasPrefix(username, "+") {
 		query = query + ` phone = $1`
 	} else {
 		query = query + ` login = $1`
@@ -439,8 +801,12 @@ func (r *userRepo) GetByUsername(ctx context.Context, username string) (res *pb.
 		&res.Phone,
 		&res.Email,
 		&res.Login,
-		&res.Password,
-		&res.Active,
+		&state,
+		&res.StateReason,
+		&suspendedUntil,
+		&deletedAt,
+		&emailVerifiedAt,
+		&phoneVerifiedAt,
 		&res.ExpiresAt,
 		&res.CreatedAt,
 		&res.UpdatedAt,
@@ -449,9 +815,21 @@ func (r *userRepo) GetByUsername(ctx context.Context, username string) (res *pb.
 		return res, err
 	}
 
+	setUserState(res, state, suspendedUntil, deletedAt)
+	setContactVerification(res, emailVerifiedAt, phoneVerifiedAt)
+
 	return res, nil
 }
 
+// GetPasswordHash is the credential lookup of authentication, user messages never carry the hash
+func (r *userRepo) GetPasswordHash(ctx context.Context, pKey *pb.UserPrimaryKey) (hash string, err error) {
+	query := `SELECT COALESCE(password, '') FROM "user" WHERE id = $1`
+
+	err = r.db.QueryRow(ctx, query, pKey.Id).Scan(&hash)
+
+	return hash, err
+}
+
 func (r *userRepo) ResetPassword(ctx context.Context, user *pb.ResetPasswordRequest) (rowsAffected int64, err error) {
 	query := `UPDATE "user" SET
 		password = :password,
@@ -474,3 +852,853 @@ func (r *userRepo) ResetPassword(ctx context.Context, user *pb.ResetPasswordRequ
 
 	return rowsAffected, err
 }
+
+// UpdateState moves the user from one state to another and records the transition,
+// nothing is changed if the user is not in from_state anymore
+func (r *userRepo) UpdateState(ctx context.Context, entity *pb.UpdateUserStateRequest) (rowsAffected int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var suspendedUntil *string
+	if len(entity.SuspendedUntil) > 0 {
+		suspendedUntil = &entity.SuspendedUntil
+	}
+
+	var changedBy *string
+	if len(entity.ChangedBy) > 0 {
+		changedBy = &entity.ChangedBy
+	}
+
+	query := `UPDATE "user" SET
+		state = $3,
+		state_reason = $4,
+		suspended_until = $5,
+		updated_at = now()
+	WHERE
+		id = $1 AND state = $2`
+
+	result, err := tx.Exec(ctx, query,
+		entity.UserId,
+		entity.FromState.String(),
+		entity.ToState.String(),
+		entity.Reason,
+		suspendedUntil,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+	if rowsAffected <= 0 {
+		return 0, nil
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return 0, err
+	}
+
+	query = `INSERT INTO "user_state_history" (
+		id,
+		user_id,
+		from_state,
+		to_state,
+		reason,
+		suspended_until,
+		changed_by
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5,
+		$6,
+		$7
+	)`
+
+	_, err = tx.Exec(ctx, query,
+		id.String(),
+		entity.UserId,
+		entity.FromState.String(),
+		entity.ToState.String(),
+		entity.Reason,
+		suspendedUntil,
+		changedBy,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, tx.Commit(ctx)
+}
+
+func (r *userRepo) GetStateHistory(ctx context.Context, pKey *pb.UserPrimaryKey) (res *pb.GetUserStateHistoryResponse, err error) {
+	res = &pb.GetUserStateHistoryResponse{}
+
+	query := `SELECT
+		id,
+		user_id,
+		from_state,
+		to_state,
+		reason,
+		TO_CHAR(suspended_until, ` + config.DatabaseQueryTimeLayout + `) AS suspended_until,
+		changed_by,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at
+	FROM
+		"user_state_history"
+	WHERE
+		user_id = $1
+	ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, pKey.Id)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &pb.UserStateHistory{}
+		var (
+			fromState      string
+			toState        string
+			suspendedUntil sql.NullString
+			changedBy      sql.NullString
+		)
+
+		err = rows.Scan(
+			&obj.Id,
+			&obj.UserId,
+			&fromState,
+			&toState,
+			&obj.Reason,
+			&suspendedUntil,
+			&changedBy,
+			&obj.CreatedAt,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		obj.FromState = pb.UserStates(pb.UserStates_value[fromState])
+		obj.ToState = pb.UserStates(pb.UserStates_value[toState])
+
+		if suspendedUntil.Valid {
+			obj.SuspendedUntil = suspendedUntil.String
+		}
+
+		if changedBy.Valid {
+			obj.ChangedBy = changedBy.String
+		}
+
+		res.History = append(res.History, obj)
+	}
+
+	return res, nil
+}
+
+// ExpirePassed moves active users whose expires_at has passed to EXPIRED
+func (r *userRepo) ExpirePassed(ctx context.Context) (rowsAffected int64, err error) {
+	return r.updateStates(ctx, "expires_at < now()", pb.UserStates_ACTIVE, pb.UserStates_EXPIRED, "expires_at has passed")
+}
+
+// EndSuspensions moves users whose temporary suspension has run out back to ACTIVE
+func (r *userRepo) EndSuspensions(ctx context.Context) (rowsAffected int64, err error) {
+	return r.updateStates(ctx, "suspended_until < now()", pb.UserStates_SUSPENDED, pb.UserStates_ACTIVE, "suspension has ended")
+}
+
+// updateStates is the bulk version of UpdateState used by the scheduler, transitions are recorded without changed_by
+func (r *userRepo) updateStates(ctx context.Context, condition string, from, to pb.UserStates, reason string) (rowsAffected int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE "user" SET
+		state = $2,
+		state_reason = $3,
+		suspended_until = NULL,
+		updated_at = now()
+	WHERE
+		state = $1 AND ` + condition + `
+	RETURNING id`
+
+	rows, err := tx.Query(ctx, query, from.String(), to.String(), reason)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	query = `INSERT INTO "user_state_history" (
+		id,
+		user_id,
+		from_state,
+		to_state,
+		reason
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5
+	)`
+
+	for _, userID := range ids {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(ctx, query, id.String(), userID, from.String(), to.String(), reason)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if to != pb.UserStates_ACTIVE {
+		_, err = tx.Exec(ctx, `DELETE FROM "session" WHERE user_id = ANY($1)`, ids)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(ids)), tx.Commit(ctx)
+}
+
+// GetListToNotifyExpiry returns active users expiring before the given time who haven't been notified yet
+func (r *userRepo) GetListToNotifyExpiry(ctx context.Context, before string) (res []*pb.User, err error) {
+	res = []*pb.User{}
+
+	query := `SELECT
+		id,
+		name,
+		COALESCE(phone, ''),
+		COALESCE(email, ''),
+		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at
+	FROM
+		"user"
+	WHERE
+		state = $1 AND expires_at > now() AND expires_at < $2 AND expiry_notified_at IS NULL`
+
+	rows, err := r.db.Query(ctx, query, pb.UserStates_ACTIVE.String(), before)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &pb.User{}
+
+		err = rows.Scan(
+			&obj.Id,
+			&obj.Name,
+			&obj.Phone,
+			&obj.Email,
+			&obj.ExpiresAt,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+// SetContactVerified marks the contact verified if it's still the one the passcode was sent to
+func (r *userRepo) SetContactVerified(ctx context.Context, userID string, contact pb.ConfirmStrategies, target string) (rowsAffected int64, err error) {
+	var query string
+	switch contact {
+	case pb.ConfirmStrategies_EMAIL:
+		query = `UPDATE "user" SET email_verified_at = now() WHERE id = $1 AND email = $2`
+	case pb.ConfirmStrategies_PHONE:
+		query = `UPDATE "user" SET phone_verified_at = now() WHERE id = $1 AND phone = $2`
+	default:
+		return 0, fmt.Errorf("unknown contact %s", contact)
+	}
+
+	result, err := r.db.Exec(ctx, query, userID, target)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+// ChangeContact replaces the email or phone with a value confirmed by a passcode, so it's verified at once
+func (r *userRepo) ChangeContact(ctx context.Context, userID string, contact pb.ConfirmStrategies, value string) (rowsAffected int64, err error) {
+	var query string
+	switch contact {
+	case pb.ConfirmStrategies_EMAIL:
+		query = `UPDATE "user" SET email = $2, email_verified_at = now(), updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
+	case pb.ConfirmStrategies_PHONE:
+		query = `UPDATE "user" SET phone = $2, phone_verified_at = now(), updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
+	default:
+		return 0, fmt.Errorf("unknown contact %s", contact)
+	}
+
+	result, err := r.db.Exec(ctx, query, userID, value)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+// ChangeLogin sets the login unless another live user of the project and client platform has it
+// as the login, the email or the phone, since any of them can be given as the username
+func (r *userRepo) ChangeLogin(ctx context.Context, userID, login string) (rowsAffected int64, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var taken bool
+	query := `SELECT EXISTS (
+		SELECT 1 FROM "user" AS o, "user" AS u
+		WHERE
+			u.id = $1 AND o.id <> u.id AND o.deleted_at IS NULL AND
+			o.project_id = u.project_id AND o.client_platform_id = u.client_platform_id AND
+			(o.login = $2 OR o.email = $2 OR o.phone = $2)
+	)`
+
+	err = tx.QueryRow(ctx, query, userID, login).Scan(&taken)
+	if err != nil {
+		return 0, err
+	}
+
+	if taken {
+		return 0, storage.ErrorUserContactTaken
+	}
+
+	query = `UPDATE "user" SET login = $2, updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := tx.Exec(ctx, query, userID, login)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, tx.Commit(ctx)
+}
+
+func (r *userRepo) SetExpiryNotifiedAt(ctx context.Context, pKey *pb.UserPrimaryKey) (rowsAffected int64, err error) {
+	query := `UPDATE "user" SET expiry_notified_at = now() WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+func setContactVerification(user *pb.User, emailVerifiedAt, phoneVerifiedAt sql.NullString) {
+	if emailVerifiedAt.Valid {
+		user.EmailVerifiedAt = emailVerifiedAt.String
+	}
+
+	if phoneVerifiedAt.Valid {
+		user.PhoneVerifiedAt = phoneVerifiedAt.String
+	}
+}
+
+// insertStateHistory records a transition made by the service itself rather than by an admin
+func insertStateHistory(ctx context.Context, tx pgx.Tx, userID, from, to, reason string) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO "user_state_history" (
+		id,
+		user_id,
+		from_state,
+		to_state,
+		reason
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4,
+		$5
+	)`
+
+	_, err = tx.Exec(ctx, query, id.String(), userID, from, to, reason)
+
+	return err
+}
+
+func setUserState(user *pb.User, state string, suspendedUntil, deletedAt sql.NullString) {
+	user.State = pb.UserStates(pb.UserStates_value[state])
+
+	if suspendedUntil.Valid {
+		user.SuspendedUntil = suspendedUntil.String
+	}
+
+	if deletedAt.Valid {
+		user.DeletedAt = deletedAt.String
+	}
+}
+
+// userInfoCondition turns the filter into a condition on user_info.data, equality, arrays and
+// in-lists use containment to be served by the GIN index of the column, ranges and substrings use
+// the expression index of searchable fields
+func userInfoCondition(f *pb.UserInfoField, filter *pb.UserInfoFilter, name string, params map[string]interface{}) (string, error) {
+	value := userInfoValue("data", f.FieldName)
+
+	contains := func(name string, v interface{}) (string, error) {
+		data, err := json.Marshal(map[string]interface{}{f.FieldName: v})
+		if err != nil {
+			return "", err
+		}
+
+		params[name] = string(data)
+
+		return "data @> CAST(:" + name + " AS JSONB)", nil
+	}
+
+	switch filter.Operator {
+	case pb.UserInfoFilterOperators_EQ:
+		return contains(name, filter.Value.AsInterface())
+	case pb.UserInfoFilterOperators_GT,
+		pb.UserInfoFilterOperators_GTE,
+		pb.UserInfoFilterOperators_LT,
+		pb.UserInfoFilterOperators_LTE:
+		data, err := json.Marshal(filter.Value.AsInterface())
+		if err != nil {
+			return "", err
+		}
+
+		operators := map[pb.UserInfoFilterOperators]string{
+			pb.UserInfoFilterOperators_GT:  ">",
+			pb.UserInfoFilterOperators_GTE: ">=",
+			pb.UserInfoFilterOperators_LT:  "<",
+			pb.UserInfoFilterOperators_LTE: "<=",
+		}
+
+		params[name] = string(data)
+		params[name+"_type"] = jsonbType(filter.Value)
+
+		return fmt.Sprintf("(jsonb_typeof(%s) = :%s_type AND %s %s CAST(:%s AS JSONB))", value, name, value, operators[filter.Operator], name), nil
+	case pb.UserInfoFilterOperators_CONTAINS:
+		if f.FieldType == userinfo.FieldTypeArray {
+			return contains(name, []interface{}{filter.Value.AsInterface()})
+		}
+
+		params[name] = filter.Value.GetStringValue()
+
+		return "(data ->> '" + strings.ReplaceAll(f.FieldName, "'", "''") + "') ILIKE ('%' || :" + name + " || '%')", nil
+	case pb.UserInfoFilterOperators_IN:
+		conditions := []string{}
+		for i, item := range filter.Value.GetListValue().GetValues() {
+			condition, err := contains(fmt.Sprintf("%s_%d", name, i), item.AsInterface())
+			if err != nil {
+				return "", err
+			}
+
+			conditions = append(conditions, condition)
+		}
+
+		if len(conditions) == 0 {
+			return "", fmt.Errorf("user info filter %s: IN requires a non-empty list", f.FieldName)
+		}
+
+		return "(" + strings.Join(conditions, " OR ") + ")", nil
+	}
+
+	return "", fmt.Errorf("user info filter %s: unknown operator %s", f.FieldName, filter.Operator)
+}
+
+func jsonbType(value *structpb.Value) string {
+	switch value.Kind.(type) {
+	case *structpb.Value_NumberValue:
+		return "number"
+	case *structpb.Value_BoolValue:
+		return "boolean"
+	}
+
+	return "string"
+}
+
+// Import upserts the rows in one transaction, every row runs in its own savepoint so a failed
+// row is reported without aborting the batch; nothing is committed on a dry run
+func (r *userRepo) Import(ctx context.Context, rows []*pb.ImportUserRow, dryRun bool) (res []*pb.ImportUserResult, err error) {
+	res = []*pb.ImportUserResult{}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, row := range rows {
+		result := &pb.ImportUserResult{
+			Row: row.Row,
+		}
+
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return res, err
+		}
+
+		result.UserId, result.Created, err = importUser(ctx, savepoint, row)
+		if err != nil {
+			result.Error = err.Error()
+			if err = savepoint.Rollback(ctx); err != nil {
+				return res, err
+			}
+		} else if err = savepoint.Commit(ctx); err != nil {
+			return res, err
+		}
+
+		res = append(res, result)
+	}
+
+	if dryRun {
+		return res, nil
+	}
+
+	return res, tx.Commit(ctx)
+}
+
+func importUser(ctx context.Context, tx pgx.Tx, row *pb.ImportUserRow) (userID string, created bool, err error) {
+	user := row.User
+
+	query := `SELECT id FROM "user"
+	WHERE
+		project_id = $1 AND
+		client_platform_id = $2 AND
+		deleted_at IS NULL AND (
+			($3 <> '' AND phone = $3) OR
+			($4 <> '' AND email = $4) OR
+			($5 <> '' AND login = $5)
+		)
+	LIMIT 2`
+
+	ids := []string{}
+	rows, err := tx.Query(ctx, query, user.ProjectId, user.ClientPlatformId, user.Phone, user.Email, user.Login)
+	if err != nil {
+		return "", false, err
+	}
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return "", false, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return "", false, err
+	}
+
+	switch len(ids) {
+	case 0:
+		roleID, expiresAt := user.RoleId, user.ExpiresAt
+		if len(roleID) == 0 {
+			roleID = row.DefaultRoleId
+		}
+
+		if len(expiresAt) == 0 {
+			expiresAt = row.DefaultExpiresAt
+		}
+
+		if len(expiresAt) == 0 {
+			return "", false, errors.New("expires_at is required for new users")
+		}
+
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return "", false, err
+		}
+		userID, created = id.String(), true
+
+		query = `INSERT INTO "user" (
+			id,
+			project_id,
+			client_platform_id,
+			client_type_id,
+			role_id,
+			name,
+			photo_url,
+			phone,
+			email,
+			login,
+			password,
+			state,
+			expires_at
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			NULLIF($8, ''),
+			NULLIF($9, ''),
+			NULLIF($10, ''),
+			$11,
+			$12,
+			$13
+		)`
+
+		_, err = tx.Exec(ctx, query,
+			userID,
+			user.ProjectId,
+			user.ClientPlatformId,
+			user.ClientTypeId,
+			roleID,
+			user.Name,
+			user.PhotoUrl,
+			user.Phone,
+			user.Email,
+			user.Login,
+			user.Password,
+			user.State.String(),
+			expiresAt,
+		)
+		if err != nil {
+			return "", false, err
+		}
+	case 1:
+		userID = ids[0]
+
+		query = `UPDATE "user" SET
+			client_type_id = COALESCE(CAST(NULLIF($2, '') AS UUID), client_type_id),
+			role_id = COALESCE(CAST(NULLIF($3, '') AS UUID), role_id),
+			name = COALESCE(NULLIF($4, ''), name),
+			photo_url = COALESCE(NULLIF($5, ''), photo_url),
+			phone = COALESCE(NULLIF($6, ''), phone),
+			email = COALESCE(NULLIF($7, ''), email),
+			phone_verified_at = CASE WHEN $6 IN ('', phone) THEN phone_verified_at ELSE NULL END,
+			email_verified_at = CASE WHEN $7 IN ('', email) THEN email_verified_at ELSE NULL END,
+			login = COALESCE(NULLIF($8, ''), login),
+			password = COALESCE(NULLIF($9, ''), password),
+			expiry_notified_at = CASE WHEN $10 = '' THEN expiry_notified_at ELSE NULL END,
+			expires_at = COALESCE(CAST(NULLIF($10, '') AS TIMESTAMP), expires_at),
+			updated_at = now()
+		WHERE
+			id = $1`
+
+		_, err = tx.Exec(ctx, query,
+			userID,
+			user.ClientTypeId,
+			user.RoleId,
+			user.Name,
+			user.PhotoUrl,
+			user.Phone,
+			user.Email,
+			user.Login,
+			user.Password,
+			user.ExpiresAt,
+		)
+		if err != nil {
+			return "", false, err
+		}
+	default:
+		return "", false, errors.New("phone, email and login match different users")
+	}
+
+	if row.UserInfo != nil && len(row.UserInfo.Fields) > 0 {
+		data, err := protojson.Marshal(row.UserInfo)
+		if err != nil {
+			return "", false, err
+		}
+
+		query = `INSERT INTO "user_info" (
+			user_id,
+			data
+		) VALUES (
+			$1,
+			$2
+		) ON CONFLICT (
+			user_id
+		) DO UPDATE SET data = COALESCE("user_info".data, '{}') || EXCLUDED.data, updated_at = NOW()`
+
+		_, err = tx.Exec(ctx, query, userID, data)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	for _, relationID := range row.RelationIds {
+		query = `INSERT INTO "user_relation" (
+			user_id,
+			relation_id
+		) VALUES (
+			$1,
+			$2
+		) ON CONFLICT DO NOTHING`
+
+		_, err = tx.Exec(ctx, query, userID, relationID)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	return userID, created, nil
+}
+
+// GetExportList returns a page of the users matching the filters ordered by created_at and id in the arrangement
+// of queryParam, the page starts after the user of afterCreatedAt and afterID when they are given. lastCreatedAt
+// is the created_at of the last user in full precision, to start the next page from, the password is never selected
+func (r *userRepo) GetExportList(ctx context.Context, queryParam *pb.GetUserListRequest, afterCreatedAt, afterID string) (res []*pb.ExportedUser, lastCreatedAt string, err error) {
+	res = []*pb.ExportedUser{}
+	params := make(map[string]interface{})
+	query := `SELECT
+		id,
+		project_id,
+		client_platform_id,
+		client_type_id,
+		COALESCE(role_id::text, ''),
+		COALESCE((SELECT name FROM "role" WHERE "role".id = "user".role_id), ''),
+		name,
+		photo_url,
+		COALESCE(phone, ''),
+		COALESCE(email, ''),
+		COALESCE(login, ''),
+		state,
+		state_reason,
+		TO_CHAR(expires_at, ` + config.DatabaseQueryTimeLayout + `) AS expires_at,
+		TO_CHAR(created_at, ` + config.DatabaseQueryTimeLayout + `) AS created_at,
+		TO_CHAR(updated_at, ` + config.DatabaseQueryTimeLayout + `) AS updated_at,
+		ARRAY(
+			SELECT relation_id::text FROM "user_relation"
+			WHERE user_id = "user".id ORDER BY relation_id
+		),
+		ARRAY(
+			SELECT r.name FROM "user_relation" ur JOIN "relation" r ON r.id = ur.relation_id
+			WHERE ur.user_id = "user".id ORDER BY ur.relation_id
+		),
+		(SELECT data FROM "user_info" WHERE user_id = "user".id),
+		created_at::TEXT
+	FROM
+		"user"`
+	limit := ""
+
+	filter, _, err := r.listFilter(ctx, queryParam, params)
+	if err != nil {
+		return res, "", err
+	}
+
+	// keyset pagination, so users created or deleted during an export don't shift the pages
+	comparison, order := ">", " ORDER BY created_at ASC, id ASC"
+	if strings.ToUpper(queryParam.Arrangement) != "ASC" {
+		comparison, order = "<", " ORDER BY created_at DESC, id DESC"
+	}
+
+	if len(afterCreatedAt) > 0 {
+		params["after_created_at"] = afterCreatedAt
+		params["after_id"] = afterID
+		filter += " AND (created_at, id) " + comparison + " (CAST(:after_created_at AS TIMESTAMP), CAST(:after_id AS UUID))"
+	}
+
+	if queryParam.Limit > 0 {
+		params["limit"] = queryParam.Limit
+		limit = " LIMIT :limit"
+	}
+
+	q, arr := helper.ReplaceQueryParams(query+filter+order+limit, params)
+	rows, err := r.db.Query(ctx, q, arr...)
+	if err != nil {
+		return res, "", err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &pb.ExportedUser{}
+		var (
+			state         string
+			expiresAt     sql.NullString
+			createdAt     sql.NullString
+			updatedAt     sql.NullString
+			relationIDs   []string
+			relationNames []string
+			userInfo      []byte
+		)
+
+		err = rows.Scan(
+			&obj.Id,
+			&obj.ProjectId,
+			&obj.ClientPlatformId,
+			&obj.ClientTypeId,
+			&obj.RoleId,
+			&obj.RoleName,
+			&obj.Name,
+			&obj.PhotoUrl,
+			&obj.Phone,
+			&obj.Email,
+			&obj.Login,
+			&state,
+			&obj.StateReason,
+			&expiresAt,
+			&createdAt,
+			&updatedAt,
+			&relationIDs,
+			&relationNames,
+			&userInfo,
+			&lastCreatedAt,
+		)
+		if err != nil {
+			return res, "", err
+		}
+
+		obj.State = pb.UserStates(pb.UserStates_value[state])
+		obj.ExpiresAt = expiresAt.String
+		obj.CreatedAt = createdAt.String
+		obj.UpdatedAt = updatedAt.String
+
+		for i, id := range relationIDs {
+			relation := &pb.Relation{Id: id}
+			if i < len(relationNames) {
+				relation.Name = relationNames[i]
+			}
+			obj.Relations = append(obj.Relations, relation)
+		}
+
+		if userInfo != nil {
+			obj.UserInfo = &structpb.Struct{}
+			if err = protojson.Unmarshal(userInfo, obj.UserInfo); err != nil {
+				return res, "", err
+			}
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, lastCreatedAt, rows.Err()
+}
@@ -2,11 +2,12 @@ package postgres
 
 import (
 	"context"
-	"encoding/json"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/storage"
 
 	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type userInfoRepo struct {
@@ -20,7 +21,7 @@ func NewUserInfoRepo(db *pgxpool.Pool) storage.UserInfoRepoI {
 }
 
 func (r *userInfoRepo) Upsert(ctx context.Context, entity *pb.UpsertUserInfoRequest) (pKey *pb.UserInfoPrimaryKey, err error) {
-	data, err := json.Marshal(entity.Data)
+	data, err := protojson.Marshal(entity.Data)
 	if err != nil {
 		return pKey, err
 	}
@@ -67,9 +68,12 @@ func (r *userInfoRepo) GetByPK(ctx context.Context, pKey *pb.UserInfoPrimaryKey)
 		return res, err
 	}
 
-	err = json.Unmarshal(data, &res.Data)
-	if err != nil {
-		return res, err
+	res.Data = &structpb.Struct{}
+	if data != nil {
+		err = protojson.Unmarshal(data, res.Data)
+		if err != nil {
+			return res, err
+		}
 	}
 
 	return res, nil
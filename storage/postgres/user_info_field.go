@@ -2,12 +2,20 @@ package postgres
 
 import (
 	"context"
+	"crypto/md5"
+	"fmt"
+	"strings"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
 	"upm/udevs_go_auth_service/pkg/helper"
+	"upm/udevs_go_auth_service/pkg/userinfo"
 	"upm/udevs_go_auth_service/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type userInfoFieldRepo struct {
@@ -26,13 +34,27 @@ func (r *userInfoFieldRepo) Add(ctx context.Context, entity *pb.AddUserInfoField
 		client_type_id,
 		field_name,
 		field_type,
-		data_type
+		data_type,
+		required,
+		enum_values,
+		pattern,
+		min_value,
+		max_value,
+		default_value,
+		searchable
 	) VALUES (
 		$1,
 		$2,
 		$3,
 		$4,
-		$5
+		$5,
+		$6,
+		$7,
+		$8,
+		$9,
+		$10,
+		$11,
+		$12
 	)`
 
 	uuid, err := uuid.NewRandom()
@@ -40,12 +62,24 @@ func (r *userInfoFieldRepo) Add(ctx context.Context, entity *pb.AddUserInfoField
 		return pKey, err
 	}
 
+	defaultValue, err := marshalDefaultValue(entity.DefaultValue)
+	if err != nil {
+		return pKey, err
+	}
+
 	_, err = r.db.Exec(ctx, query,
 		uuid.String(),
 		entity.ClientTypeId,
 		entity.FieldName,
 		entity.FieldType,
 		entity.DataType,
+		entity.Required,
+		enumValues(entity.EnumValues),
+		entity.Pattern,
+		doubleValue(entity.MinValue),
+		doubleValue(entity.MaxValue),
+		defaultValue,
+		entity.Searchable,
 	)
 
 	pKey = &pb.UserInfoFieldPrimaryKey{
@@ -56,29 +90,63 @@ func (r *userInfoFieldRepo) Add(ctx context.Context, entity *pb.AddUserInfoField
 }
 
 func (r *userInfoFieldRepo) GetByPK(ctx context.Context, pKey *pb.UserInfoFieldPrimaryKey) (res *pb.UserInfoField, err error) {
-	res = &pb.UserInfoField{}
 	query := `SELECT
 		id,
 		client_type_id,
 		field_name,
 		field_type,
-		data_type
+		data_type,
+		required,
+		enum_values,
+		pattern,
+		min_value,
+		max_value,
+		default_value,
+		searchable
 	FROM
 		"user_info_field"
 	WHERE
 		id = $1`
 
-	err = r.db.QueryRow(ctx, query, pKey.Id).Scan(
-		&res.Id,
-		&res.ClientTypeId,
-		&res.FieldName,
-		&res.FieldType,
-		&res.DataType,
-	)
+	return scanUserInfoField(r.db.QueryRow(ctx, query, pKey.Id))
+}
+
+func (r *userInfoFieldRepo) GetListByClientTypeID(ctx context.Context, clientTypeID string) (res []*pb.UserInfoField, err error) {
+	res = []*pb.UserInfoField{}
+
+	query := `SELECT
+		id,
+		client_type_id,
+		field_name,
+		field_type,
+		data_type,
+		required,
+		enum_values,
+		pattern,
+		min_value,
+		max_value,
+		default_value,
+		searchable
+	FROM
+		"user_info_field"
+	WHERE
+		client_type_id = $1
+	ORDER BY field_name`
 
+	rows, err := r.db.Query(ctx, query, clientTypeID)
 	if err != nil {
 		return res, err
 	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj, err := scanUserInfoField(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, obj)
+	}
 
 	return res, nil
 }
@@ -89,16 +157,35 @@ func (r *userInfoFieldRepo) Update(ctx context.Context, entity *pb.UpdateUserInf
 		field_name = :field_name,
 		field_type = :field_type,
 		data_type = :data_type,
+		required = :required,
+		enum_values = :enum_values,
+		pattern = :pattern,
+		min_value = :min_value,
+		max_value = :max_value,
+		default_value = :default_value,
+		searchable = :searchable,
 		updated_at = now()
 	WHERE
 		id = :id`
 
+	defaultValue, err := marshalDefaultValue(entity.DefaultValue)
+	if err != nil {
+		return 0, err
+	}
+
 	params := map[string]interface{}{
 		"id":             entity.Id,
 		"client_type_id": entity.ClientTypeId,
 		"field_name":     entity.FieldName,
 		"field_type":     entity.FieldType,
 		"data_type":      entity.DataType,
+		"required":       entity.Required,
+		"enum_values":    enumValues(entity.EnumValues),
+		"pattern":        entity.Pattern,
+		"min_value":      doubleValue(entity.MinValue),
+		"max_value":      doubleValue(entity.MaxValue),
+		"default_value":  defaultValue,
+		"searchable":     entity.Searchable,
 	}
 
 	q, arr := helper.ReplaceQueryParams(query, params)
@@ -124,3 +211,111 @@ func (r *userInfoFieldRepo) Remove(ctx context.Context, pKey *pb.UserInfoFieldPr
 
 	return rowsAffected, err
 }
+
+// SyncSearchIndex creates the expression index of the field name while any client type
+// has it as a searchable flat field and drops it otherwise
+func (r *userInfoFieldRepo) SyncSearchIndex(ctx context.Context, fieldName string) (err error) {
+	var searchable bool
+
+	query := `SELECT EXISTS (
+		SELECT 1 FROM "user_info_field"
+		WHERE field_name = $1 AND field_type = $2 AND searchable
+	)`
+
+	err = r.db.QueryRow(ctx, query, fieldName, userinfo.FieldTypeFlat).Scan(&searchable)
+	if err != nil {
+		return err
+	}
+
+	index := userInfoSearchIndex(fieldName)
+
+	if searchable {
+		query = `CREATE INDEX CONCURRENTLY IF NOT EXISTS "` + index + `" ON "user_info" ((` + userInfoValue("data", fieldName) + `))`
+	} else {
+		query = `DROP INDEX CONCURRENTLY IF EXISTS "` + index + `"`
+	}
+
+	_, err = r.db.Exec(ctx, query)
+
+	return err
+}
+
+func scanUserInfoField(row pgx.Row) (res *pb.UserInfoField, err error) {
+	res = &pb.UserInfoField{}
+
+	var (
+		minValue     *float64
+		maxValue     *float64
+		defaultValue []byte
+	)
+
+	err = row.Scan(
+		&res.Id,
+		&res.ClientTypeId,
+		&res.FieldName,
+		&res.FieldType,
+		&res.DataType,
+		&res.Required,
+		&res.EnumValues,
+		&res.Pattern,
+		&minValue,
+		&maxValue,
+		&defaultValue,
+		&res.Searchable,
+	)
+	if err != nil {
+		return res, err
+	}
+
+	if minValue != nil {
+		res.MinValue = wrapperspb.Double(*minValue)
+	}
+
+	if maxValue != nil {
+		res.MaxValue = wrapperspb.Double(*maxValue)
+	}
+
+	if defaultValue != nil {
+		res.DefaultValue = &structpb.Value{}
+		err = protojson.Unmarshal(defaultValue, res.DefaultValue)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+func marshalDefaultValue(value *structpb.Value) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return protojson.Marshal(value)
+}
+
+func doubleValue(value *wrapperspb.DoubleValue) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	return &value.Value
+}
+
+func enumValues(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+func userInfoSearchIndex(fieldName string) string {
+	return fmt.Sprintf("idx_user_info_search_%x", md5.Sum([]byte(fieldName)))
+}
+
+// userInfoValue is the jsonb expression of a user_info field, it must stay the same
+// for the search indexes and the list filters to match
+func userInfoValue(column, fieldName string) string {
+	return column + " -> '" + strings.ReplaceAll(fieldName, "'", "''") + "'"
+}
@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type userPermissionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewUserPermissionRepo(db *pgxpool.Pool) storage.UserPermissionRepoI {
+	return &userPermissionRepo{
+		db: db,
+	}
+}
+
+func (r *userPermissionRepo) Create(ctx context.Context, entity *pb.GrantUserPermissionRequest) (pKey *pb.UserPermissionPrimaryKey, err error) {
+	query := `INSERT INTO "user_permission" (
+		id,
+		user_id,
+		permission_id,
+		starts_at,
+		ends_at,
+		reason,
+		granted_by,
+		permission_name
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		COALESCE($4::TIMESTAMP, now()),
+		$5,
+		$6,
+		$7,
+		(
+			WITH RECURSIVE tree AS (
+				SELECT parent_id, name::VARCHAR AS path FROM "permission" WHERE id = $3
+				UNION ALL
+				SELECT p.parent_id, p.name || '/' || tree.path FROM "permission" AS p INNER JOIN tree ON p.id = tree.parent_id
+			)
+			SELECT '/' || path FROM tree WHERE parent_id IS NULL
+		)
+	)`
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return pKey, err
+	}
+
+	var startsAt *string
+	if len(entity.StartsAt) > 0 {
+		startsAt = &entity.StartsAt
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		id.String(),
+		entity.UserId,
+		entity.PermissionId,
+		startsAt,
+		entity.EndsAt,
+		entity.Reason,
+		entity.GrantedBy,
+	)
+
+	pKey = &pb.UserPermissionPrimaryKey{
+		Id: id.String(),
+	}
+
+	return pKey, err
+}
+
+const userPermissionSelect = `SELECT
+		up.id,
+		up.user_id,
+		up.permission_id,
+		TO_CHAR(up.starts_at, ` + config.DatabaseQueryTimeLayout + `),
+		TO_CHAR(up.ends_at, ` + config.DatabaseQueryTimeLayout + `),
+		up.reason,
+		up.granted_by,
+		TO_CHAR(up.created_at, ` + config.DatabaseQueryTimeLayout + `),
+		up.permission_name,
+		p.id,
+		p.client_platform_id,
+		COALESCE(p.parent_id::VARCHAR, ''),
+		p.name,
+		p.recent_auth_minutes,
+		p.relation_scoped
+	FROM
+		"user_permission" AS up
+	INNER JOIN
+		"permission" AS p
+	ON
+		p.id = up.permission_id`
+
+func scanUserPermission(row pgx.Row) (*pb.UserPermission, error) {
+	res := &pb.UserPermission{Permission: &pb.Permission{}}
+
+	err := row.Scan(
+		&res.Id,
+		&res.UserId,
+		&res.PermissionId,
+		&res.StartsAt,
+		&res.EndsAt,
+		&res.Reason,
+		&res.GrantedBy,
+		&res.CreatedAt,
+		&res.PermissionName,
+		&res.Permission.Id,
+		&res.Permission.ClientPlatformId,
+		&res.Permission.ParentId,
+		&res.Permission.Name,
+		&res.Permission.RecentAuthMinutes,
+		&res.Permission.RelationScoped,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *userPermissionRepo) GetByPK(ctx context.Context, pKey *pb.UserPermissionPrimaryKey) (res *pb.UserPermission, err error) {
+	query := userPermissionSelect + `
+	WHERE
+		up.id = $1`
+
+	return scanUserPermission(r.db.QueryRow(ctx, query, pKey.Id))
+}
+
+// GetListByUserID returns the grants of the user that haven't ended yet, future ones included
+func (r *userPermissionRepo) GetListByUserID(ctx context.Context, userID string) (res []*pb.UserPermission, err error) {
+	res = []*pb.UserPermission{}
+	query := userPermissionSelect + `
+	WHERE
+		up.user_id = $1 AND up.ends_at > now()
+	ORDER BY up.starts_at, up.ends_at`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj, err := scanUserPermission(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+// GetInactiveForPermissions returns the grants of the permissions to the user that aren't in effect now,
+// ended ones are kept, every query of grants in effect checks the dates
+func (r *userPermissionRepo) GetInactiveForPermissions(ctx context.Context, userID string, permissionIDs []string) (res []*pb.UserPermission, err error) {
+	res = []*pb.UserPermission{}
+	query := userPermissionSelect + `
+	WHERE
+		up.user_id = $1 AND up.permission_id = ANY($2::UUID[]) AND (up.starts_at > now() OR up.ends_at <= now())
+	ORDER BY up.starts_at, up.ends_at`
+
+	rows, err := r.db.Query(ctx, query, userID, permissionIDs)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj, err := scanUserPermission(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+// GetActivePermissions returns the permissions granted to the user right now, each once with
+// the latest end of its grants
+func (r *userPermissionRepo) GetActivePermissions(ctx context.Context, userID string) (res []*pb.Permission, err error) {
+	res = []*pb.Permission{}
+	query := `SELECT
+		p.id,
+		p.client_platform_id,
+		COALESCE(p.parent_id::VARCHAR, ''),
+		COALESCE(pp.name || '/', '') || p.name,
+		p.recent_auth_minutes,
+		p.relation_scoped,
+		TO_CHAR(MAX(up.ends_at), ` + config.DatabaseQueryTimeLayout + `)
+	FROM
+		"user_permission" AS up
+	INNER JOIN
+		"permission" AS p
+	ON
+		p.id = up.permission_id
+	LEFT JOIN
+		"permission" AS pp
+	ON
+		pp.id = p.parent_id
+	WHERE
+		up.user_id = $1 AND up.starts_at <= now() AND up.ends_at > now()
+	GROUP BY p.id, pp.name
+	ORDER BY p.created_at`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		permission := &pb.Permission{}
+		err = rows.Scan(
+			&permission.Id,
+			&permission.ClientPlatformId,
+			&permission.ParentId,
+			&permission.Name,
+			&permission.RecentAuthMinutes,
+			&permission.RelationScoped,
+			&permission.GrantedUntil,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, permission)
+	}
+
+	return res, nil
+}
+
+func (r *userPermissionRepo) Delete(ctx context.Context, pKey *pb.UserPermissionPrimaryKey) (rowsAffected int64, err error) {
+	query := `DELETE FROM "user_permission" WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, pKey.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
@@ -61,6 +61,88 @@ func (r *userRelationRepo) GetByPK(ctx context.Context, pKey *pb.UserRelationPri
 	return res, nil
 }
 
+// GetListByUserID returns the relations of the user with their names and types
+func (r *userRelationRepo) GetListByUserID(ctx context.Context, userID string) (res []*pb.Relation, err error) {
+	res = []*pb.Relation{}
+	query := `SELECT
+		r.id,
+		r.client_type_id,
+		r.type,
+		r.name,
+		COALESCE(r.description, '')
+	FROM
+		"user_relation" ur
+	JOIN "relation" r ON r.id = ur.relation_id
+	WHERE
+		ur.user_id = $1
+	ORDER BY r.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &pb.Relation{}
+		var relationType string
+
+		err = rows.Scan(
+			&obj.Id,
+			&obj.ClientTypeId,
+			&relationType,
+			&obj.Name,
+			&obj.Description,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		obj.Type = pb.RelationTypes(pb.RelationTypes_value[relationType])
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+// GetEffectiveRelations returns the relations of the user and all their descendants, each once
+func (r *userRelationRepo) GetEffectiveRelations(ctx context.Context, userID string) (res []*pb.Relation, err error) {
+	res = []*pb.Relation{}
+	query := `SELECT DISTINCT
+		d.id,
+		d.client_type_id,
+		d.type,
+		d.name,
+		COALESCE(d.description, ''),
+		COALESCE(d.parent_id::VARCHAR, ''),
+		d.path
+	FROM
+		"user_relation" ur
+	JOIN "relation" r ON r.id = ur.relation_id
+	JOIN "relation" d ON d.path LIKE r.path || '%'
+	WHERE
+		ur.user_id = $1
+	ORDER BY d.path`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		relation, err := scanRelation(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, relation)
+	}
+
+	return res, rows.Err()
+}
+
 func (r *userRelationRepo) Remove(ctx context.Context, pKey *pb.UserRelationPrimaryKey) (rowsAffected int64, err error) {
 	query := `DELETE FROM
 		"user_relation"
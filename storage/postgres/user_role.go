@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"upm/udevs_go_auth_service/config"
+	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/storage"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type userRoleRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewUserRoleRepo(db *pgxpool.Pool) storage.UserRoleRepoI {
+	return &userRoleRepo{
+		db: db,
+	}
+}
+
+// Upsert assigns the role to the user, the dates of an existing assignment are replaced
+func (r *userRoleRepo) Upsert(ctx context.Context, entity *pb.AddUserRoleRequest) (err error) {
+	query := `INSERT INTO "user_role" (
+		user_id,
+		role_id,
+		starts_at,
+		ends_at
+	) VALUES (
+		$1,
+		$2,
+		$3,
+		$4
+	) ON CONFLICT (user_id, role_id) DO UPDATE SET
+		starts_at = EXCLUDED.starts_at,
+		ends_at = EXCLUDED.ends_at,
+		updated_at = now()`
+
+	var startsAt, endsAt *string
+	if len(entity.StartsAt) > 0 {
+		startsAt = &entity.StartsAt
+	}
+	if len(entity.EndsAt) > 0 {
+		endsAt = &entity.EndsAt
+	}
+
+	_, err = r.db.Exec(ctx, query,
+		entity.UserId,
+		entity.RoleId,
+		startsAt,
+		endsAt,
+	)
+
+	return err
+}
+
+const userRoleSelect = `SELECT
+		ur.user_id,
+		ur.role_id,
+		COALESCE(TO_CHAR(ur.starts_at, ` + config.DatabaseQueryTimeLayout + `), ''),
+		COALESCE(TO_CHAR(ur.ends_at, ` + config.DatabaseQueryTimeLayout + `), ''),
+		TO_CHAR(ur.created_at, ` + config.DatabaseQueryTimeLayout + `),
+		r.id,
+		r.client_type_id,
+		r.name,
+		r.client_platform_id,
+		r.project_id
+	FROM
+		"user_role" AS ur
+	INNER JOIN
+		"role" AS r
+	ON
+		r.id = ur.role_id`
+
+func scanUserRole(row pgx.Row) (*pb.UserRole, error) {
+	res := &pb.UserRole{Role: &pb.Role{}}
+
+	err := row.Scan(
+		&res.UserId,
+		&res.RoleId,
+		&res.StartsAt,
+		&res.EndsAt,
+		&res.CreatedAt,
+		&res.Role.Id,
+		&res.Role.ClientTypeId,
+		&res.Role.Name,
+		&res.Role.ClientPlatformId,
+		&res.Role.ProjectId,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *userRoleRepo) GetByPK(ctx context.Context, pKey *pb.UserRolePrimaryKey) (res *pb.UserRole, err error) {
+	query := userRoleSelect + `
+	WHERE
+		ur.user_id = $1 AND ur.role_id = $2`
+
+	return scanUserRole(r.db.QueryRow(ctx, query, pKey.UserId, pKey.RoleId))
+}
+
+// GetListByUserID returns every assignment of the user, expired and future ones included
+func (r *userRoleRepo) GetListByUserID(ctx context.Context, userID string) (res []*pb.UserRole, err error) {
+	res = []*pb.UserRole{}
+	query := userRoleSelect + `
+	WHERE
+		ur.user_id = $1
+	ORDER BY r.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj, err := scanUserRole(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+// GetInactiveByUserID returns the assignments of the user whose dates don't include the current time,
+// ended ones and ones that haven't started yet
+func (r *userRoleRepo) GetInactiveByUserID(ctx context.Context, userID string) (res []*pb.UserRole, err error) {
+	res = []*pb.UserRole{}
+	query := userRoleSelect + `
+	WHERE
+		ur.user_id = $1 AND (ur.starts_at > now() OR ur.ends_at <= now())
+	ORDER BY r.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj, err := scanUserRole(rows)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, obj)
+	}
+
+	return res, nil
+}
+
+func (r *userRoleRepo) Remove(ctx context.Context, pKey *pb.UserRolePrimaryKey) (rowsAffected int64, err error) {
+	query := `DELETE FROM
+		"user_role"
+	WHERE
+		user_id = $1 AND role_id = $2`
+
+	result, err := r.db.Exec(ctx, query, pKey.UserId, pKey.RoleId)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected = result.RowsAffected()
+
+	return rowsAffected, err
+}
+
+// GetActiveRoles returns the roles the user has right now, the primary role of user.role_id first
+// and then the assignments whose dates include the current time
+func (r *userRoleRepo) GetActiveRoles(ctx context.Context, userID string) (res []*pb.Role, err error) {
+	res = []*pb.Role{}
+	query := `SELECT
+		r.id,
+		r.client_type_id,
+		r.name,
+		r.client_platform_id,
+		r.project_id
+	FROM
+		"role" AS r
+	INNER JOIN
+		"user" AS u
+	ON
+		u.id = $1
+	LEFT JOIN
+		"user_role" AS ur
+	ON
+		ur.user_id = u.id AND ur.role_id = r.id
+	WHERE
+		r.id = u.role_id OR (
+			ur.role_id IS NOT NULL
+			AND (ur.starts_at IS NULL OR ur.starts_at <= now())
+			AND (ur.ends_at IS NULL OR ur.ends_at > now())
+		)
+	ORDER BY r.id = u.role_id DESC, r.name`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		role := &pb.Role{}
+		err = rows.Scan(
+			&role.Id,
+			&role.ClientTypeId,
+			&role.Name,
+			&role.ClientPlatformId,
+			&role.ProjectId,
+		)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, role)
+	}
+
+	return res, nil
+}
@@ -4,10 +4,18 @@ import (
 	"context"
 	"errors"
 	pb "upm/udevs_go_auth_service/genproto/auth_service"
+	"upm/udevs_go_auth_service/pkg/access"
+	"upm/udevs_go_auth_service/pkg/rbac"
 )
 
 var ErrorTheSameId = errors.New("cannot use the same uuid for 'id' and 'parent_id' fields")
 var ErrorProjectId = errors.New("not valid 'project_id'")
+var ErrorRoleCycle = errors.New("role can't inherit from itself or its descendants")
+var ErrorRoleParentPlatform = errors.New("role can inherit only from roles of its client platform")
+var ErrorRelationCycle = errors.New("relation can't be moved under itself or its descendants")
+var ErrorRelationParentClientType = errors.New("relation can be put only under relations of its client type")
+var ErrorRelationParentNotFound = errors.New("parent relation doesn't exist")
+var ErrorUserContactTaken = errors.New("phone, email or login of the user is taken by another user")
 
 type StorageI interface {
 	CloseDB()
@@ -26,6 +34,16 @@ type StorageI interface {
 	UserRelation() UserRelationRepoI
 	UserInfo() UserInfoRepoI
 	Session() SessionRepoI
+	Passcode() PasscodeRepoI
+	PasskeyCredential() PasskeyCredentialRepoI
+	PasskeyChallenge() PasskeyChallengeRepoI
+	RevokedSession() RevokedSessionRepoI
+	AdvisoryLock() AdvisoryLockRepoI
+	DataSubjectRequest() DataSubjectRequestRepoI
+	RBAC() RBACRepoI
+	UserRole() UserRoleRepoI
+	UserPermission() UserPermissionRepoI
+	DenyRule() DenyRuleRepoI
 }
 
 type ClientPlatformRepoI interface {
@@ -60,13 +78,17 @@ type RelationRepoI interface {
 	GetByPK(ctx context.Context, entity *pb.RelationPrimaryKey) (res *pb.Relation, err error)
 	Update(ctx context.Context, entity *pb.UpdateRelationRequest) (rowsAffected int64, err error)
 	Remove(ctx context.Context, entity *pb.RelationPrimaryKey) (rowsAffected int64, err error)
+	GetSubtree(ctx context.Context, pKey *pb.RelationPrimaryKey) (res []*pb.Relation, err error)
+	Move(ctx context.Context, entity *pb.MoveRelationRequest) (err error)
 }
 
 type UserInfoFieldRepoI interface {
 	Add(ctx context.Context, entity *pb.AddUserInfoFieldRequest) (pKey *pb.UserInfoFieldPrimaryKey, err error)
 	GetByPK(ctx context.Context, entity *pb.UserInfoFieldPrimaryKey) (res *pb.UserInfoField, err error)
+	GetListByClientTypeID(ctx context.Context, clientTypeID string) (res []*pb.UserInfoField, err error)
 	Update(ctx context.Context, entity *pb.UpdateUserInfoFieldRequest) (rowsAffected int64, err error)
 	Remove(ctx context.Context, entity *pb.UserInfoFieldPrimaryKey) (rowsAffected int64, err error)
+	SyncSearchIndex(ctx context.Context, fieldName string) (err error)
 }
 
 type RoleRepoI interface {
@@ -74,6 +96,9 @@ type RoleRepoI interface {
 	GetByPK(ctx context.Context, entity *pb.RolePrimaryKey) (res *pb.Role, err error)
 	GetList(ctx context.Context, entity *pb.GetRolesListRequest) (res *pb.GetRolesResponse, err error)
 	GetRoleByIdDetailed(ctx context.Context, entity *pb.RolePrimaryKey) (res *pb.GetRoleByIdResponse, err error)
+	AddParent(ctx context.Context, entity *pb.RoleParent) (err error)
+	RemoveParent(ctx context.Context, entity *pb.RoleParent) (rowsAffected int64, err error)
+	GetParents(ctx context.Context, roleID string) (res []*pb.Role, err error)
 	Update(ctx context.Context, entity *pb.UpdateRoleRequest) (rowsAffected int64, err error)
 	Remove(ctx context.Context, entity *pb.RolePrimaryKey) (rowsAffected int64, err error)
 }
@@ -86,6 +111,8 @@ type PermissionRepoI interface {
 	Delete(ctx context.Context, pKey *pb.PermissionPrimaryKey) (rowsAffected int64, err error)
 	GetListByClientPlatformId(ctx context.Context, clientPlatformID string) (res []*pb.Permission, err error)
 	GetListByRoleId(ctx context.Context, roleID string) (res []*pb.Permission, err error)
+	GetListByRoleIds(ctx context.Context, roleIDs []string) (res []*pb.Permission, err error)
+	GeneratePermission(ctx context.Context, req *pb.PermissionGenerated) (res *pb.PermissionGeneratedResponse, err error)
 }
 
 type ScopeRepoI interface {
@@ -98,7 +125,9 @@ type PermissionScopeRepoI interface {
 	Add(ctx context.Context, entity *pb.AddPermissionScopeRequest) (res *pb.PermissionScopePrimaryKey, err error)
 	Remove(ctx context.Context, entity *pb.PermissionScopePrimaryKey) (rowsAffected int64, err error)
 	GetByPK(ctx context.Context, pKey *pb.PermissionScopePrimaryKey) (res *pb.PermissionScope, err error)
-	HasAccess(ctx context.Context, roleID, clientPlatformID, path, method string) (hasAccess bool, err error)
+	GetAccessPolicy(ctx context.Context, userID string, roleIDs []string, clientPlatformID, path, method string) (policy *access.Policy, err error)
+	GetPlatformPolicy(ctx context.Context, clientPlatformID string) (policy *access.Policy, err error)
+	GetListByClientPlatformID(ctx context.Context, clientPlatformID string) (res []*pb.PermissionScope, err error)
 }
 
 type RolePermissionRepoI interface {
@@ -115,8 +144,23 @@ type UserRepoI interface {
 	GetByPK(ctx context.Context, pKey *pb.UserPrimaryKey) (res *pb.User, err error)
 	Update(ctx context.Context, entity *pb.UpdateUserRequest) (rowsAffected int64, err error)
 	Delete(ctx context.Context, pKey *pb.UserPrimaryKey) (rowsAffected int64, err error)
+	Restore(ctx context.Context, entity *pb.ChangeUserStateRequest, deletedAfter string) (rowsAffected int64, err error)
+	PurgeDeleted(ctx context.Context, before string) (rowsAffected int64, err error)
+	Erase(ctx context.Context, entity *pb.DataSubjectRequest) (erased map[string]int64, err error)
 	GetByUsername(ctx context.Context, username string) (res *pb.User, err error)
+	GetPasswordHash(ctx context.Context, pKey *pb.UserPrimaryKey) (hash string, err error)
 	ResetPassword(ctx context.Context, user *pb.ResetPasswordRequest) (rowsAffected int64, err error)
+	UpdateState(ctx context.Context, entity *pb.UpdateUserStateRequest) (rowsAffected int64, err error)
+	GetStateHistory(ctx context.Context, pKey *pb.UserPrimaryKey) (res *pb.GetUserStateHistoryResponse, err error)
+	ExpirePassed(ctx context.Context) (rowsAffected int64, err error)
+	EndSuspensions(ctx context.Context) (rowsAffected int64, err error)
+	GetListToNotifyExpiry(ctx context.Context, before string) (res []*pb.User, err error)
+	SetExpiryNotifiedAt(ctx context.Context, pKey *pb.UserPrimaryKey) (rowsAffected int64, err error)
+	SetContactVerified(ctx context.Context, userID string, contact pb.ConfirmStrategies, target string) (rowsAffected int64, err error)
+	ChangeContact(ctx context.Context, userID string, contact pb.ConfirmStrategies, value string) (rowsAffected int64, err error)
+	ChangeLogin(ctx context.Context, userID, login string) (rowsAffected int64, err error)
+	Import(ctx context.Context, rows []*pb.ImportUserRow, dryRun bool) (res []*pb.ImportUserResult, err error)
+	GetExportList(ctx context.Context, queryParam *pb.GetUserListRequest, afterCreatedAt, afterID string) (res []*pb.ExportedUser, lastCreatedAt string, err error)
 }
 
 type IntegrationRepoI interface {
@@ -130,12 +174,15 @@ type IntegrationRepoI interface {
 	GetIntegrationSessions(ctx context.Context, pKey *pb.IntegrationPrimaryKey) (res *pb.GetIntegrationSessionsResponse, err error)
 	DeleteSession(ctx context.Context, pKey *pb.GetIntegrationTokenRequest) (rowsAffected int64, err error)
 	GetIntegrationSession(ctx context.Context, req *pb.GetIntegrationTokenRequest) (res *pb.Session, err error)
+	ExpirePassed(ctx context.Context) (rowsAffected int64, err error)
 }
 
 type UserRelationRepoI interface {
 	Add(ctx context.Context, entity *pb.AddUserRelationRequest) (res *pb.UserRelationPrimaryKey, err error)
 	Remove(ctx context.Context, entity *pb.UserRelationPrimaryKey) (rowsAffected int64, err error)
 	GetByPK(ctx context.Context, pKey *pb.UserRelationPrimaryKey) (res *pb.UserRelation, err error)
+	GetListByUserID(ctx context.Context, userID string) (res []*pb.Relation, err error)
+	GetEffectiveRelations(ctx context.Context, userID string) (res []*pb.Relation, err error)
 }
 
 type UserInfoRepoI interface {
@@ -149,8 +196,83 @@ type SessionRepoI interface {
 	GetByPK(ctx context.Context, pKey *pb.SessionPrimaryKey) (res *pb.Session, err error)
 	Update(ctx context.Context, entity *pb.UpdateSessionRequest) (rowsAffected int64, err error)
 	Delete(ctx context.Context, pKey *pb.SessionPrimaryKey) (rowsAffected int64, err error)
+	UpdateLastAuthAt(ctx context.Context, pKey *pb.SessionPrimaryKey) (rowsAffected int64, err error)
+	DeleteByUserID(ctx context.Context, userID string) (rowsAffected int64, err error)
 	DeleteExpiredUserSessions(ctx context.Context, userID string) (rowsAffected int64, err error)
 	DeleteExpiredIntegrationSessions(ctx context.Context, userID string) (rowsAffected int64, err error)
 	GetSessionListByUserID(ctx context.Context, userID string) (res *pb.GetSessionListResponse, err error)
 	GetSessionListByIntegrationID(ctx context.Context, userID string) (res *pb.GetSessionListResponse, err error)
+	DeleteExpired(ctx context.Context) (rowsAffected int64, err error)
+}
+
+type PasscodeRepoI interface {
+	Create(ctx context.Context, entity *pb.CreatePasscodeRequest) (pKey *pb.PasscodePrimaryKey, err error)
+	GetByPK(ctx context.Context, pKey *pb.PasscodePrimaryKey) (res *pb.Passcode, err error)
+	UpdateState(ctx context.Context, pKey *pb.PasscodePrimaryKey, state int32) (rowsAffected int64, err error)
+	UseAttempt(ctx context.Context, pKey *pb.PasscodePrimaryKey, maxAttempts int32) (rowsAffected int64, err error)
+	GetListByUserID(ctx context.Context, userID string) (res []*pb.Passcode, err error)
+	DeleteExpired(ctx context.Context) (rowsAffected int64, err error)
+}
+
+type PasskeyCredentialRepoI interface {
+	Create(ctx context.Context, entity *pb.CreatePasskeyCredentialRequest) (pKey *pb.PasskeyCredentialPrimaryKey, err error)
+	GetByPK(ctx context.Context, pKey *pb.PasskeyCredentialPrimaryKey) (res *pb.PasskeyCredential, err error)
+	GetByCredentialID(ctx context.Context, credentialID string) (res *pb.PasskeyCredential, err error)
+	GetListByUserID(ctx context.Context, userID string) (res []*pb.PasskeyCredential, err error)
+	UpdateSignCount(ctx context.Context, pKey *pb.PasskeyCredentialPrimaryKey, signCount int64) (rowsAffected int64, err error)
+	Delete(ctx context.Context, pKey *pb.PasskeyCredentialPrimaryKey) (rowsAffected int64, err error)
+}
+
+type PasskeyChallengeRepoI interface {
+	Create(ctx context.Context, entity *pb.CreatePasskeyChallengeRequest) (pKey *pb.PasskeyChallengePrimaryKey, err error)
+	GetByPK(ctx context.Context, pKey *pb.PasskeyChallengePrimaryKey) (res *pb.PasskeyChallenge, err error)
+	Delete(ctx context.Context, pKey *pb.PasskeyChallengePrimaryKey) (rowsAffected int64, err error)
+	DeleteExpired(ctx context.Context) (rowsAffected int64, err error)
+}
+
+type RevokedSessionRepoI interface {
+	GetList(ctx context.Context, queryParam *pb.GetRevokedSessionListRequest) (res *pb.GetRevokedSessionListResponse, err error)
+	DeleteExpired(ctx context.Context) (rowsAffected int64, err error)
+}
+
+type AdvisoryLockRepoI interface {
+	// Do runs fn only if the lock is free, so a job scheduled on every replica runs on one of them
+	Do(ctx context.Context, key string, fn func(ctx context.Context) error) (acquired bool, err error)
+}
+
+type DataSubjectRequestRepoI interface {
+	Create(ctx context.Context, entity *pb.DataSubjectReceipt) (err error)
+	GetByPK(ctx context.Context, id string) (res *pb.DataSubjectReceipt, err error)
+}
+
+type RBACRepoI interface {
+	GetConfig(ctx context.Context, clientPlatformID string) (res *rbac.Config, err error)
+	GetPermissionPaths(ctx context.Context, clientPlatformID string) (paths map[string]string, err error)
+	Apply(ctx context.Context, clientPlatformID string, config *rbac.Config, dryRun bool) (plan []*pb.RBACChange, err error)
+}
+
+type UserRoleRepoI interface {
+	Upsert(ctx context.Context, entity *pb.AddUserRoleRequest) (err error)
+	GetByPK(ctx context.Context, pKey *pb.UserRolePrimaryKey) (res *pb.UserRole, err error)
+	GetListByUserID(ctx context.Context, userID string) (res []*pb.UserRole, err error)
+	Remove(ctx context.Context, pKey *pb.UserRolePrimaryKey) (rowsAffected int64, err error)
+	GetActiveRoles(ctx context.Context, userID string) (res []*pb.Role, err error)
+	GetInactiveByUserID(ctx context.Context, userID string) (res []*pb.UserRole, err error)
+}
+
+type UserPermissionRepoI interface {
+	Create(ctx context.Context, entity *pb.GrantUserPermissionRequest) (pKey *pb.UserPermissionPrimaryKey, err error)
+	GetByPK(ctx context.Context, pKey *pb.UserPermissionPrimaryKey) (res *pb.UserPermission, err error)
+	GetListByUserID(ctx context.Context, userID string) (res []*pb.UserPermission, err error)
+	GetActivePermissions(ctx context.Context, userID string) (res []*pb.Permission, err error)
+	GetInactiveForPermissions(ctx context.Context, userID string, permissionIDs []string) (res []*pb.UserPermission, err error)
+	Delete(ctx context.Context, pKey *pb.UserPermissionPrimaryKey) (rowsAffected int64, err error)
+}
+
+type DenyRuleRepoI interface {
+	Create(ctx context.Context, entity *pb.CreateDenyRuleRequest) (pKey *pb.DenyRulePrimaryKey, err error)
+	GetByPK(ctx context.Context, pKey *pb.DenyRulePrimaryKey) (res *pb.DenyRule, err error)
+	GetList(ctx context.Context, queryParam *pb.GetDenyRuleListRequest) (res []*pb.DenyRule, err error)
+	GetListForSubjects(ctx context.Context, clientPlatformID, userID string, roleIDs []string) (res []*pb.DenyRule, err error)
+	Delete(ctx context.Context, pKey *pb.DenyRulePrimaryKey) (rowsAffected int64, err error)
 }
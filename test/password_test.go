@@ -0,0 +1,138 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	ht "upm/udevs_go_auth_service/api/http"
+	"upm/udevs_go_auth_service/genproto/auth_service"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	grpcHost = "localhost:9103"
+)
+
+// TestNoPasswordInResponses creates a user with a password and reads it back through the handlers
+// and the grpc service, neither the password nor its hash may show up in any of the responses
+func TestNoPasswordInResponses(t *testing.T) {
+	login := fmt.Sprintf("user%d", time.Now().UnixNano())
+	password := faker.Password()
+
+	created := &ht.Response{}
+	resp, err := PerformRequest(http.MethodPost, "/user", &auth_service.CreateUserRequest{
+		ProjectId:        "e04766bc-3228-4cd9-bd22-09e3fa27a6be",
+		ClientPlatformId: "7d4a4c38-dd84-4902-b744-0488b80a4c02",
+		ClientTypeId:     "5a3818a9-90f0-44e9-a053-3be0ba1e2c04",
+		RoleId:           "a1ca1301-4da9-424d-a9e2-578ae6dcde04",
+		Phone:            fmt.Sprintf("+99890%07d", time.Now().UnixNano()%10000000),
+		Email:            login + "@example.com",
+		Login:            login,
+		Password:         password,
+		Name:             faker.Name(),
+		State:            auth_service.UserStates_ACTIVE,
+	}, created)
+	if !assert.NoError(t, err) || !assert.Equal(t, 201, resp.StatusCode) {
+		return
+	}
+	assertNoPassword(t, "POST /user", created.Data, password)
+
+	data, ok := created.Data.(map[string]interface{})
+	if !assert.True(t, ok) {
+		return
+	}
+	userID, _ := data["id"].(string)
+
+	responses := map[string]func() (*http.Response, *ht.Response, error){
+		"GET /user/:user-id": func() (*http.Response, *ht.Response, error) {
+			res := &ht.Response{}
+			resp, err := PerformRequest(http.MethodGet, "/user/"+userID, nil, res)
+			return resp, res, err
+		},
+		"GET /user": func() (*http.Response, *ht.Response, error) {
+			res := &ht.Response{}
+			resp, err := PerformRequest(http.MethodGet, "/user?search="+login, nil, res)
+			return resp, res, err
+		},
+		"POST /login": func() (*http.Response, *ht.Response, error) {
+			res := &ht.Response{}
+			resp, err := PerformRequest(http.MethodPost, "/login", &auth_service.LoginRequest{
+				Username: login,
+				Password: password,
+			}, res)
+			return resp, res, err
+		},
+	}
+
+	for name, request := range responses {
+		resp, res, err := request()
+		if assert.NoError(t, err, name) && assert.Contains(t, []int{200, 201}, resp.StatusCode, name) {
+			assertNoPassword(t, name, res.Data, password)
+		}
+	}
+
+	conn, err := grpc.Dial(grpcHost, grpc.WithInsecure())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	user, err := auth_service.NewUserServiceClient(conn).GetUserByID(ctx, &auth_service.UserPrimaryKey{Id: userID})
+	if assert.NoError(t, err) {
+		assert.Empty(t, user.ProtoReflect().GetUnknown(), "UserService.GetUserByID")
+		assertNoPassword(t, "UserService.GetUserByID", messageJSON(t, user), password)
+	}
+
+	loginResponse, err := auth_service.NewSessionServiceClient(conn).Login(ctx, &auth_service.LoginRequest{
+		Username: login,
+		Password: password,
+	})
+	if assert.NoError(t, err) {
+		assert.Empty(t, loginResponse.User.ProtoReflect().GetUnknown(), "SessionService.Login")
+		assertNoPassword(t, "SessionService.Login", messageJSON(t, loginResponse), password)
+	}
+}
+
+// messageJSON decodes a grpc response the way handlers write it
+func messageJSON(t *testing.T, m proto.Message) interface{} {
+	data, err := protojson.Marshal(m)
+	assert.NoError(t, err)
+
+	var v interface{}
+	assert.NoError(t, json.Unmarshal(data, &v))
+
+	return v
+}
+
+// assertNoPassword fails for keys naming a password and for values carrying the password or a bcrypt hash
+func assertNoPassword(t *testing.T, name string, v interface{}, password string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if strings.Contains(strings.ToLower(key), "password") {
+				assert.Failf(t, "password in response", "%s returns %s", name, key)
+			}
+			assertNoPassword(t, fmt.Sprintf("%s.%s", name, key), value, password)
+		}
+	case []interface{}:
+		for i, value := range v {
+			assertNoPassword(t, fmt.Sprintf("%s[%d]", name, i), value, password)
+		}
+	case string:
+		if strings.Contains(v, password) || strings.Contains(v, "$2a$") || strings.Contains(v, "$2b$") {
+			assert.Failf(t, "password in response", "%s carries the password or its hash", name)
+		}
+	}
+}